@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"time"
 
 	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
 )
@@ -34,6 +35,11 @@ type Config struct {
 	// across all resources handled by this provider for situations where
 	// external systems are managing certain tags.
 	IgnoreTags *goaviatrix.IgnoreTagsConfig
+	// APIRetryCount is the number of times a controller call failing with a
+	// transient error is retried.
+	APIRetryCount int
+	// APIRetryDelay is the time to wait before retrying a controller call.
+	APIRetryDelay time.Duration
 }
 
 // wrapTransport represents an HTTP transport used for setting the user-agent
@@ -100,8 +106,11 @@ func (c *Config) Client() (*goaviatrix.Client, error) {
 
 	if client == nil || err != nil {
 		log.Printf("[ERROR] unable to create client: %s", err)
+		return client, err
 	}
-	return client, err
+	client.RetryCount = c.APIRetryCount
+	client.RetryDelay = c.APIRetryDelay
+	return client, nil
 }
 
 // mustClient asserts that the meta interface is a valid *goaviatrix.Client.
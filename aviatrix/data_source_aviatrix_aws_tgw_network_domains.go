@@ -0,0 +1,78 @@
+package aviatrix
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceAviatrixAwsTgwNetworkDomains() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceAviatrixAwsTgwNetworkDomainsRead,
+
+		Schema: map[string]*schema.Schema{
+			"tgw_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "AWS TGW name.",
+			},
+			"network_domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of Network Domains of the AWS TGW.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Network Domain name.",
+						},
+						"aviatrix_firewall": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the network domain is an aviatrix firewall domain.",
+						},
+						"native_egress": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the network domain is a native egress domain.",
+						},
+						"native_firewall": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the network domain is a native firewall domain.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAviatrixAwsTgwNetworkDomainsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := mustClient(meta)
+
+	tgwName := getString(d, "tgw_name")
+	domains, err := client.ListSecurityDomains(ctx, tgwName)
+	if err != nil {
+		return diag.Errorf("could not list network domains of AWS TGW %s: %v", tgwName, err)
+	}
+
+	networkDomains := make([]map[string]interface{}, 0, len(domains))
+	for _, domain := range domains {
+		networkDomains = append(networkDomains, map[string]interface{}{
+			"name":              domain.Name,
+			"aviatrix_firewall": domain.AviatrixFirewallDomain,
+			"native_egress":     domain.NativeEgressDomain,
+			"native_firewall":   domain.NativeFirewallDomain,
+		})
+	}
+	if err = d.Set("network_domains", networkDomains); err != nil {
+		return diag.Errorf("couldn't set network_domains: %v", err)
+	}
+	d.SetId(tgwName)
+	return nil
+}
@@ -0,0 +1,126 @@
+package aviatrix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestAccDataSourceAviatrixAwsTgwNetworkDomains_basic(t *testing.T) {
+	rName := acctest.RandString(5)
+	charset := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	tgwName := acctest.RandStringFromCharSet(5, charset) + acctest.RandString(5)
+	ndName := acctest.RandStringFromCharSet(5, charset) + acctest.RandString(5)
+	resourceName := "data.aviatrix_aws_tgw_network_domains.test"
+
+	skipAcc := os.Getenv("SKIP_DATA_AWS_TGW_NETWORK_DOMAIN")
+	if skipAcc == "yes" {
+		t.Skip("Skipping Data Source AWS TGW Network Domain tests as SKIP_DATA_AWS_TGW_NETWORK_DOMAIN is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAviatrixAwsTgwNetworkDomainsConfigBasic(rName, tgwName, ndName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tgw_name", tgwName),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "network_domains.*", map[string]string{
+						"name":              ndName,
+						"aviatrix_firewall": "false",
+						"native_egress":     "true",
+						"native_firewall":   "false",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAviatrixAwsTgwNetworkDomainsConfigBasic(rName, tgwName, ndName string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test" {
+	account_name       = "tfa-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_aws_tgw" "test" {
+	account_name       = aviatrix_account.test.account_name
+	aws_side_as_number = "64512"
+	region             = "us-west-1"
+	tgw_name           = "%s"
+}
+resource "aviatrix_aws_tgw_network_domain" "test" {
+	name          = "%s"
+	tgw_name      = aviatrix_aws_tgw.test.tgw_name
+	native_egress = true
+}
+data "aviatrix_aws_tgw_network_domains" "test" {
+	tgw_name   = aviatrix_aws_tgw.test.tgw_name
+	depends_on = [aviatrix_aws_tgw_network_domain.test]
+}
+`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		tgwName, ndName)
+}
+
+func TestDataSourceAviatrixAwsTgwNetworkDomainsRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected []interface{}
+	}{
+		{
+			name: "network domains",
+			response: `{"return": true, "results": [
+				{"name": "Default_Domain"},
+				{"name": "Shared_Service_Domain"},
+				{"name": "firewall-domain", "firewall_domain": true},
+				{"name": "egress-domain", "egress_domain": true},
+				{"name": "native-firewall-domain", "native_firewall_domain": true}
+			], "reason": ""}`,
+			expected: []interface{}{
+				map[string]interface{}{"name": "Default_Domain", "aviatrix_firewall": false, "native_egress": false, "native_firewall": false},
+				map[string]interface{}{"name": "Shared_Service_Domain", "aviatrix_firewall": false, "native_egress": false, "native_firewall": false},
+				map[string]interface{}{"name": "firewall-domain", "aviatrix_firewall": true, "native_egress": false, "native_firewall": false},
+				map[string]interface{}{"name": "egress-domain", "aviatrix_firewall": false, "native_egress": true, "native_firewall": false},
+				map[string]interface{}{"name": "native-firewall-domain", "aviatrix_firewall": false, "native_egress": false, "native_firewall": true},
+			},
+		},
+		{
+			name:     "no domains reported",
+			response: `{"return": true, "results": null, "reason": ""}`,
+			expected: []interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: tt.response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, dataSourceAviatrixAwsTgwNetworkDomains().Schema, map[string]interface{}{
+				"tgw_name": "tgw-1",
+			})
+
+			diags := dataSourceAviatrixAwsTgwNetworkDomainsRead(context.Background(), d, client)
+			assert.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, "tgw-1", d.Id())
+			assert.Equal(t, []string{"list_tgw_security_domain_details"}, transport.actions)
+			assert.Equal(t, tt.expected, getList(d, "network_domains"))
+		})
+	}
+}
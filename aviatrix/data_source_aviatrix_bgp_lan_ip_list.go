@@ -0,0 +1,68 @@
+package aviatrix
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func dataSourceAviatrixBgpLanIpList() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAviatrixBgpLanIpListRead,
+
+		Schema: map[string]*schema.Schema{
+			"gw_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the spoke or transit gateway.",
+			},
+			"bgp_lan_ip_list": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of private IPs of the BGP over LAN interfaces of the gateway.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ha_bgp_lan_ip_list": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of private IPs of the BGP over LAN interfaces of the HA gateway.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAviatrixBgpLanIpListRead(d *schema.ResourceData, meta interface{}) error {
+	client := mustClient(meta)
+
+	gwName := getString(d, "gw_name")
+	gw, err := client.GetGateway(&goaviatrix.Gateway{GwName: gwName})
+	if err != nil {
+		return fmt.Errorf("couldn't get gateway %s: %w", gwName, err)
+	}
+
+	var bgpLanIpList, haBgpLanIpList []string
+	// BGP over LAN interfaces only exist on Azure and GCP gateways, other clouds have no IPs to report.
+	if gw.EnableBgpOverLan && goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AzureArmRelatedCloudTypes|goaviatrix.GCPRelatedCloudTypes) {
+		bgpLanIpInfo, err := client.GetBgpLanIPList(&goaviatrix.TransitVpc{GwName: gwName})
+		if err != nil {
+			return fmt.Errorf("couldn't get BGP LAN IP info for gateway %s: %w", gwName, err)
+		}
+		if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AzureArmRelatedCloudTypes) {
+			bgpLanIpList, haBgpLanIpList = bgpLanIpInfo.AzureBgpLanIpList, bgpLanIpInfo.AzureHaBgpLanIpList
+		} else {
+			bgpLanIpList, haBgpLanIpList = bgpLanIpInfo.BgpLanIpList, bgpLanIpInfo.HaBgpLanIpList
+		}
+	}
+	mustSet(d, "bgp_lan_ip_list", bgpLanIpList)
+	mustSet(d, "ha_bgp_lan_ip_list", haBgpLanIpList)
+
+	d.SetId(gwName)
+	return nil
+}
@@ -0,0 +1,154 @@
+package aviatrix
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestAccDataSourceAviatrixBgpLanIpList_basic(t *testing.T) {
+	resourceName := "data.aviatrix_bgp_lan_ip_list.foo"
+
+	skipAcc := os.Getenv("SKIP_DATA_BGP_LAN_IP_LIST")
+	if skipAcc == "yes" {
+		t.Skip("Skipping Data Source BGP LAN IP List test as SKIP_DATA_BGP_LAN_IP_LIST is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAviatrixBgpLanIpListConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceAviatrixBgpLanIpList(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "bgp_lan_ip_list.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAviatrixBgpLanIpListConfigBasic() string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc" {
+	account_name        = "tfa-azure"
+	cloud_type          = 8
+	arm_subscription_id = "%s"
+	arm_directory_id    = "%s"
+	arm_application_id  = "%s"
+	arm_application_key = "%s"
+}
+resource "aviatrix_vpc" "test_vpc" {
+	cloud_type           = 8
+	account_name         = aviatrix_account.test_acc.account_name
+	region               = "West US"
+	name                 = "test-vpc"
+	cidr                 = "18.9.0.0/16"
+	aviatrix_firenet_vpc = false
+}
+resource "aviatrix_spoke_gateway" "test_spoke" {
+	cloud_type               = 8
+	account_name             = aviatrix_account.test_acc.account_name
+	gw_name                  = "test-spoke"
+	vpc_id                   = aviatrix_vpc.test_vpc.vpc_id
+	vpc_reg                  = aviatrix_vpc.test_vpc.region
+	gw_size                  = "Standard_D3_v2"
+	subnet                   = aviatrix_vpc.test_vpc.subnets[0].cidr
+	enable_bgp               = true
+	enable_bgp_over_lan      = true
+	bgp_lan_interfaces_count = 2
+}
+data "aviatrix_bgp_lan_ip_list" "foo" {
+	gw_name = aviatrix_spoke_gateway.test_spoke.gw_name
+}
+    `, os.Getenv("ARM_SUBSCRIPTION_ID"), os.Getenv("ARM_DIRECTORY_ID"), os.Getenv("ARM_APPLICATION_ID"),
+		os.Getenv("ARM_APPLICATION_KEY"))
+}
+
+func testAccDataSourceAviatrixBgpLanIpList(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("root module has no data source called %s", name)
+		}
+
+		return nil
+	}
+}
+
+func TestDataSourceAviatrixBgpLanIpListRead(t *testing.T) {
+	tests := []struct {
+		name            string
+		gateway         string
+		expectedActions []string
+		expected        []interface{}
+		expectedHa      []interface{}
+	}{
+		{
+			name:            "Azure gateway with HA",
+			gateway:         `{"vpc_name": "gw", "cloud_type": 8, "enable_bgp_over_lan": true}`,
+			expectedActions: []string{"list_vpcs_summary", "list_aviatrix_transit_advanced_config"},
+			expected:        []interface{}{"10.0.2.4", "10.0.3.4"},
+			expectedHa:      []interface{}{"10.0.2.5"},
+		},
+		{
+			name:            "GCP gateway",
+			gateway:         `{"vpc_name": "gw", "cloud_type": 4, "enable_bgp_over_lan": true}`,
+			expectedActions: []string{"list_vpcs_summary", "list_aviatrix_transit_advanced_config"},
+			expected:        []interface{}{"10.1.0.2"},
+			expectedHa:      []interface{}{},
+		},
+		{
+			name:            "BGP over LAN disabled",
+			gateway:         `{"vpc_name": "gw", "cloud_type": 8}`,
+			expectedActions: []string{"list_vpcs_summary"},
+			expected:        []interface{}{},
+			expectedHa:      []interface{}{},
+		},
+		{
+			name:            "unsupported cloud",
+			gateway:         `{"vpc_name": "gw", "cloud_type": 1, "enable_bgp_over_lan": true}`,
+			expectedActions: []string{"list_vpcs_summary"},
+			expected:        []interface{}{},
+			expectedHa:      []interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{respond: func(form url.Values) string {
+				if form.Get("action") == "list_vpcs_summary" {
+					return `{"return": true, "results": [` + tt.gateway + `], "reason": ""}`
+				}
+				return `{"return": true, "results": {
+					"gce_bgp_lan_all_intf_tuple_list": ["bgp-lan-vpc:bgp-lan-subnet:10.1.0.2"],
+					"arm_bgp_lan_all_intf_ip_list": ["10.0.2.4", "10.0.3.4"],
+					"arm_bgp_lan_all_intf_ha_ip_list": ["10.0.2.5"]
+				}, "reason": ""}`
+			}}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, dataSourceAviatrixBgpLanIpList().Schema, map[string]interface{}{
+				"gw_name": "gw",
+			})
+
+			err := dataSourceAviatrixBgpLanIpListRead(d, client)
+			assert.NoError(t, err)
+			assert.Equal(t, "gw", d.Id())
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			assert.Equal(t, tt.expected, d.Get("bgp_lan_ip_list"))
+			assert.Equal(t, tt.expectedHa, d.Get("ha_bgp_lan_ip_list"))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package aviatrix
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func dataSourceAviatrixControllerEntitlements() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceAviatrixControllerEntitlementsRead,
+
+		Schema: map[string]*schema.Schema{
+			"entitlements": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeBool},
+				Description: "Feature flags permitted by the controller license, keyed by feature name.",
+			},
+		},
+	}
+}
+
+func dataSourceAviatrixControllerEntitlementsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := mustClient(meta)
+
+	controllerEntitlements, err := client.GetControllerEntitlements(ctx)
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("couldn't get controller entitlements: %s", err)
+	}
+	mustSet(d, "entitlements", controllerEntitlements.Entitlements)
+
+	d.SetId(strings.Replace(client.ControllerIP, ".", "-", -1))
+	return nil
+}
@@ -0,0 +1,97 @@
+package aviatrix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestAccDataSourceAviatrixControllerEntitlements_basic(t *testing.T) {
+	resourceName := "data.aviatrix_controller_entitlements.foo"
+
+	skipAcc := os.Getenv("SKIP_DATA_CONTROLLER_ENTITLEMENTS")
+	if skipAcc == "yes" {
+		t.Skip("Skipping Data Source Controller Entitlements test as SKIP_DATA_CONTROLLER_ENTITLEMENTS is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preAccountCheck(t, ". Set SKIP_DATA_CONTROLLER_ENTITLEMENTS to yes to skip Data Source Controller Entitlements tests")
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAviatrixControllerEntitlementsConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceAviatrixControllerEntitlements(resourceName),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAviatrixControllerEntitlementsConfigBasic() string {
+	return `
+data "aviatrix_controller_entitlements" "foo" {
+}
+	`
+}
+
+func testAccDataSourceAviatrixControllerEntitlements(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("root module has no data source called %s", name)
+		}
+
+		return nil
+	}
+}
+
+func TestDataSourceAviatrixControllerEntitlementsRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    string
+		expectedID  string
+		expectedMap map[string]interface{}
+	}{
+		{
+			name:        "entitlements",
+			response:    `{"entitlements": {"copilot": true, "distributed_firewalling": false, "edge": true}}`,
+			expectedID:  "10-0-0-1",
+			expectedMap: map[string]interface{}{"copilot": true, "distributed_firewalling": false, "edge": true},
+		},
+		{
+			name:        "no entitlements reported",
+			response:    `{}`,
+			expectedMap: map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: tt.response}
+			client := &goaviatrix.Client{
+				HTTPClient:   &http.Client{Transport: transport},
+				CID:          "cid",
+				ControllerIP: "10.0.0.1",
+			}
+			d := schema.TestResourceDataRaw(t, dataSourceAviatrixControllerEntitlements().Schema, map[string]interface{}{})
+
+			diags := dataSourceAviatrixControllerEntitlementsRead(context.Background(), d, client)
+			assert.False(t, diags.HasError())
+			assert.Equal(t, tt.expectedID, d.Id())
+			assert.Equal(t, tt.expectedMap, d.Get("entitlements"))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package aviatrix
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAviatrixGatewaysPendingUpgrade() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAviatrixGatewaysPendingUpgradeRead,
+
+		Schema: map[string]*schema.Schema{
+			"gateways": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of gateways running a software version behind the controller's version, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"gw_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the gateway.",
+						},
+						"current_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Software version the gateway is running.",
+						},
+						"latest_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Software version of the controller the gateway can be upgraded to.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAviatrixGatewaysPendingUpgradeRead(d *schema.ResourceData, meta interface{}) error {
+	client := mustClient(meta)
+
+	latestVersion, err := client.GetCurrentVersion()
+	if err != nil {
+		return fmt.Errorf("couldn't get controller version: %w", err)
+	}
+
+	gwList, err := client.ListGateways()
+	if err != nil {
+		return fmt.Errorf("couldn't list gateways: %w", err)
+	}
+	sort.Slice(gwList, func(i, j int) bool {
+		return gwList[i].GwName < gwList[j].GwName
+	})
+
+	gateways := make([]map[string]interface{}, 0)
+	for _, gw := range gwList {
+		if !isGatewaySoftwareVersionBehind(gw.SoftwareVersion, latestVersion) {
+			continue
+		}
+		gateways = append(gateways, map[string]interface{}{
+			"gw_name":         gw.GwName,
+			"current_version": gw.SoftwareVersion,
+			"latest_version":  latestVersion,
+		})
+	}
+	mustSet(d, "gateways", gateways)
+
+	d.SetId(latestVersion)
+	return nil
+}
+
+// userConnectVersionRegex matches UserConnect versions of the form X.Y-NNNN.BUILD.
+var userConnectVersionRegex = regexp.MustCompile(`^(\d+\.\d+)-\d+\.(\d+)$`)
+
+// normalizeGatewaySoftwareVersion turns a UserConnect version "UserConnect-X.Y-NNNN.BUILD" into "X.Y.BUILD",
+// so that its build number is compared like the build number of a "X.Y.BUILD" version.
+func normalizeGatewaySoftwareVersion(v string) string {
+	v = strings.TrimPrefix(v, "UserConnect-")
+	return userConnectVersionRegex.ReplaceAllString(v, "$1.$2")
+}
+
+// isGatewaySoftwareVersionBehind returns whether a gateway software version is older than the latest version.
+// Gateways not reporting a version are never pending, versions that can't be parsed are pending if they differ.
+func isGatewaySoftwareVersionBehind(currentVersion, latestVersion string) bool {
+	currentVersion = normalizeGatewaySoftwareVersion(currentVersion)
+	latestVersion = normalizeGatewaySoftwareVersion(latestVersion)
+	if currentVersion == "" || latestVersion == "" {
+		return false
+	}
+	current, err := version.NewVersion(currentVersion)
+	if err != nil {
+		return currentVersion != latestVersion
+	}
+	latest, err := version.NewVersion(latestVersion)
+	if err != nil {
+		return currentVersion != latestVersion
+	}
+	return current.LessThan(latest)
+}
@@ -0,0 +1,109 @@
+package aviatrix
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestAccDataSourceAviatrixGatewaysPendingUpgrade_basic(t *testing.T) {
+	resourceName := "data.aviatrix_gateways_pending_upgrade.foo"
+
+	skipAcc := os.Getenv("SKIP_DATA_GATEWAYS_PENDING_UPGRADE")
+	if skipAcc == "yes" {
+		t.Skip("Skipping Data Source Gateways Pending Upgrade test as SKIP_DATA_GATEWAYS_PENDING_UPGRADE is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAviatrixGatewaysPendingUpgradeConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceAviatrixGatewaysPendingUpgrade(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "gateways.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAviatrixGatewaysPendingUpgradeConfigBasic() string {
+	return `
+data "aviatrix_gateways_pending_upgrade" "foo" {
+}
+`
+}
+
+func testAccDataSourceAviatrixGatewaysPendingUpgrade(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("root module has no data source called %s", name)
+		}
+
+		return nil
+	}
+}
+
+func TestDataSourceAviatrixGatewaysPendingUpgradeRead(t *testing.T) {
+	transport := &fakeControllerTransport{respond: func(form url.Values) string {
+		if form.Get("action") == "list_version_info" {
+			return `{"return": true, "results": {"current_version": "7.2.4820", "latest_version": "8.0.0"}, "reason": ""}`
+		}
+		return `{"return": true, "results": [
+			{"vpc_name": "transit-gw", "gw_software_version": "7.1.3958"},
+			{"vpc_name": "spoke-gw", "gw_software_version": "7.2.4820"},
+			{"vpc_name": "edge-gw"},
+			{"vpc_name": "egress-gw", "gw_software_version": "7.2.4500"},
+			{"vpc_name": "legacy-gw", "gw_software_version": "UserConnect-6.8-1509.1454"}
+		], "reason": ""}`
+	}}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, dataSourceAviatrixGatewaysPendingUpgrade().Schema, map[string]interface{}{})
+
+	err := dataSourceAviatrixGatewaysPendingUpgradeRead(d, client)
+	assert.NoError(t, err)
+	assert.Equal(t, "7.2.4820", d.Id())
+	assert.Equal(t, []string{"list_version_info", "list_vpcs_summary"}, transport.actions)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"gw_name": "egress-gw", "current_version": "7.2.4500", "latest_version": "7.2.4820"},
+		map[string]interface{}{"gw_name": "legacy-gw", "current_version": "UserConnect-6.8-1509.1454", "latest_version": "7.2.4820"},
+		map[string]interface{}{"gw_name": "transit-gw", "current_version": "7.1.3958", "latest_version": "7.2.4820"},
+	}, d.Get("gateways"))
+}
+
+func TestIsGatewaySoftwareVersionBehind(t *testing.T) {
+	tests := []struct {
+		name           string
+		currentVersion string
+		latestVersion  string
+		expected       bool
+	}{
+		{name: "older build", currentVersion: "7.1.3958", latestVersion: "7.2.4820", expected: true},
+		{name: "same version", currentVersion: "7.2.4820", latestVersion: "7.2.4820", expected: false},
+		{name: "older pre-release build", currentVersion: "8.0.1-1000.1000", latestVersion: "8.0.1-1000.1200", expected: true},
+		{name: "UserConnect version", currentVersion: "UserConnect-7.2-1804.4665", latestVersion: "7.2.4820", expected: true},
+		{name: "newer UserConnect build", currentVersion: "UserConnect-7.2-1804.4900", latestVersion: "7.2.4820", expected: false},
+		{name: "same UserConnect build", currentVersion: "UserConnect-7.2-1804.4820", latestVersion: "UserConnect-7.2-1804.4820", expected: false},
+		{name: "no version reported", currentVersion: "", latestVersion: "7.2.4820", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isGatewaySoftwareVersionBehind(tt.currentVersion, tt.latestVersion))
+		})
+	}
+}
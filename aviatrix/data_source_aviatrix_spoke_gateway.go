@@ -28,6 +28,7 @@ func dataSourceAviatrixSpokeGateway() *schema.Resource {
 			},
 			"account_name": {
 				Type:        schema.TypeString,
+				Optional:    true,
 				Computed:    true,
 				Description: "This parameter represents the name of a Cloud-Account in Aviatrix controller.",
 			},
@@ -385,6 +386,18 @@ func dataSourceAviatrixSpokeGateway() *schema.Resource {
 				Computed:    true,
 				Description: "The EIP address of the HA Spoke Gateway.",
 			},
+			"bgp_lan_ip_list": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "List of available BGP LAN interface IPs for spoke external device connection creation.",
+			},
+			"ha_bgp_lan_ip_list": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "List of available BGP LAN interface IPs for spoke external device HA connection creation.",
+			},
 		},
 	}
 }
@@ -403,11 +416,13 @@ func dataSourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{}
 	gw, err := client.GetGateway(gateway)
 	if err != nil {
 		if errors.Is(err, goaviatrix.ErrNotFound) {
-			d.SetId("")
-			return nil
+			return fmt.Errorf("spoke gateway %q not found", gateway.GwName)
 		}
 		return fmt.Errorf("couldn't find Aviatrix spoke gateway: %w", err)
 	}
+	if gateway.AccountName != "" && gw.AccountName != gateway.AccountName {
+		return fmt.Errorf("spoke gateway %q not found in account %q", gateway.GwName, gateway.AccountName)
+	}
 	if gw != nil {
 		mustSet(d, "cloud_type", gw.CloudType)
 		mustSet(d, "account_name", gw.AccountName)
@@ -587,6 +602,17 @@ func dataSourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{}
 		mustSet(d, "enable_auto_advertise_s2c_cidrs", gw.AutoAdvertiseCidrsEnabled)
 		mustSet(d, "spoke_bgp_manual_advertise_cidrs", gw.BgpManualSpokeAdvertiseCidrs)
 		mustSet(d, "enable_bgp", gw.EnableBgp)
+		if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AzureArmRelatedCloudTypes) && gw.EnableBgpOverLan {
+			bgpLanIpInfo, err := client.GetBgpLanIPList(&goaviatrix.TransitVpc{GwName: gw.GwName})
+			if err != nil {
+				return fmt.Errorf("could not get BGP LAN IP info for Azure spoke gateway %s: %w", gw.GwName, err)
+			}
+			mustSet(d, "bgp_lan_ip_list", bgpLanIpInfo.AzureBgpLanIpList)
+			mustSet(d, "ha_bgp_lan_ip_list", bgpLanIpInfo.AzureHaBgpLanIpList)
+		} else {
+			mustSet(d, "bgp_lan_ip_list", nil)
+			mustSet(d, "ha_bgp_lan_ip_list", nil)
+		}
 		mustSet(d, "enable_learned_cidrs_approval", gw.EnableLearnedCidrsApproval)
 		if gw.EnableLearnedCidrsApproval {
 			spokeAdvancedConfig, err := client.GetSpokeGatewayAdvancedConfig(&goaviatrix.SpokeVpc{GwName: gw.GwName})
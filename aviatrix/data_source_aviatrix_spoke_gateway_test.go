@@ -2,12 +2,18 @@ package aviatrix
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
 )
 
 func TestAccDataSourceAviatrixSpokeGateway_basic(t *testing.T) {
@@ -202,3 +208,76 @@ func testAccDataSourceAviatrixSpokeGateway(name string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func testSpokeGatewayDataSourceTransport() *fakeControllerTransport {
+	return &fakeControllerTransport{
+		respond: func(form url.Values) string {
+			switch form.Get("action") {
+			case "list_vpcs_summary":
+				switch form.Get("gateway_name") {
+				case "spoke-gw":
+					return `{"return": true, "results": [{"vpc_name": "spoke-gw", "account_name": "azure-account",
+						"cloud_type": 8, "vpc_id": "spoke-vnet:rg:0a1b2c3d", "vpc_region": "West US", "public_ip": "198.51.100.10",
+						"private_ip": "10.0.0.10", "gw_security_group_id": "spoke-gw-nsg", "enable_bgp_over_lan": true}], "reason": ""}`
+				case "spoke-gw-hagw":
+					return `{"return": true, "results": [{"vpc_name": "spoke-gw-hagw", "account_name": "azure-account",
+						"cloud_type": 8, "public_ip": "198.51.100.11", "private_ip": "10.0.0.11"}], "reason": ""}`
+				}
+				return `{"return": true, "results": [], "reason": ""}`
+			case "list_aviatrix_transit_advanced_config":
+				return `{"return": true, "results": {"arm_bgp_lan_all_intf_ip_list": ["10.0.2.4", "10.0.3.4"],
+					"arm_bgp_lan_all_intf_ha_ip_list": ["10.0.2.5", "10.0.3.5"]}, "reason": ""}`
+			}
+			return `{"return": true, "results": {}, "reason": ""}`
+		},
+	}
+}
+
+func TestDataSourceAviatrixSpokeGatewayRead(t *testing.T) {
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: testSpokeGatewayDataSourceTransport()}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, dataSourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name":      "spoke-gw",
+		"account_name": "azure-account",
+	})
+
+	err := dataSourceAviatrixSpokeGatewayRead(d, client)
+	assert.NoError(t, err)
+	assert.Equal(t, "spoke-gw", d.Id())
+	assert.Equal(t, "spoke-gw-nsg", d.Get("security_group_id"))
+	assert.Equal(t, "10.0.0.10", d.Get("private_ip"))
+	assert.Equal(t, "198.51.100.10", d.Get("public_ip"))
+	assert.Equal(t, "spoke-gw-hagw", d.Get("ha_gw_name"))
+	assert.Equal(t, "10.0.0.11", d.Get("ha_private_ip"))
+	assert.Equal(t, []interface{}{"10.0.2.4", "10.0.3.4"}, d.Get("bgp_lan_ip_list"))
+	assert.Equal(t, []interface{}{"10.0.2.5", "10.0.3.5"}, d.Get("ha_bgp_lan_ip_list"))
+}
+
+func TestDataSourceAviatrixSpokeGatewayReadNotFound(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name:        "unknown gateway",
+			config:      map[string]interface{}{"gw_name": "other-gw"},
+			expectError: `spoke gateway "other-gw" not found`,
+		},
+		{
+			name:        "other account",
+			config:      map[string]interface{}{"gw_name": "spoke-gw", "account_name": "aws-account"},
+			expectError: `spoke gateway "spoke-gw" not found in account "aws-account"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: testSpokeGatewayDataSourceTransport()}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, dataSourceAviatrixSpokeGateway().Schema, tt.config)
+
+			err := dataSourceAviatrixSpokeGatewayRead(d, client)
+			assert.EqualError(t, err, tt.expectError)
+			assert.Empty(t, d.Id())
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package aviatrix
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func dataSourceAviatrixTransitInstance() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceAviatrixTransitInstanceRead,
+
+		Schema: map[string]*schema.Schema{
+			"group_uuid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"group_uuid", "group_name"},
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "UUID of the transit group.",
+			},
+			"group_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Name of the transit group. The group must have at least one gateway to be looked up by name.",
+			},
+			"gw_uuid_list": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "UUIDs of the gateways in the transit group.",
+			},
+			"gateways": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Gateways in the transit group, the primary gateway first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"gw_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the gateway.",
+						},
+						"gw_size": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Size of the gateway.",
+						},
+						"is_primary": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the gateway is the primary gateway of the group rather than an HA gateway.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAviatrixTransitInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := mustClient(meta)
+
+	gwList, err := client.ListGateways()
+	if err != nil {
+		return diag.Errorf("couldn't list gateways: %s", err)
+	}
+
+	groupUUID := getString(d, "group_uuid")
+	if groupUUID == "" {
+		groupName := getString(d, "group_name")
+		for _, gw := range gwList {
+			if gw.GroupName == groupName && gw.GroupUUID != "" {
+				groupUUID = gw.GroupUUID
+				break
+			}
+		}
+		if groupUUID == "" {
+			return diag.Errorf("couldn't find a transit group named %q with any gateways", groupName)
+		}
+	}
+
+	transitGroup, err := client.GetGatewayGroup(ctx, groupUUID)
+	if err != nil {
+		return diag.Errorf("couldn't get transit group %s: %s", groupUUID, err)
+	}
+
+	// HA gateways report the name of the primary gateway of their group
+	var groupGateways []goaviatrix.Gateway
+	for _, gw := range gwList {
+		if gw.GroupUUID == groupUUID {
+			groupGateways = append(groupGateways, gw)
+		}
+	}
+	sort.Slice(groupGateways, func(i, j int) bool {
+		iPrimary, jPrimary := groupGateways[i].PrimaryGwName == "", groupGateways[j].PrimaryGwName == ""
+		if iPrimary != jPrimary {
+			return iPrimary
+		}
+		return groupGateways[i].GwName < groupGateways[j].GwName
+	})
+
+	var gateways []map[string]interface{}
+	for _, gw := range groupGateways {
+		gateways = append(gateways, map[string]interface{}{
+			"gw_name":    gw.GwName,
+			"gw_size":    gw.GwSize,
+			"is_primary": gw.PrimaryGwName == "",
+		})
+	}
+
+	mustSet(d, "group_uuid", groupUUID)
+	mustSet(d, "group_name", transitGroup.GroupName)
+	mustSet(d, "gw_uuid_list", transitGroup.GwUUIDList)
+	if err := d.Set("gateways", gateways); err != nil {
+		return diag.FromErr(fmt.Errorf("setting 'gateways' to state: %w", err))
+	}
+
+	d.SetId(groupUUID)
+	return nil
+}
@@ -0,0 +1,168 @@
+package aviatrix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestAccDataSourceAviatrixTransitInstance_basic(t *testing.T) {
+	rName := acctest.RandString(5)
+	resourceName := "data.aviatrix_transit_instance.foo"
+
+	skipAcc := os.Getenv("SKIP_DATA_TRANSIT_INSTANCE")
+	if skipAcc == "yes" {
+		t.Skip("Skipping Data Source Transit Instance test as SKIP_DATA_TRANSIT_INSTANCE is set")
+	}
+	msgCommon := ". Set SKIP_DATA_TRANSIT_INSTANCE to yes to skip Data Source Transit Instance tests"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preGatewayCheck(t, msgCommon)
+			if os.Getenv("AWS_HA_SUBNET") == "" {
+				t.Fatal("Environment variable AWS_HA_SUBNET is not set" + msgCommon)
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAviatrixTransitInstanceConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceAviatrixTransitInstance(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "group_uuid", "aviatrix_transit_group.test", "group_uuid"),
+					resource.TestCheckResourceAttr(resourceName, "gw_uuid_list.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "gateways.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "gateways.0.gw_name", fmt.Sprintf("tfi-aws-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "gateways.0.is_primary", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "gateways.1.gw_name", "aviatrix_transit_instance.ha", "gw_name"),
+					resource.TestCheckResourceAttr(resourceName, "gateways.1.is_primary", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAviatrixTransitInstanceConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_transit_group" "test" {
+	group_name          = "tfg-transit-group-%[1]s"
+	cloud_type          = 1
+	gw_type             = "transit"
+	group_instance_size = "t3.small"
+	vpc_id              = "%[5]s"
+	account_name        = aviatrix_account.test_acc_aws.account_name
+	vpc_region          = "%[6]s"
+}
+resource "aviatrix_transit_instance" "primary" {
+	group_uuid = aviatrix_transit_group.test.group_uuid
+	gw_name    = "tfi-aws-%[1]s"
+	gw_size    = "t3.small"
+	subnet     = "%[7]s"
+}
+resource "aviatrix_transit_instance" "ha" {
+	group_uuid = aviatrix_transit_group.test.group_uuid
+	gw_size    = "t3.small"
+	subnet     = "%[8]s"
+	depends_on = [aviatrix_transit_instance.primary]
+}
+data "aviatrix_transit_instance" "foo" {
+	group_name = aviatrix_transit_group.test.group_name
+	depends_on = [aviatrix_transit_instance.ha]
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), os.Getenv("AWS_SUBNET"), os.Getenv("AWS_HA_SUBNET"))
+}
+
+func testAccDataSourceAviatrixTransitInstance(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("root module has no data source called %s", name)
+		}
+
+		return nil
+	}
+}
+
+func TestDataSourceAviatrixTransitInstanceRead(t *testing.T) {
+	gateways := `[
+		{"vpc_name": "transit-hagw", "vpc_size": "t3.small", "group_uuid": "group-1", "group_name": "transit", "primary_gw_name": "transit"},
+		{"vpc_name": "spoke", "vpc_size": "t3.micro", "group_uuid": "group-2", "group_name": "spoke"},
+		{"vpc_name": "transit", "vpc_size": "t3.small", "group_uuid": "group-1", "group_name": "transit"}
+	]`
+
+	tests := []struct {
+		name            string
+		config          map[string]interface{}
+		expectedActions []string
+		expectError     string
+	}{
+		{
+			name:            "by group_uuid",
+			config:          map[string]interface{}{"group_uuid": "group-1"},
+			expectedActions: []string{"list_vpcs_summary", "get_gateway_group_details"},
+		},
+		{
+			name:            "by group_name",
+			config:          map[string]interface{}{"group_name": "transit"},
+			expectedActions: []string{"list_vpcs_summary", "get_gateway_group_details"},
+		},
+		{
+			name:            "unknown group_name",
+			config:          map[string]interface{}{"group_name": "missing"},
+			expectedActions: []string{"list_vpcs_summary"},
+			expectError:     "couldn't find a transit group named \"missing\" with any gateways",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{respond: func(form url.Values) string {
+				if form.Get("action") == "list_vpcs_summary" {
+					return `{"return": true, "results": ` + gateways + `, "reason": ""}`
+				}
+				return fmt.Sprintf(`{"return": true, "results": {"group_name": "transit", "uuid": %q, "gw_uuid_list": ["gw-uuid-1", "gw-uuid-2"]}, "reason": ""}`,
+					form.Get("group_uuid"))
+			}}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, dataSourceAviatrixTransitInstance().Schema, tt.config)
+
+			diags := dataSourceAviatrixTransitInstanceRead(context.Background(), d, client)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			if tt.expectError != "" {
+				assert.True(t, diags.HasError())
+				assert.Contains(t, diags[0].Summary, tt.expectError)
+				return
+			}
+			assert.False(t, diags.HasError())
+			assert.Equal(t, "group-1", d.Id())
+			assert.Equal(t, "group-1", getString(d, "group_uuid"))
+			assert.Equal(t, "transit", getString(d, "group_name"))
+			assert.Equal(t, []interface{}{"gw-uuid-1", "gw-uuid-2"}, d.Get("gw_uuid_list"))
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{"gw_name": "transit", "gw_size": "t3.small", "is_primary": true},
+				map[string]interface{}{"gw_name": "transit-hagw", "gw_size": "t3.small", "is_primary": false},
+			}, d.Get("gateways"))
+		})
+	}
+}
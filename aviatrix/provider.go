@@ -2,13 +2,16 @@ package aviatrix
 
 import (
 	"errors"
+	"log"
 	"os"
+	"time"
 
 	_ "embed"
 
 	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 //go:embed terraform_provider_version.txt
@@ -38,6 +41,7 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("AVIATRIX_SKIP_VERSION_VALIDATION", false),
+				Description: "Skip checking whether the controller version is supported by this provider version.",
 			},
 			"verify_ssl_certificate": {
 				Type:     schema.TypeBool,
@@ -48,6 +52,20 @@ func Provider() *schema.Provider {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"api_retry_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      goaviatrix.DefaultRetryCount,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of times a controller call failing with a transient error, such as the gateway being down, is retried.",
+			},
+			"api_retry_delay": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(goaviatrix.DefaultRetryDelay / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of seconds to wait before the first retry of a controller call failing with a transient error. The wait doubles after every failed attempt, up to 2 minutes.",
+			},
 			"ignore_tags": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -83,6 +101,7 @@ func Provider() *schema.Provider {
 			"aviatrix_aws_tgw_connect":                                        resourceAviatrixAwsTgwConnect(),
 			"aviatrix_aws_tgw_connect_peer":                                   resourceAviatrixAwsTgwConnectPeer(),
 			"aviatrix_aws_tgw_directconnect":                                  resourceAviatrixAWSTgwDirectConnect(),
+			"aviatrix_aws_tgw_domain_connection_policy":                       resourceAviatrixAwsTgwDomainConnectionPolicy(),
 			"aviatrix_aws_tgw_intra_domain_inspection":                        resourceAviatrixAwsTgwIntraDomainInspection(),
 			"aviatrix_aws_tgw_network_domain":                                 resourceAviatrixAwsTgwNetworkDomain(),
 			"aviatrix_aws_tgw_peering":                                        resourceAviatrixAWSTgwPeering(),
@@ -222,7 +241,10 @@ func Provider() *schema.Provider {
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"aviatrix_account":                              dataSourceAviatrixAccount(),
+			"aviatrix_aws_tgw_network_domains":              dataSourceAviatrixAwsTgwNetworkDomains(),
+			"aviatrix_bgp_lan_ip_list":                      dataSourceAviatrixBgpLanIpList(),
 			"aviatrix_caller_identity":                      dataSourceAviatrixCallerIdentity(),
+			"aviatrix_controller_entitlements":              dataSourceAviatrixControllerEntitlements(),
 			"aviatrix_controller_metadata":                  dataSourceAviatrixControllerMetadata(),
 			"aviatrix_web_group":                            dataSourceAviatrixDcfWebgroups(),
 			"aviatrix_dcf_trustbundle":                      dataSourceAviatrixDcfTrustbundle(),
@@ -236,6 +258,7 @@ func Provider() *schema.Provider {
 			"aviatrix_firenet_vendor_integration":           dataSourceAviatrixFireNetVendorIntegration(),
 			"aviatrix_gateway":                              dataSourceAviatrixGateway(),
 			"aviatrix_gateway_image":                        dataSourceAviatrixGatewayImage(),
+			"aviatrix_gateways_pending_upgrade":             dataSourceAviatrixGatewaysPendingUpgrade(),
 			"aviatrix_network_domains":                      dataSourceAviatrixNetworkDomains(),
 			"aviatrix_smart_groups":                         dataSourceAviatrixSmartGroups(),
 			"aviatrix_spoke_gateway":                        dataSourceAviatrixSpokeGateway(),
@@ -243,6 +266,7 @@ func Provider() *schema.Provider {
 			"aviatrix_spoke_gateway_inspection_subnets":     dataSourceAviatrixSpokeGatewayInspectionSubnets(),
 			"aviatrix_transit_gateway":                      dataSourceAviatrixTransitGateway(),
 			"aviatrix_transit_gateways":                     dataSourceAviatrixTransitGateways(),
+			"aviatrix_transit_instance":                     dataSourceAviatrixTransitInstance(),
 			"aviatrix_vpc":                                  dataSourceAviatrixVpc(),
 			"aviatrix_vpc_tracker":                          dataSourceAviatrixVpcTracker(),
 			"aviatrix_firewall":                             dataSourceAviatrixFirewall(),
@@ -264,16 +288,20 @@ func envDefaultFunc(k string) schema.SchemaDefaultFunc {
 
 func aviatrixConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
-		ControllerIP: getString(d, "controller_ip"),
-		Username:     getString(d, "username"),
-		Password:     getString(d, "password"),
-		VerifyCert:   getBool(d, "verify_ssl_certificate"),
-		PathToCACert: getString(d, "path_to_ca_certificate"),
-		IgnoreTags:   expandProviderIgnoreTags(getList(d, "ignore_tags")),
+		ControllerIP:  getString(d, "controller_ip"),
+		Username:      getString(d, "username"),
+		Password:      getString(d, "password"),
+		VerifyCert:    getBool(d, "verify_ssl_certificate"),
+		PathToCACert:  getString(d, "path_to_ca_certificate"),
+		IgnoreTags:    expandProviderIgnoreTags(getList(d, "ignore_tags")),
+		APIRetryCount: getInt(d, "api_retry_count"),
+		APIRetryDelay: time.Duration(getInt(d, "api_retry_delay")) * time.Second,
 	}
 
 	skipVersionValidation := getBool(d, "skip_version_validation")
 	if skipVersionValidation {
+		log.Printf("[WARN] skip_version_validation is set, the controller version is not checked against "+
+			"the versions supported by provider version %s", buildVersion)
 		return config.Client()
 	}
 
@@ -292,12 +320,14 @@ func aviatrixConfigure(d *schema.ResourceData) (interface{}, error) {
 
 func aviatrixConfigureWithoutVersionValidation(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
-		ControllerIP: getString(d, "controller_ip"),
-		Username:     getString(d, "username"),
-		Password:     getString(d, "password"),
-		VerifyCert:   getBool(d, "verify_ssl_certificate"),
-		PathToCACert: getString(d, "path_to_ca_certificate"),
-		IgnoreTags:   expandProviderIgnoreTags(getList(d, "ignore_tags")),
+		ControllerIP:  getString(d, "controller_ip"),
+		Username:      getString(d, "username"),
+		Password:      getString(d, "password"),
+		VerifyCert:    getBool(d, "verify_ssl_certificate"),
+		PathToCACert:  getString(d, "path_to_ca_certificate"),
+		IgnoreTags:    expandProviderIgnoreTags(getList(d, "ignore_tags")),
+		APIRetryCount: getInt(d, "api_retry_count"),
+		APIRetryDelay: time.Duration(getInt(d, "api_retry_delay")) * time.Second,
 	}
 
 	return config.Client()
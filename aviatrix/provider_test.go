@@ -2,8 +2,14 @@ package aviatrix
 
 import (
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -48,6 +54,189 @@ func TestProvider_impl(t *testing.T) {
 	_ = Provider()
 }
 
+// newMockControllerServer returns a TLS server that accepts any login and reports the given
+// controller version. The client sends form encoded bodies for GET requests as well, so the
+// body is decoded regardless of the method.
+func newMockControllerServer(t *testing.T, version string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch form.Get("action") {
+		case "get_api_token":
+			fmt.Fprint(w, `{"return": true, "results": {"api_token": "token"}}`)
+		case "login":
+			fmt.Fprint(w, `{"return": true, "CID": "cid"}`)
+		case "list_version_info":
+			fmt.Fprintf(w, `{"return": true, "results": {"current_version": %q}}`, version)
+		default:
+			fmt.Fprintf(w, `{"return": false, "reason": "unexpected action %s"}`, form.Get("action"))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAviatrixConfigureSkipVersionValidation(t *testing.T) {
+	server := newMockControllerServer(t, "99.0.0-beta")
+	controllerIP := strings.TrimPrefix(server.URL, "https://")
+
+	tests := []struct {
+		name                  string
+		skipVersionValidation bool
+		expectError           string
+	}{
+		{
+			name:                  "unknown version with skip_version_validation",
+			skipVersionValidation: true,
+		},
+		{
+			name:                  "unknown version without skip_version_validation",
+			skipVersionValidation: false,
+			expectError:           "controller version validation failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+				"controller_ip":           controllerIP,
+				"username":                "admin",
+				"password":                "password",
+				"skip_version_validation": tt.skipVersionValidation,
+			})
+
+			meta, err := aviatrixConfigure(d)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if client := mustClient(meta); client.CID != "cid" {
+				t.Fatalf("expected CID %q, got %q", "cid", client.CID)
+			}
+		})
+	}
+}
+
+func TestAviatrixConfigureAPIRetry(t *testing.T) {
+	server := newMockControllerServer(t, "99.0.0-beta")
+	controllerIP := strings.TrimPrefix(server.URL, "https://")
+
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		expectedCount int
+		expectedDelay time.Duration
+	}{
+		{
+			name:          "defaults",
+			config:        map[string]interface{}{},
+			expectedCount: goaviatrix.DefaultRetryCount,
+			expectedDelay: goaviatrix.DefaultRetryDelay,
+		},
+		{
+			name:          "configured",
+			config:        map[string]interface{}{"api_retry_count": 5, "api_retry_delay": 30},
+			expectedCount: 5,
+			expectedDelay: 30 * time.Second,
+		},
+		{
+			name:          "retries disabled",
+			config:        map[string]interface{}{"api_retry_count": 0},
+			expectedCount: 0,
+			expectedDelay: goaviatrix.DefaultRetryDelay,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"controller_ip":           controllerIP,
+				"username":                "admin",
+				"password":                "password",
+				"skip_version_validation": true,
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, Provider().Schema, config)
+
+			meta, err := aviatrixConfigure(d)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			client := mustClient(meta)
+			if client.RetryCount != tt.expectedCount {
+				t.Fatalf("expected retry count %d, got %d", tt.expectedCount, client.RetryCount)
+			}
+			if client.RetryDelay != tt.expectedDelay {
+				t.Fatalf("expected retry delay %s, got %s", tt.expectedDelay, client.RetryDelay)
+			}
+		})
+	}
+}
+
+func TestAviatrixConfigureIgnoreTags(t *testing.T) {
+	server := newMockControllerServer(t, "99.0.0-beta")
+	controllerIP := strings.TrimPrefix(server.URL, "https://")
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected *goaviatrix.IgnoreTagsConfig
+	}{
+		{
+			name:   "not configured",
+			config: map[string]interface{}{},
+		},
+		{
+			name: "keys and key prefixes",
+			config: map[string]interface{}{
+				"ignore_tags": []interface{}{
+					map[string]interface{}{
+						"keys":         []interface{}{"CostCenter"},
+						"key_prefixes": []interface{}{"aws:", "kubernetes.io/"},
+					},
+				},
+			},
+			expected: &goaviatrix.IgnoreTagsConfig{
+				Keys:        goaviatrix.KeyValueTags{"CostCenter": ""},
+				KeyPrefixes: goaviatrix.KeyValueTags{"aws:": "", "kubernetes.io/": ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"controller_ip":           controllerIP,
+				"username":                "admin",
+				"password":                "password",
+				"skip_version_validation": true,
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, Provider().Schema, config)
+
+			meta, err := aviatrixConfigure(d)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			client := mustClient(meta)
+			if !reflect.DeepEqual(client.IgnoreTagsConfig, tt.expected) {
+				t.Fatalf("expected ignore tags config %+v, got %+v", tt.expected, client.IgnoreTagsConfig)
+			}
+		})
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("AVIATRIX_CONTROLLER_IP"); v == "" {
 		t.Fatal("AVIATRIX_CONTROLLER_IP must be set for acceptance tests.")
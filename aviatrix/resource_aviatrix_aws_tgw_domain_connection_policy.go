@@ -0,0 +1,129 @@
+package aviatrix
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func resourceAviatrixAwsTgwDomainConnectionPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: resourceAviatrixAwsTgwDomainConnectionPolicyCreate,
+		ReadWithoutTimeout:   resourceAviatrixAwsTgwDomainConnectionPolicyRead,
+		DeleteWithoutTimeout: resourceAviatrixAwsTgwDomainConnectionPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"tgw_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "AWS TGW name.",
+			},
+			"domain_name1": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the first network domain of the connection policy.",
+			},
+			"domain_name2": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the second network domain of the connection policy.",
+			},
+		},
+	}
+}
+
+// marshalTGWConnectionPolicyInput returns the policy as a domain connection whose both ends are on the same TGW.
+func marshalTGWConnectionPolicyInput(d *schema.ResourceData) *goaviatrix.DomainConn {
+	return &goaviatrix.DomainConn{
+		TgwName1:    getString(d, "tgw_name"),
+		DomainName1: getString(d, "domain_name1"),
+		TgwName2:    getString(d, "tgw_name"),
+		DomainName2: getString(d, "domain_name2"),
+	}
+}
+
+func resourceAviatrixAwsTgwDomainConnectionPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := mustClient(meta)
+
+	policy := marshalTGWConnectionPolicyInput(d)
+	if policy.DomainName1 == policy.DomainName2 {
+		return diag.Errorf("'domain_name1' and 'domain_name2' must be different network domains")
+	}
+
+	log.Printf("[INFO] Creating connection policy between network domains %s and %s of TGW %s", policy.DomainName1, policy.DomainName2, policy.TgwName1)
+
+	d.SetId(policy.TgwName1 + "~" + policy.DomainName1 + "~" + policy.DomainName2)
+	flag := false
+	defer resourceAviatrixAwsTgwDomainConnectionPolicyReadIfRequired(ctx, d, meta, &flag)
+
+	if err := client.CreateDomainConnection(&goaviatrix.AWSTgw{Name: policy.TgwName1}, policy.DomainName1, policy.DomainName2); err != nil {
+		return diag.Errorf("could not create connection policy: %v", err)
+	}
+
+	return resourceAviatrixAwsTgwDomainConnectionPolicyReadIfRequired(ctx, d, meta, &flag)
+}
+
+func resourceAviatrixAwsTgwDomainConnectionPolicyReadIfRequired(ctx context.Context, d *schema.ResourceData, meta interface{}, flag *bool) diag.Diagnostics {
+	if !(*flag) {
+		*flag = true
+		return resourceAviatrixAwsTgwDomainConnectionPolicyRead(ctx, d, meta)
+	}
+	return nil
+}
+
+func resourceAviatrixAwsTgwDomainConnectionPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := mustClient(meta)
+
+	if getString(d, "tgw_name") == "" {
+		id := d.Id()
+		log.Printf("[DEBUG] Looks like an import. Import Id is %s", id)
+		parts := strings.Split(id, "~")
+		if len(parts) != 3 {
+			return diag.Errorf("invalid ID, expected ID tgw_name~domain_name1~domain_name2, instead got %s", d.Id())
+		}
+		mustSet(d, "tgw_name", parts[0])
+		mustSet(d, "domain_name1", parts[1])
+		mustSet(d, "domain_name2", parts[2])
+		d.SetId(id)
+	}
+
+	policy := marshalTGWConnectionPolicyInput(d)
+
+	err := client.GetDomainConn(policy)
+	if errors.Is(err, goaviatrix.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("couldn't find connection policy between network domains %s and %s: %v", policy.DomainName1, policy.DomainName2, err)
+	}
+
+	d.SetId(policy.TgwName1 + "~" + policy.DomainName1 + "~" + policy.DomainName2)
+	return nil
+}
+
+func resourceAviatrixAwsTgwDomainConnectionPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := mustClient(meta)
+
+	policy := marshalTGWConnectionPolicyInput(d)
+
+	log.Printf("[INFO] Deleting connection policy between network domains %s and %s of TGW %s", policy.DomainName1, policy.DomainName2, policy.TgwName1)
+
+	if err := client.DeleteDomainConnection(&goaviatrix.AWSTgw{Name: policy.TgwName1}, policy.DomainName1, policy.DomainName2); err != nil {
+		return diag.Errorf("could not delete connection policy: %v", err)
+	}
+
+	return nil
+}
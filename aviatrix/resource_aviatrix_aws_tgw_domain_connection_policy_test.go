@@ -0,0 +1,67 @@
+package aviatrix
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestResourceAviatrixAwsTgwDomainConnectionPolicyRead(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		response   string
+		expectedID string
+		expectErr  string
+	}{
+		{
+			name:       "import",
+			id:         "tgw~dev~prod",
+			response:   `{"return": true, "results": {"connected_domain_names": ["prod"]}, "reason": ""}`,
+			expectedID: "tgw~dev~prod",
+		},
+		{
+			name:     "policy removed",
+			id:       "tgw~dev~prod",
+			response: `{"return": true, "results": {"connected_domain_names": ["Shared_Service_Domain"]}, "reason": ""}`,
+		},
+		{
+			name:     "domain removed",
+			id:       "tgw~dev~prod",
+			response: `{"return": false, "reason": "Route domain dev does not exist"}`,
+		},
+		{
+			name:      "invalid id",
+			id:        "tgw~dev",
+			expectErr: "invalid ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: tt.response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixAwsTgwDomainConnectionPolicy().Schema, map[string]interface{}{})
+			d.SetId(tt.id)
+
+			diags := resourceAviatrixAwsTgwDomainConnectionPolicyRead(context.Background(), d, client)
+			if tt.expectErr != "" {
+				assert.True(t, diags.HasError())
+				assert.Contains(t, diags[0].Summary, tt.expectErr)
+				assert.Empty(t, transport.actions)
+				return
+			}
+			assert.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, []string{"list_connected_route_domains"}, transport.actions)
+			assert.Equal(t, tt.expectedID, d.Id())
+			assert.Equal(t, "tgw", d.Get("tgw_name"))
+			assert.Equal(t, "dev", d.Get("domain_name1"))
+			assert.Equal(t, "prod", d.Get("domain_name2"))
+		})
+	}
+}
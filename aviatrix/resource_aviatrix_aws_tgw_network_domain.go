@@ -6,6 +6,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,6 +19,7 @@ func resourceAviatrixAwsTgwNetworkDomain() *schema.Resource {
 	return &schema.Resource{
 		CreateWithoutTimeout: resourceAviatrixAwsTgwNetworkDomainCreate,
 		ReadWithoutTimeout:   resourceAviatrixAwsTgwNetworkDomainRead,
+		UpdateWithoutTimeout: resourceAviatrixAwsTgwNetworkDomainUpdate,
 		DeleteWithoutTimeout: resourceAviatrixAwsTgwNetworkDomainDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -58,6 +60,41 @@ func resourceAviatrixAwsTgwNetworkDomain() *schema.Resource {
 				ForceNew:    true,
 				Description: "Set to true if the network domain is a native firewall domain.",
 			},
+			"force_delete": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				ValidateDiagFunc: validateNetworkDomainForceDelete,
+				Description: "Delete the network domain even if it still has attachments. " +
+					"Connection policies of the domain may be left behind.",
+			},
+			"inspection_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether traffic inspection is enabled for the aviatrix firewall domain.",
+			},
+			"firewall_instances": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Firewall instances attached to the aviatrix firewall domain.",
+			},
+		},
+	}
+}
+
+// validateNetworkDomainForceDelete warns that force deleting a network domain may orphan its connection policies.
+func validateNetworkDomainForceDelete(i interface{}, path cty.Path) diag.Diagnostics {
+	if forceDelete, ok := i.(bool); !ok || !forceDelete {
+		return nil
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Network domain will be force deleted",
+			Detail: "With 'force_delete' set to true the network domain is deleted even if it still has attachments. " +
+				"Connection policies of the domain may be left behind and need to be removed separately.",
+			AttributePath: path,
 		},
 	}
 }
@@ -126,6 +163,7 @@ func resourceAviatrixAwsTgwNetworkDomainRead(ctx context.Context, d *schema.Reso
 		}
 		mustSet(d, "tgw_name", parts[0])
 		mustSet(d, "name", parts[1])
+		mustSet(d, "force_delete", false)
 		d.SetId(id)
 	}
 
@@ -149,18 +187,33 @@ func resourceAviatrixAwsTgwNetworkDomainRead(ctx context.Context, d *schema.Reso
 	mustSet(d, "native_egress", networkDomainDetails.NativeEgressDomain)
 	mustSet(d, "native_firewall", networkDomainDetails.NativeFirewallDomain)
 
+	if networkDomainDetails.AviatrixFirewallDomain {
+		mustSet(d, "inspection_enabled", networkDomainDetails.InspectionEnabled)
+		mustSet(d, "firewall_instances", networkDomainDetails.FirewallInstances)
+	} else {
+		mustSet(d, "inspection_enabled", false)
+		mustSet(d, "firewall_instances", nil)
+	}
+
 	d.SetId(tgwName + "~" + name)
 	return nil
 }
 
+func resourceAviatrixAwsTgwNetworkDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// force_delete is the only attribute that can change in place and it is only used on delete
+	return resourceAviatrixAwsTgwNetworkDomainRead(ctx, d, meta)
+}
+
 func resourceAviatrixAwsTgwNetworkDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := mustClient(meta)
 
 	networkDomain := &goaviatrix.SecurityDomain{
-		Name:       getString(d, "name"),
-		AwsTgwName: getString(d, "tgw_name"),
+		Name:        getString(d, "name"),
+		AwsTgwName:  getString(d, "tgw_name"),
+		ForceDelete: getBool(d, "force_delete"),
 	}
 
+	// Default domains are always force deleted
 	defaultDomains := []string{"Aviatrix_Edge_Domain", "Default_Domain", "Shared_Service_Domain"}
 
 	for _, d := range defaultDomains {
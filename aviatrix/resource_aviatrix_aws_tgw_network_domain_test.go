@@ -1,14 +1,20 @@
 package aviatrix
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 
 	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
 )
@@ -163,3 +169,107 @@ func testAccCheckAwsTgwNetworkDomainDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestReadAwsTgwNetworkDomainFirewallInspection(t *testing.T) {
+	tests := []struct {
+		name                      string
+		response                  string
+		expectedInspectionEnabled bool
+		expectedFirewallInstances []interface{}
+	}{
+		{
+			name: "aviatrix firewall domain",
+			response: `{"return": true, "results": [{"name": "fw-domain", "firewall_domain": true, "inspection_enabled": true,
+				"firewall_instances": ["i-0a1b2c3d4e5f60001", "i-0a1b2c3d4e5f60002"]}], "reason": ""}`,
+			expectedInspectionEnabled: true,
+			expectedFirewallInstances: []interface{}{"i-0a1b2c3d4e5f60001", "i-0a1b2c3d4e5f60002"},
+		},
+		{
+			name:                      "aviatrix firewall domain without instances",
+			response:                  `{"return": true, "results": [{"name": "fw-domain", "firewall_domain": true}], "reason": ""}`,
+			expectedFirewallInstances: []interface{}{},
+		},
+		{
+			name: "regular domain",
+			response: `{"return": true, "results": [{"name": "fw-domain", "inspection_enabled": true,
+				"firewall_instances": ["i-0a1b2c3d4e5f60001"]}], "reason": ""}`,
+			expectedFirewallInstances: []interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: tt.response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixAwsTgwNetworkDomain().Schema, map[string]interface{}{
+				"name":     "fw-domain",
+				"tgw_name": "tgw",
+			})
+
+			diags := resourceAviatrixAwsTgwNetworkDomainRead(context.Background(), d, client)
+			assert.False(t, diags.HasError())
+			assert.Equal(t, []string{"list_tgw_security_domain_details"}, transport.actions)
+			assert.Equal(t, "tgw~fw-domain", d.Id())
+			assert.Equal(t, tt.expectedInspectionEnabled, d.Get("inspection_enabled"))
+			assert.Equal(t, tt.expectedFirewallInstances, d.Get("firewall_instances"))
+		})
+	}
+}
+
+func TestResourceAviatrixAwsTgwNetworkDomainDelete(t *testing.T) {
+	tests := []struct {
+		name          string
+		domainName    string
+		forceDelete   bool
+		expectedForce string
+	}{
+		{
+			name:       "custom domain",
+			domainName: "custom-domain",
+		},
+		{
+			name:          "custom domain with force_delete",
+			domainName:    "custom-domain",
+			forceDelete:   true,
+			expectedForce: "true",
+		},
+		{
+			name:          "default domain",
+			domainName:    "Shared_Service_Domain",
+			expectedForce: "true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent url.Values
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					sent = form
+					return `{"return": true, "results": "Network domain deleted", "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixAwsTgwNetworkDomain().Schema, map[string]interface{}{
+				"name":         tt.domainName,
+				"tgw_name":     "tgw",
+				"force_delete": tt.forceDelete,
+			})
+
+			diags := resourceAviatrixAwsTgwNetworkDomainDelete(context.Background(), d, client)
+			assert.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, []string{"delete_route_domain"}, transport.actions)
+			assert.Equal(t, tt.domainName, sent.Get("route_domain_name"))
+			assert.Equal(t, tt.expectedForce, sent.Get("force"))
+		})
+	}
+}
+
+func TestValidateNetworkDomainForceDelete(t *testing.T) {
+	diags := validateNetworkDomainForceDelete(true, nil)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, "Connection policies of the domain may be left behind")
+
+	assert.Empty(t, validateNetworkDomainForceDelete(false, nil))
+}
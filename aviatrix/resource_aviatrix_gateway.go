@@ -1,12 +1,17 @@
 package aviatrix
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"net"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,14 +21,19 @@ import (
 
 func resourceAviatrixGateway() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAviatrixGatewayCreate,
-		Read:   resourceAviatrixGatewayRead,
-		Update: resourceAviatrixGatewayUpdate,
-		Delete: resourceAviatrixGatewayDelete,
+		CreateContext: resourceAviatrixGatewayCreateContext,
+		ReadContext:   resourceAviatrixGatewayReadContext,
+		UpdateContext: resourceAviatrixGatewayUpdateContext,
+		Delete:        resourceAviatrixGatewayDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough, //nolint:staticcheck // SA1019: deprecated but requires structural changes to migrate,
 		},
 
+		// CustomizeDiff forces recreation when subnet_ipv6_cidr changes while enable_ipv6 is true, and when
+		// subnet or insane_mode_az changes unless the gateway can be replaced in place. It also rejects tags
+		// on cloud types whose tags are not read back.
+		CustomizeDiff: resourceAviatrixGatewayCustomizeDiff,
+
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -74,9 +84,19 @@ func resourceAviatrixGateway() *schema.Resource {
 			"subnet": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "A VPC Network address range selected from one of the available network ranges.",
 			},
+			"subnet_ipv6_cidr": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateIPv6CIDR,
+				// DiffSuppressFunc ignores changes to this field when enable_ipv6 is false
+				DiffSuppressFunc: func(_, _, _ string, d *schema.ResourceData) bool {
+					return !getBool(d, "enable_ipv6")
+				},
+				Description: "IPv6 CIDR for the subnet. Only used if enable_ipv6 flag is set. Currently only supported on Azure and AWS Cloud.",
+			},
 			"zone": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -87,9 +107,15 @@ func resourceAviatrixGateway() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "",
-				ForceNew:    true,
 				Description: "AZ of subnet being created for Insane Mode Gateway. Required if insane_mode is set.",
 			},
+			"recreate_in_place": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Recreate the gateway and its peering HA gateway in the update when 'subnet' or " +
+					"'insane_mode_az' changes, instead of replacing the resource. Valid values: true, false.",
+			},
 			"single_ip_snat": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -127,6 +153,11 @@ func resourceAviatrixGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Maximum connection of VPN access. Valid for VPN gateway only. If not set, '100' will be default value.",
 			},
+			"effective_max_vpn_conn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Maximum connection of VPN access in effect on the peering HA gateway, or on the gateway itself without peering HA.",
+			},
 			"name_servers": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -148,6 +179,12 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "A list of destination CIDR ranges that will also go through the VPN tunnel " +
 					"when Split Tunnel Mode is enabled.",
 			},
+			"save_split_tunnel_template": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Save the split tunnel configuration as a template for reuse when Split Tunnel Mode is enabled.",
+			},
 			"otp_mode": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -210,6 +247,18 @@ func resourceAviatrixGateway() *schema.Resource {
 				Default:     "",
 				Description: "Push mode for DUO auth.",
 			},
+			"enable_client_cert_auth": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable certificate-based authentication of VPN clients. Only valid if vpn_access is true.",
+			},
+			"client_cert_ca_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Name of the CA certificate, uploaded to the controller, that signs the VPN client certificates. Required if enable_client_cert_auth is true.",
+			},
 			"enable_ldap": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -289,7 +338,7 @@ func resourceAviatrixGateway() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 				ForceNew:    true,
-				Description: "Enable Insane Mode for Gateway. Valid values: true, false.",
+				Description: "Enable Insane Mode for Gateway. Valid values: true, false. If insane mode is enabled, gateway size has to at least be c5 size for AWS and Standard_D3_v2 size for Azure.",
 			},
 			"enable_vpc_dns_server": {
 				Type:        schema.TypeBool,
@@ -304,10 +353,19 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "Enable 'designated_gateway' feature for Gateway. Valid values: true, false.",
 			},
 			"additional_cidrs_designated_gateway": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "A list of CIDR ranges separated by comma to configure when 'designated_gateway' feature is enabled.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ConflictsWith: []string{"additional_cidrs_designated_gateway_list"},
+				Deprecated:    "Use 'additional_cidrs_designated_gateway_list' instead.",
+				Description:   "A list of CIDR ranges separated by comma to configure when 'designated_gateway' feature is enabled.",
+			},
+			"additional_cidrs_designated_gateway_list": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsCIDR},
+				ConflictsWith: []string{"additional_cidrs_designated_gateway"},
+				Description:   "A set of CIDR ranges to configure when 'designated_gateway' feature is enabled.",
 			},
 			"enable_encrypt_volume": {
 				Type:        schema.TypeBool,
@@ -316,10 +374,18 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "Enable encrypt gateway EBS volume. Only supported for AWS provider. Valid values: true, false. Default value: false.",
 			},
 			"customer_managed_keys": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				Description: "Customer managed key ID.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validateCustomerManagedKeys,
+				Description:  "Customer managed key ID or the ARN of a key alias, e.g. 'arn:aws:kms:us-west-2:111122223333:alias/example'.",
+			},
+			"peering_ha_customer_managed_keys": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validateCustomerManagedKeys,
+				Description:  "Customer managed key ID or the ARN of a key alias to encrypt the peering HA gateway volume with. Defaults to 'customer_managed_keys'.",
 			},
 			"enable_monitor_gateway_subnets": {
 				Type:        schema.TypeBool,
@@ -335,6 +401,16 @@ func resourceAviatrixGateway() *schema.Resource {
 				},
 				Description: "A set of monitored instance ids. Only valid when 'enable_monitor_gateway_subnets' = true.",
 			},
+			"monitor_exclude_name_list": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				Description: "A set of Name tags of monitored instances in the gateway VPC, resolved to instance ids at apply time " +
+					"and excluded together with 'monitor_exclude_list'. Only valid when 'enable_monitor_gateway_subnets' = true.",
+			},
 			"idle_timeout": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -402,7 +478,19 @@ func resourceAviatrixGateway() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
-				Description: "Specify whether to disable GRO/GSO or not.",
+				Description: "Specify whether to disable GRO/GSO or not. Also applied to the peering HA gateway when it exists.",
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 255),
+				Description:  "Free-text description label of the gateway, for inventory purposes. Maximum 255 characters.",
+			},
+			"enable_ipv6": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable IPv6 for the gateway. Only supported for AWS (1), Azure (8).",
 			},
 			"tags": {
 				Type:        schema.TypeMap,
@@ -410,6 +498,12 @@ func resourceAviatrixGateway() *schema.Resource {
 				Optional:    true,
 				Description: "A map of tags to assign to the gateway.",
 			},
+			"all_tags": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "Every tag on the gateway's cloud instance, including controller managed tags and tags matched by the provider's ignore_tags.",
+			},
 			"enable_spot_instance": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -428,8 +522,7 @@ func resourceAviatrixGateway() *schema.Resource {
 			"spot_price": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
-				Description:  "Price for spot instance. NOT supported for production deployment.",
+				Description:  "Price for spot instance. Can be updated without recreating the gateway. NOT supported for production deployment.",
 				RequiredWith: []string{"enable_spot_instance"},
 			},
 			"delete_spot": {
@@ -470,6 +563,15 @@ func resourceAviatrixGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Peering HA fault domain for OCI.",
 			},
+			"aws_iam_instance_profile": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateAwsIamInstanceProfile,
+				DiffSuppressFunc: DiffSuppressFuncAwsIamInstanceProfile,
+				Description:      "Name or ARN of the IAM instance profile attached to the gateway. Only supported for AWS related cloud types.",
+			},
 			"eip": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -517,6 +619,13 @@ func resourceAviatrixGateway() *schema.Resource {
 				ValidateFunc: validation.IntBetween(20, 600),
 				Description:  "The IPSec tunnel down detection time for the Gateway.",
 			},
+			"peering_ha_tunnel_detection_time": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(20, 600),
+				Description:  "The IPSec tunnel down detection time for the Peering HA Gateway.",
+			},
 			"software_version": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -572,6 +681,11 @@ func resourceAviatrixGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Instance ID of the gateway.",
 			},
+			"eip_allocation_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "AWS allocation ID of the Elastic IP assigned to the gateway. Only available for AWS related cloud types.",
+			},
 			"private_ip": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -592,6 +706,16 @@ func resourceAviatrixGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Private IP address of HA gateway.",
 			},
+			"peering_ha_public_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Public IP address of HA gateway.",
+			},
+			"peering_ha_enable_gro_gso": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether GRO/GSO is enabled on the peering HA gateway.",
+			},
 			"fqdn_lan_interface": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -601,6 +725,15 @@ func resourceAviatrixGateway() *schema.Resource {
 	}
 }
 
+func resourceAviatrixGatewayCreateContext(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := monitorExcludeListVpcWarnings(d, mustClient(meta))
+	diags = append(diags, insaneModeGwSizeWarnings(d, "gw_size", "peering_ha_gw_size")...)
+	if err := resourceAviatrixGatewayCreate(d, meta); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
 func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) error {
 	client := mustClient(meta)
 
@@ -631,7 +764,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 		NameServers:        getString(d, "name_servers"),
 		SearchDomains:      getString(d, "search_domains"),
 		Eip:                getString(d, "eip"),
-		SaveTemplate:       "no",
+		SaveTemplate:       splitTunnelSaveTemplate(d),
 		AvailabilityDomain: getString(d, "availability_domain"),
 		FaultDomain:        getString(d, "fault_domain"),
 	}
@@ -689,6 +822,13 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("invalid cloud type, it can only be AWS (1), GCP (4), Azure (8), OCI (16), AzureGov (32), AWSGov (256), AWSChina (1024), AzureChina (2048), Alibaba Cloud (8192), AWS Top Secret (16384) or AWS Secret (32768)")
 	}
 
+	if iamInstanceProfile := getString(d, "aws_iam_instance_profile"); iamInstanceProfile != "" {
+		if !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
+			return fmt.Errorf("attribute 'aws_iam_instance_profile' is only valid for AWS (1), AWSGov (256), AWSChina (1024), AWS Top Secret (16384) and AWS Secret (32768)")
+		}
+		gateway.IamInstanceProfile = iamInstanceProfile
+	}
+
 	singleIpNat := getBool(d, "single_ip_snat")
 	if singleIpNat {
 		gateway.EnableNat = "yes"
@@ -843,6 +983,9 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 			return fmt.Errorf("'vpn_protocol' should be left empty for non-vpn gateway")
 		}
 	}
+	if err := validateGatewayClientCertAuth(d); err != nil {
+		return err
+	}
 
 	if goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.OCIRelatedCloudTypes) && (gateway.AvailabilityDomain == "" || gateway.FaultDomain == "") {
 		return fmt.Errorf("'availability_domain' and 'fault_domain' are required for OCI")
@@ -871,6 +1014,9 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 	if peeringHaSubnet == "" && peeringHaZone == "" && peeringHaGwSize != "" {
 		return fmt.Errorf("'peering_ha_gw_size' is only required if enabling Peering HA")
 	}
+	if _, ok := d.GetOk("peering_ha_tunnel_detection_time"); ok && peeringHaSubnet == "" && peeringHaZone == "" {
+		return fmt.Errorf("'peering_ha_tunnel_detection_time' is only valid if enabling Peering HA")
+	}
 	if peeringHaSubnet != "" {
 		if goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.OCIRelatedCloudTypes) && (peeringHaAvailabilityDomain == "" || peeringHaFaultDomain == "") {
 			return fmt.Errorf("'peering_ha_availability_domain' and 'peering_ha_fault_domain' are required to enable Peering HA on OCI")
@@ -900,7 +1046,15 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 		if !enableEncryptVolume {
 			return fmt.Errorf("'customer_managed_keys' should be empty since Encrypt Volume is not enabled")
 		}
-		gateway.CustomerManagedKeys = customerManagedKeys
+		keyID, err := resolveCustomerManagedKeys(client, gateway.AccountName, customerManagedKeys)
+		if err != nil {
+			return err
+		}
+		gateway.CustomerManagedKeys = keyID
+	}
+	peeringHaCustomerManagedKeys := getString(d, "peering_ha_customer_managed_keys")
+	if peeringHaCustomerManagedKeys != "" && !enableEncryptVolume {
+		return fmt.Errorf("'peering_ha_customer_managed_keys' should be empty since Encrypt Volume is not enabled")
 	}
 	if !enableEncryptVolume && goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
 		gateway.EncVolume = "no"
@@ -918,6 +1072,9 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 	if !enableMonitorSubnets && len(excludedInstances) != 0 {
 		return fmt.Errorf("'monitor_exclude_list' must be empty if 'enable_monitor_gateway_subnets' is false")
 	}
+	if !enableMonitorSubnets && len(getStringSet(d, "monitor_exclude_name_list")) != 0 {
+		return fmt.Errorf("'monitor_exclude_name_list' must be empty if 'enable_monitor_gateway_subnets' is false")
+	}
 
 	_, tagsOk := d.GetOk("tags")
 	if tagsOk {
@@ -963,6 +1120,18 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	if getBool(d, "enable_ipv6") {
+		if err := validateGatewayIPv6(gateway.CloudType, vpnStatus, gateway.VpnCidr); err != nil {
+			return fmt.Errorf("error creating gateway: %w", err)
+		}
+		subnetIPv6Cidr := getString(d, "subnet_ipv6_cidr")
+		if subnetIPv6Cidr == "" {
+			return fmt.Errorf("error creating gateway: subnet_ipv6_cidr must be set when enable_ipv6 is true")
+		}
+		gateway.EnableIPv6 = true
+		gateway.VpcNet = strings.TrimRight(gateway.VpcNet, "~") + subnetSeparator + subnetIPv6Cidr
+	}
+
 	log.Printf("[INFO] Creating Aviatrix gateway: %#v", gateway)
 
 	d.SetId(gateway.GwName)
@@ -1001,6 +1170,13 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("'enable_vpc_nat' is only supported for vpn gateway. Can't modify it for non-vpn gateway")
 	}
 
+	if getBool(d, "enable_client_cert_auth") {
+		err := client.SetVpnClientCertAuth(gateway.GwName, getString(d, "client_cert_ca_name"))
+		if err != nil {
+			return fmt.Errorf("failed to enable VPN client certificate authentication: %w", err)
+		}
+	}
+
 	singleAZ := getBool(d, "single_az_ha")
 	if singleAZ && !getBool(d, "enable_public_subnet_filtering") {
 		singleAZGateway := &goaviatrix.Gateway{
@@ -1028,7 +1204,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 	}
 
 	if enableDesignatedGw {
-		additionalCidrsDesignatedGw := getString(d, "additional_cidrs_designated_gateway")
+		additionalCidrsDesignatedGw := getDesignatedGatewayCidrs(d)
 		if additionalCidrsDesignatedGw != "" {
 			designatedGw := &goaviatrix.Gateway{
 				GwName:                      getString(d, "gw_name"),
@@ -1133,6 +1309,12 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 				return fmt.Errorf("failed to update Aviatrix Peering HA Gateway size: %w", err)
 			}
 		}
+
+		if peeringHaCustomerManagedKeys != "" {
+			if err := encryptPeeringHaGatewayVolume(d, client, gateway.CustomerManagedKeys); err != nil {
+				return err
+			}
+		}
 	}
 
 	enableVpcDnsServer := getBool(d, "enable_vpc_dns_server")
@@ -1153,7 +1335,12 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 
 	if enableMonitorSubnets {
 		log.Printf("[INFO] Enable Monitor Gateway Subnets")
-		err := client.EnableMonitorGatewaySubnets(gateway.GwName, excludedInstances)
+		resolvedInstances, err := resolveMonitorExcludeNames(d, client)
+		if err != nil {
+			return err
+		}
+		excludedInstances = mergeMonitorExcludeList(excludedInstances, resolvedInstances)
+		err = client.EnableMonitorGatewaySubnets(gateway.GwName, excludedInstances)
 		if err != nil {
 			return fmt.Errorf("could not enable monitor gateway subnets: %w", err)
 		}
@@ -1225,12 +1412,19 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 	}
 
 	if !getBool(d, "enable_gro_gso") {
-		err := client.DisableGroGso(gateway)
+		err := setGatewayGroGso(client, gateway.GwName, peeringHaSubnet != "" || peeringHaZone != "", false)
 		if err != nil {
 			return fmt.Errorf("couldn't disable GRO/GSO on gateway: %w", err)
 		}
 	}
 
+	if description := getString(d, "description"); description != "" {
+		err := client.SetGatewayDescription(context.Background(), gateway.GwName, description)
+		if err != nil {
+			return fmt.Errorf("could not set description for Gateway: %w", err)
+		}
+	}
+
 	if detectionTime, ok := d.GetOk("tunnel_detection_time"); ok {
 		err := client.ModifyTunnelDetectionTime(gateway.GwName, mustInt(detectionTime))
 		if err != nil {
@@ -1238,6 +1432,13 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	if peeringHaSubnet != "" || peeringHaZone != "" {
+		err := setPeeringHaTunnelDetectionTime(d, client, gateway.GwName+"-hagw", false)
+		if err != nil {
+			return fmt.Errorf("could not set tunnel detection time for Peering HA Gateway during Gateway creation: %w", err)
+		}
+	}
+
 	if getBool(d, "enable_public_subnet_filtering") && len(gateway.TagJson) > 0 {
 		// Workaround for setting tags during creation of a Public Subnet Filtering Gateway in R2.21.1
 		tags := &goaviatrix.Tags{
@@ -1277,14 +1478,30 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 func resourceAviatrixGatewayReadIfRequired(d *schema.ResourceData, meta interface{}, flag *bool) error {
 	if !(*flag) {
 		*flag = true
+		// effective_max_vpn_conn is read from the peering HA gateway, so forget it as well.
+		mustClient(meta).ForgetCachedGateway(getString(d, "gw_name"))
+		mustClient(meta).ForgetCachedGateway(getString(d, "gw_name") + "-hagw")
 		return resourceAviatrixGatewayRead(d, meta)
 	}
 	return nil
 }
 
+func resourceAviatrixGatewayReadContext(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if err := readAviatrixGateway(d, meta, &diags); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
 func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	return readAviatrixGateway(d, meta, nil)
+}
+
+// readAviatrixGateway reads the gateway into d. Warnings found while reading are appended to
+// warnings when it is non-nil.
+func readAviatrixGateway(d *schema.ResourceData, meta interface{}, warnings *diag.Diagnostics) error {
 	client := mustClient(meta)
-	ignoreTagsConfig := client.IgnoreTagsConfig
 
 	var isImport bool
 	gwName := getString(d, "gw_name")
@@ -1296,12 +1513,7 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 		d.SetId(id)
 	}
 
-	gateway := &goaviatrix.Gateway{
-		AccountName: getString(d, "account_name"),
-		GwName:      getString(d, "gw_name"),
-	}
-
-	gw, err := client.GetGateway(gateway)
+	gw, err := getGatewayCached(client, getString(d, "gw_name"))
 	if err != nil {
 		if errors.Is(err, goaviatrix.ErrNotFound) {
 			d.SetId("")
@@ -1331,11 +1543,27 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 	mustSet(d, "single_az_ha", gw.SingleAZ == "yes")
 	mustSet(d, "enable_encrypt_volume", gw.EnableEncryptVolume)
 	mustSet(d, "eip", gw.PublicIP)
+	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes) && gw.PublicIP != "" {
+		allocationID, err := client.GetGatewayEipAllocationID(gw.GwName)
+		if err != nil {
+			return fmt.Errorf("could not get EIP allocation ID for gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "eip_allocation_id", allocationID)
+	} else {
+		mustSet(d, "eip_allocation_id", "")
+	}
+	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes) {
+		mustSet(d, "aws_iam_instance_profile", gw.IamInstanceProfile)
+	} else {
+		mustSet(d, "aws_iam_instance_profile", "")
+	}
 	mustSet(d, "cloud_instance_id", gw.CloudnGatewayInstID)
 	mustSet(d, "public_dns_server", gw.PublicDnsServer)
 	mustSet(d, "security_group_id", gw.GwSecurityGroupID)
 	mustSet(d, "private_ip", gw.PrivateIP)
 	mustSet(d, "enable_jumbo_frame", gw.JumboFrame)
+	mustSet(d, "enable_ipv6", gw.EnableIPv6)
+	mustSet(d, "subnet_ipv6_cidr", gw.SubnetIPv6Cidr)
 	mustSet(d, "enable_vpc_dns_server", goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes|goaviatrix.AliCloudRelatedCloudTypes) && gw.EnableVpcDnsServer == "Enabled")
 	mustSet(d, "tunnel_detection_time", gw.TunnelDetectionTime)
 	mustSet(d, "image_version", gw.ImageVersion)
@@ -1394,16 +1622,11 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 
 	if gw.EnableDesignatedGateway == "Yes" || gw.EnableDesignatedGateway == "yes" {
 		mustSet(d, "enable_designated_gateway", true)
-		cidrsTF := strings.Split(getString(d, "additional_cidrs_designated_gateway"), ",")
-		cidrsRESTAPI := strings.Split(gw.AdditionalCidrsDesignatedGw, ",")
-		if len(goaviatrix.Difference(cidrsTF, cidrsRESTAPI)) == 0 && len(goaviatrix.Difference(cidrsRESTAPI, cidrsTF)) == 0 {
-			mustSet(d, "additional_cidrs_designated_gateway", getString(d, "additional_cidrs_designated_gateway"))
-		} else {
-			mustSet(d, "additional_cidrs_designated_gateway", gw.AdditionalCidrsDesignatedGw)
-		}
+		setDesignatedGatewayCidrs(d, gw.AdditionalCidrsDesignatedGw)
 	} else {
 		mustSet(d, "enable_designated_gateway", false)
 		mustSet(d, "additional_cidrs_designated_gateway", "")
+		mustSet(d, "additional_cidrs_designated_gateway_list", nil)
 	}
 
 	_, zoneIsSet := d.GetOk("zone")
@@ -1452,6 +1675,13 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 		mustSet(d, "otp_mode", "")
 	}
 
+	if getBool(d, "enable_client_cert_auth") || isImport {
+		if err := readGatewayClientCertAuth(d, client, gw); err != nil {
+			return err
+		}
+	}
+	readGatewayEffectiveMaxVpnConn(d, client, gw)
+
 	if gw.NewZone != "" {
 		mustSet(d, "zone", gw.NewZone)
 	}
@@ -1479,12 +1709,8 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 		mustSet(d, "insane_mode_az", "")
 	}
 
-	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes) {
-		tags := goaviatrix.KeyValueTags(gw.Tags).IgnoreConfig(ignoreTagsConfig)
-		if err := d.Set("tags", tags); err != nil {
-			log.Printf("[WARN] Error setting tags for (%s): %s", d.Id(), err)
-		}
-	}
+	setGatewayTags(d, client, gw.CloudType, gw.Tags)
+	setGatewayAllTags(d, client, gw.CloudType, gw.GwName)
 
 	if gw.VpnStatus == "enabled" && gw.SplitTunnel == "yes" {
 		mustSet(d, "name_servers", gw.NameServers)
@@ -1496,7 +1722,18 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 		mustSet(d, "additional_cidrs", "")
 	}
 	mustSet(d, "enable_monitor_gateway_subnets", gw.MonitorSubnetsAction == "enable")
-	if err := d.Set("monitor_exclude_list", gw.MonitorExcludeGWList); err != nil {
+	monitorExcludeList := gw.MonitorExcludeGWList
+	if len(getStringSet(d, "monitor_exclude_name_list")) != 0 {
+		// Instances excluded by name are reported by the controller as well. Leave them out so they
+		// don't show up as a difference with the instance ids configured in monitor_exclude_list.
+		resolvedInstances, err := resolveMonitorExcludeNames(d, client)
+		if err != nil {
+			log.Printf("[WARN] Could not resolve 'monitor_exclude_name_list' of gateway %s: %v", gw.GwName, err)
+		} else {
+			monitorExcludeList = monitorExcludeListWithoutResolved(gw.MonitorExcludeGWList, resolvedInstances, getStringSet(d, "monitor_exclude_list"))
+		}
+	}
+	if err := d.Set("monitor_exclude_list", monitorExcludeList); err != nil {
 		return fmt.Errorf("setting 'monitor_exclude_list' to state: %w", err)
 	}
 
@@ -1516,24 +1753,33 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 		mustSet(d, "public_subnet_filtering_ha_route_tables", []string{})
 		mustSet(d, "public_subnet_filtering_guard_duty_enforced", true)
 	} else {
+		psfDetails := &gw.PsfDetails
+		// The gateway list does not always include the PSF details, e.g. when importing a gateway
+		// that was not created by this provider. Query them directly in that case.
+		if psfDetails.GwSubnetCidr == "" {
+			psfDetails, err = client.GetPublicSubnetFilteringGatewayDetails(gw)
+			if err != nil {
+				return fmt.Errorf("could not get public subnet filtering details of gateway %s: %w", gw.GwName, err)
+			}
+		}
 		mustSet(d, "enable_public_subnet_filtering", true)
-		if err := d.Set("public_subnet_filtering_route_tables", gw.PsfDetails.RouteTableList); err != nil {
+		if err := d.Set("public_subnet_filtering_route_tables", psfDetails.RouteTableList); err != nil {
 			return fmt.Errorf("could not set public_subnet_filtering_route_tables into state: %w", err)
 		}
-		mustSet(d, "public_subnet_filtering_guard_duty_enforced", gw.PsfDetails.GuardDutyEnforced == "yes")
-		mustSet(d, "subnet", gw.PsfDetails.GwSubnetCidr)
-		mustSet(d, "zone", gw.PsfDetails.GwSubnetAz)
+		mustSet(d, "public_subnet_filtering_guard_duty_enforced", psfDetails.GuardDutyEnforced == "yes")
+		mustSet(d, "subnet", psfDetails.GwSubnetCidr)
+		mustSet(d, "zone", psfDetails.GwSubnetAz)
 		if gw.HaGw.GwSize == "" {
 			err := d.Set("public_subnet_filtering_ha_route_tables", []string{})
 			if err != nil {
 				return fmt.Errorf("could not set public_subnet_filtering_ha_route_tables into state: %w", err)
 			}
 		} else {
-			if err := d.Set("public_subnet_filtering_ha_route_tables", gw.PsfDetails.HaRouteTableList); err != nil {
+			if err := d.Set("public_subnet_filtering_ha_route_tables", psfDetails.HaRouteTableList); err != nil {
 				return fmt.Errorf("could not set public_subnet_filtering_ha_route_tables into state: %w", err)
 			}
-			mustSet(d, "peering_ha_subnet", gw.PsfDetails.HaGwSubnetCidr)
-			mustSet(d, "peering_ha_zone", gw.PsfDetails.HaGwSubnetAz)
+			mustSet(d, "peering_ha_subnet", psfDetails.HaGwSubnetCidr)
+			mustSet(d, "peering_ha_zone", psfDetails.HaGwSubnetAz)
 		}
 	}
 
@@ -1560,31 +1806,28 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 	}
 	mustSet(d, "enable_gro_gso", enableGroGso)
 
+	description, err := client.GetGatewayDescription(context.Background(), gw.GwName)
+	if err != nil {
+		return fmt.Errorf("failed to get description of gateway %s: %w", gw.GwName, err)
+	}
+	mustSet(d, "description", description)
+
+	setGatewayPeeringHaDetails(d, &gw.HaGw)
 	if gw.HaGw.GwSize == "" {
-		mustSet(d, "peering_ha_availability_domain", "")
-		mustSet(d, "peering_ha_azure_eip_name_resource_group", "")
-		mustSet(d, "peering_ha_cloud_instance_id", "")
-		mustSet(d, "peering_ha_eip", "")
-		mustSet(d, "peering_ha_fault_domain", "")
-		mustSet(d, "peering_ha_gw_name", "")
-		mustSet(d, "peering_ha_gw_size", "")
-		mustSet(d, "peering_ha_image_version", "")
-		mustSet(d, "peering_ha_insane_mode_az", "")
-		mustSet(d, "peering_ha_private_ip", "")
-		mustSet(d, "peering_ha_security_group_id", "")
-		mustSet(d, "peering_ha_software_version", "")
-		mustSet(d, "peering_ha_subnet", "")
-		mustSet(d, "peering_ha_zone", "")
 		return nil
 	}
-	mustSet(d, "peering_ha_cloud_instance_id", gw.HaGw.CloudnGatewayInstID)
-	mustSet(d, "peering_ha_gw_name", gw.HaGw.GwName)
-	mustSet(d, "peering_ha_eip", gw.HaGw.PublicIP)
-	mustSet(d, "peering_ha_gw_size", gw.HaGw.GwSize)
-	mustSet(d, "peering_ha_private_ip", gw.HaGw.PrivateIP)
-	mustSet(d, "peering_ha_software_version", gw.HaGw.SoftwareVersion)
-	mustSet(d, "peering_ha_image_version", gw.HaGw.ImageVersion)
-	mustSet(d, "peering_ha_security_group_id", gw.HaGw.GwSecurityGroupID)
+
+	// peering_ha_enable_gro_gso is informational only, so a failed lookup keeps the last known value
+	haEnableGroGso, err := client.GetGroGsoStatus(&goaviatrix.Gateway{GwName: gw.HaGw.GwName})
+	if err != nil {
+		log.Printf("[WARN] failed to get GRO/GSO status of peering HA gateway %s: %v", gw.HaGw.GwName, err)
+	} else {
+		mustSet(d, "peering_ha_enable_gro_gso", haEnableGroGso)
+	}
+
+	if warnings != nil {
+		*warnings = append(*warnings, peeringHaRegionMismatchWarning(gw.GwName, gw.VpcRegion, gw.HaGw.VpcRegion)...)
+	}
 
 	if goaviatrix.IsCloudType(gw.HaGw.CloudType, goaviatrix.AWSRelatedCloudTypes) {
 		if gw.HaGw.InsaneMode == "yes" {
@@ -1637,6 +1880,15 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
+func resourceAviatrixGatewayUpdateContext(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := monitorExcludeListVpcWarnings(d, mustClient(meta))
+	diags = append(diags, insaneModeGwSizeWarnings(d, "gw_size", "peering_ha_gw_size")...)
+	if err := resourceAviatrixGatewayUpdate(d, meta); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
 func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := mustClient(meta)
 
@@ -1655,6 +1907,9 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 	if d.HasChange("vpn_protocol") {
 		return fmt.Errorf("updating vpn_protocol is not allowed")
 	}
+	if err := validateGatewayClientCertAuth(d); err != nil {
+		return err
+	}
 	if d.HasChange("allocate_new_eip") {
 		return fmt.Errorf("updating allocate_new_eip is not allowed")
 	}
@@ -1686,6 +1941,11 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 	if err != nil {
 		return err
 	}
+	// Only reachable with recreate_in_place, otherwise CustomizeDiff forces a new resource.
+	if d.HasChanges("subnet", "insane_mode_az") {
+		d.Partial(false)
+		return recreateGatewayInPlace(d, meta)
+	}
 
 	gateway := &goaviatrix.Gateway{
 		CloudType: getInt(d, "cloud_type"),
@@ -1875,6 +2135,17 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	if d.HasChanges("enable_client_cert_auth", "client_cert_ca_name") {
+		var caCertName string
+		if getBool(d, "enable_client_cert_auth") {
+			caCertName = getString(d, "client_cert_ca_name")
+		}
+		err := client.SetVpnClientCertAuth(gateway.GwName, caCertName)
+		if err != nil {
+			return fmt.Errorf("failed to update VPN client certificate authentication: %w", err)
+		}
+	}
+
 	if d.HasChange("tags") {
 		if !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes) {
 			return fmt.Errorf("failed to update gateway: adding tags is only supported for AWS (1), Azure (8), AzureGov (32), AWSGov(256) AWSChina (1024), AzureChina (2048), AWS Top Secret (16384) and AWS Secret (32768)")
@@ -1903,7 +2174,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 	}
 
 	if d.HasChange("split_tunnel") || d.HasChange("additional_cidrs") ||
-		d.HasChange("name_servers") || d.HasChange("search_domains") {
+		d.HasChange("name_servers") || d.HasChange("search_domains") || d.HasChange("save_split_tunnel_template") {
 		splitTunnel := getBool(d, "split_tunnel")
 		sTunnel := &goaviatrix.SplitTunnel{
 			VpcID:   getString(d, "vpc_id"),
@@ -1913,11 +2184,12 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			sTunnel.ElbName = getString(d, "gw_name")
 		}
 
-		if splitTunnel && (d.HasChange("additional_cidrs") || d.HasChange("name_servers") || d.HasChange("search_domains")) {
+		if splitTunnel && (d.HasChange("additional_cidrs") || d.HasChange("name_servers") || d.HasChange("search_domains") ||
+			d.HasChange("save_split_tunnel_template")) {
 			sTunnel.AdditionalCidrs = getString(d, "additional_cidrs")
 			sTunnel.NameServers = getString(d, "name_servers")
 			sTunnel.SearchDomains = getString(d, "search_domains")
-			sTunnel.SaveTemplate = "no"
+			sTunnel.SaveTemplate = splitTunnelSaveTemplate(d)
 			sTunnel.SplitTunnel = "yes"
 
 			if goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.GCPRelatedCloudTypes) {
@@ -1985,7 +2257,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 
 	}
-	if d.HasChange("additional_cidrs_designated_gateway") {
+	if d.HasChanges("additional_cidrs_designated_gateway", "additional_cidrs_designated_gateway_list") {
 		if !getBool(d, "enable_designated_gateway") {
 			return fmt.Errorf("failed to edit additional cidrs for 'designated_gateway' since it is not enabled")
 		}
@@ -1994,7 +2266,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 		designatedGw := &goaviatrix.Gateway{
 			GwName:                      getString(d, "gw_name"),
-			AdditionalCidrsDesignatedGw: getString(d, "additional_cidrs_designated_gateway"),
+			AdditionalCidrsDesignatedGw: getDesignatedGatewayCidrs(d),
 		}
 		err := client.EditDesignatedGateway(designatedGw)
 		if err != nil {
@@ -2353,11 +2625,15 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			if !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
 				return fmt.Errorf("'enable_encrypt_volume' is only supported for AWS (1), AWSGov (256), AWSChina (1024), AWS Top Secret (16384) and AWS Secret (32768) provider")
 			}
+			customerManagedKeys, err := resolveCustomerManagedKeys(client, getString(d, "account_name"), getString(d, "customer_managed_keys"))
+			if err != nil {
+				return err
+			}
 			gwEncVolume := &goaviatrix.Gateway{
 				GwName:              getString(d, "gw_name"),
-				CustomerManagedKeys: getString(d, "customer_managed_keys"),
+				CustomerManagedKeys: customerManagedKeys,
 			}
-			err := client.EnableEncryptVolume(gwEncVolume)
+			err = client.EnableEncryptVolume(gwEncVolume)
 			if err != nil {
 				return fmt.Errorf("failed to enable encrypt gateway volume for %s due to %w", gwEncVolume.GwName, err)
 			}
@@ -2366,13 +2642,8 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			haZone := getString(d, "peering_ha_zone")
 			haEnabled := haSubnet != "" || haZone != ""
 			if haEnabled {
-				gwHAEncVolume := &goaviatrix.Gateway{
-					GwName:              getString(d, "gw_name") + "-hagw",
-					CustomerManagedKeys: getString(d, "customer_managed_keys"),
-				}
-				err := client.EnableEncryptVolume(gwHAEncVolume)
-				if err != nil {
-					return fmt.Errorf("failed to enable encrypt gateway volume for %s due to %w", gwHAEncVolume.GwName, err)
+				if err := encryptPeeringHaGatewayVolume(d, client, customerManagedKeys); err != nil {
+					return err
 				}
 			}
 		} else {
@@ -2380,6 +2651,21 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 	} else if d.HasChange("customer_managed_keys") {
 		return fmt.Errorf("updating customer_managed_keys only is not allowed")
+	} else if d.HasChange("peering_ha_customer_managed_keys") && !newHaGwEnabled {
+		return fmt.Errorf("updating peering_ha_customer_managed_keys only is not allowed")
+	}
+
+	// A Peering HA Gateway created after the volume of the gateway was encrypted does not inherit the
+	// encryption, so encrypt it here. When enable_encrypt_volume changed, the HA gateway is encrypted above.
+	if newHaGwEnabled && getBool(d, "enable_encrypt_volume") && !d.HasChange("enable_encrypt_volume") {
+		customerManagedKeys, err := resolveCustomerManagedKeys(client, getString(d, "account_name"), getString(d, "customer_managed_keys"))
+		if err != nil {
+			return err
+		}
+		err = encryptPeeringHaGatewayVolume(d, client, customerManagedKeys)
+		if err != nil {
+			return err
+		}
 	}
 
 	monitorGatewaySubnets := getBool(d, "enable_monitor_gateway_subnets")
@@ -2390,6 +2676,16 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 	if !monitorGatewaySubnets && len(excludedInstances) != 0 {
 		return fmt.Errorf("'monitor_exclude_list' must be empty if 'enable_monitor_gateway_subnets' is false")
 	}
+	if !monitorGatewaySubnets && len(getStringSet(d, "monitor_exclude_name_list")) != 0 {
+		return fmt.Errorf("'monitor_exclude_name_list' must be empty if 'enable_monitor_gateway_subnets' is false")
+	}
+	if monitorGatewaySubnets && d.HasChanges("enable_monitor_gateway_subnets", "monitor_exclude_list", "monitor_exclude_name_list") {
+		resolvedInstances, err := resolveMonitorExcludeNames(d, client)
+		if err != nil {
+			return err
+		}
+		excludedInstances = mergeMonitorExcludeList(excludedInstances, resolvedInstances)
+	}
 	if d.HasChange("enable_monitor_gateway_subnets") {
 		if monitorGatewaySubnets {
 			err := client.EnableMonitorGatewaySubnets(gateway.GwName, excludedInstances)
@@ -2402,7 +2698,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 				return fmt.Errorf("could not disable monitor gateway subnets: %w", err)
 			}
 		}
-	} else if d.HasChange("monitor_exclude_list") {
+	} else if d.HasChanges("monitor_exclude_list", "monitor_exclude_name_list") {
 		err := client.DisableMonitorGatewaySubnets(gateway.GwName)
 		if err != nil {
 			return fmt.Errorf("could not disable monitor gateway subnets: %w", err)
@@ -2525,20 +2821,44 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	if d.HasChange("enable_gro_gso") {
-		if getBool(d, "enable_gro_gso") {
-			err := client.EnableGroGso(gateway)
+	if d.HasChange("enable_ipv6") {
+		if getBool(d, "enable_ipv6") {
+			err := validateGatewayIPv6(gateway.CloudType, getBool(d, "vpn_access"), getString(d, "vpn_cidr"))
 			if err != nil {
-				return fmt.Errorf("couldn't enable GRO/GSO on gateway when updating: %w", err)
+				return fmt.Errorf("couldn't enable IPv6 on gateway when updating: %w", err)
+			}
+			err = client.EnableIPv6(gateway)
+			if err != nil {
+				return fmt.Errorf("couldn't enable IPv6 on gateway when updating: %w", err)
 			}
 		} else {
-			err := client.DisableGroGso(gateway)
+			err := client.DisableIPv6(gateway)
 			if err != nil {
-				return fmt.Errorf("couldn't disable GRO/GSO on gateway when updating: %w", err)
+				return fmt.Errorf("couldn't disable IPv6 on gateway when updating: %w", err)
 			}
 		}
 	}
 
+	if d.HasChange("enable_gro_gso") {
+		err := setGatewayGroGso(client, gateway.GwName, haEnabled, getBool(d, "enable_gro_gso"))
+		if err != nil {
+			return fmt.Errorf("couldn't update GRO/GSO on gateway when updating: %w", err)
+		}
+	} else if newHaGwEnabled && !getBool(d, "enable_gro_gso") {
+		// A newly created peering HA gateway starts with GRO/GSO enabled.
+		err := client.DisableGroGso(&goaviatrix.Gateway{GwName: gateway.GwName + "-hagw"})
+		if err != nil {
+			return fmt.Errorf("couldn't disable GRO/GSO on peering HA gateway when updating: %w", err)
+		}
+	}
+
+	if d.HasChange("description") {
+		err := client.SetGatewayDescription(context.Background(), gateway.GwName, getString(d, "description"))
+		if err != nil {
+			return fmt.Errorf("could not update description during Gateway update: %w", err)
+		}
+	}
+
 	if d.HasChange("tunnel_detection_time") {
 		detectionTimeInterface, ok := d.GetOk("tunnel_detection_time")
 		var detectionTime int
@@ -2556,6 +2876,13 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	if haEnabled && (d.HasChange("peering_ha_tunnel_detection_time") || newHaGwEnabled) {
+		err := setPeeringHaTunnelDetectionTime(d, client, gateway.GwName+"-hagw", !newHaGwEnabled)
+		if err != nil {
+			return fmt.Errorf("could not modify tunnel detection time for Peering HA Gateway during Gateway update: %w", err)
+		}
+	}
+
 	if d.HasChange("rx_queue_size") {
 		if !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
 			return fmt.Errorf("could not update rx_queue_size since it only supports AWS related cloud types")
@@ -2580,8 +2907,17 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	if d.HasChange("spot_price") {
+		err := client.UpdateSpotPrice(gateway.GwName, getString(d, "spot_price"))
+		if err != nil {
+			return fmt.Errorf("could not update spot price for gateway: %s during gateway update: %w", gateway.GwName, err)
+		}
+	}
+
 	d.Partial(false)
 	d.SetId(gateway.GwName)
+	client.ForgetCachedGateway(gateway.GwName)
+	client.ForgetCachedGateway(gateway.GwName + "-hagw")
 	return resourceAviatrixGatewayRead(d, meta)
 }
 
@@ -2658,11 +2994,424 @@ func checkPublicSubnetFilteringConfig(d *schema.ResourceData) error {
 	return nil
 }
 
+// peeringHaRegionMismatchWarning returns a warning if the peering HA gateway was reported in a
+// different region than the primary gateway.
+func peeringHaRegionMismatchWarning(gwName, primaryRegion, haRegion string) diag.Diagnostics {
+	if primaryRegion == "" || haRegion == "" || primaryRegion == haRegion {
+		return nil
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Peering HA gateway region mismatch",
+			Detail: fmt.Sprintf("The peering HA gateway of gateway %s is in region %q, but the gateway is in region %q. "+
+				"Check the peering_ha_subnet and peering_ha_zone of the gateway.", gwName, haRegion, primaryRegion),
+		},
+	}
+}
+
+// setPeeringHaTunnelDetectionTime sets the tunnel detection time of the Peering HA Gateway haGwName from
+// peering_ha_tunnel_detection_time. When it is not set, the controller default is restored if resetDefault
+// is true and the HA gateway is left untouched otherwise.
+func setPeeringHaTunnelDetectionTime(d *schema.ResourceData, client *goaviatrix.Client, haGwName string, resetDefault bool) error {
+	var detectionTime int
+	if v, ok := d.GetOk("peering_ha_tunnel_detection_time"); ok {
+		detectionTime = mustInt(v)
+	} else if resetDefault {
+		var err error
+		detectionTime, err = client.GetTunnelDetectionTime("Controller")
+		if err != nil {
+			return fmt.Errorf("could not get default tunnel detection time: %w", err)
+		}
+	} else {
+		return nil
+	}
+	return client.ModifyTunnelDetectionTime(haGwName, detectionTime)
+}
+
+// kmsKeyAliasArnRegex matches the ARN of an AWS KMS key alias.
+var kmsKeyAliasArnRegex = regexp.MustCompile(`^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:alias/[a-zA-Z0-9/_-]+$`)
+
+// isKmsKeyAlias returns whether the customer managed key refers to a KMS key alias rather than a key ID.
+func isKmsKeyAlias(key string) bool {
+	return strings.Contains(key, "alias/")
+}
+
+// validateCustomerManagedKeys checks that a customer managed key given as a KMS key alias is a valid alias ARN.
+// The value is sensitive and is therefore not included in the error.
+func validateCustomerManagedKeys(val interface{}, key string) ([]string, []error) {
+	v, ok := val.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", key)}
+	}
+	if isKmsKeyAlias(v) && !kmsKeyAliasArnRegex.MatchString(v) {
+		return nil, []error{fmt.Errorf("%q must be a key ID or a KMS key alias ARN in the format "+
+			"'arn:aws:kms:<region>:<account ID>:alias/<alias name>'", key)}
+	}
+	return nil, nil
+}
+
+// resolveCustomerManagedKeys returns the key ID to encrypt the gateway volume with, resolving a KMS key
+// alias ARN through the access account. Key IDs are returned unchanged.
+func resolveCustomerManagedKeys(client *goaviatrix.Client, accountName, key string) (string, error) {
+	if !isKmsKeyAlias(key) {
+		return key, nil
+	}
+	keyID, err := client.GetKmsKeyIDByAlias(accountName, key)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve KMS key alias: %w", err)
+	}
+	return keyID, nil
+}
+
+// resolveHaCustomerManagedKeys returns the key ID to encrypt the HA gateway volume with. The HA gateway
+// uses the primary gateway's key unless a key is given for it.
+func resolveHaCustomerManagedKeys(client *goaviatrix.Client, accountName, haKey, primaryKeyID string) (string, error) {
+	if haKey == "" {
+		return primaryKeyID, nil
+	}
+	return resolveCustomerManagedKeys(client, accountName, haKey)
+}
+
+// encryptPeeringHaGatewayVolume encrypts the volume of the Peering HA Gateway with
+// peering_ha_customer_managed_keys, or with primaryKeyID, the resolved customer_managed_keys, if it is not set.
+func encryptPeeringHaGatewayVolume(d *schema.ResourceData, client *goaviatrix.Client, primaryKeyID string) error {
+	keyID, err := resolveHaCustomerManagedKeys(client, getString(d, "account_name"), getString(d, "peering_ha_customer_managed_keys"), primaryKeyID)
+	if err != nil {
+		return err
+	}
+	gwHAEncVolume := &goaviatrix.Gateway{
+		GwName:              getString(d, "gw_name") + "-hagw",
+		CustomerManagedKeys: keyID,
+	}
+	err = client.EnableEncryptVolume(gwHAEncVolume)
+	if err != nil {
+		return fmt.Errorf("failed to enable encrypt gateway volume for %s due to %w", gwHAEncVolume.GwName, err)
+	}
+	return nil
+}
+
+// setGatewayGroGso enables or disables GRO/GSO on the gateway and, when haEnabled is set, on its peering HA gateway.
+func setGatewayGroGso(client *goaviatrix.Client, gwName string, haEnabled bool, enable bool) error {
+	gwNames := []string{gwName}
+	if haEnabled {
+		gwNames = append(gwNames, gwName+"-hagw")
+	}
+	for _, name := range gwNames {
+		gw := &goaviatrix.Gateway{GwName: name}
+		var err error
+		if enable {
+			err = client.EnableGroGso(gw)
+		} else {
+			err = client.DisableGroGso(gw)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// monitorExcludeListVpcWarnings returns a warning for each instance in monitor_exclude_list that is not
+// in the VPC of the gateway, as the controller silently ignores such instances. The instances are only
+// looked up when monitor gateway subnets is enabled and the list changed.
+func monitorExcludeListVpcWarnings(d *schema.ResourceData, client *goaviatrix.Client) diag.Diagnostics {
+	if !getBool(d, "enable_monitor_gateway_subnets") || !d.HasChanges("enable_monitor_gateway_subnets", "monitor_exclude_list") {
+		return nil
+	}
+
+	gwName := getString(d, "gw_name")
+	vpcID := getString(d, "vpc_id")
+	var diags diag.Diagnostics
+	for _, instanceID := range getStringSet(d, "monitor_exclude_list") {
+		instanceVpcID, err := client.GetInstanceVpcID(getString(d, "account_name"), getString(d, "vpc_reg"), instanceID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Could not verify excluded instance",
+				Detail: fmt.Sprintf("Could not look up the VPC of instance %s in 'monitor_exclude_list' of gateway %s: %s",
+					instanceID, gwName, err),
+			})
+			continue
+		}
+		if instanceVpcID != vpcID {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Excluded instance is not in the gateway VPC",
+				Detail: fmt.Sprintf("Instance %s in 'monitor_exclude_list' of gateway %s is in VPC %q, but the gateway is in VPC %q. "+
+					"The controller ignores excluded instances outside of the gateway VPC.", instanceID, gwName, instanceVpcID, vpcID),
+			})
+		}
+	}
+	return diags
+}
+
+// resolveMonitorExcludeNames returns the ids of the instances in the VPC of the gateway whose Name tag is
+// in monitor_exclude_name_list. Every name must match at least one instance.
+func resolveMonitorExcludeNames(d *schema.ResourceData, client *goaviatrix.Client) ([]string, error) {
+	var instanceIDs []string
+	for _, name := range getStringSet(d, "monitor_exclude_name_list") {
+		ids, err := client.GetInstanceIDsByName(getString(d, "account_name"), getString(d, "vpc_reg"), getString(d, "vpc_id"), name)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve instance name %q in 'monitor_exclude_name_list': %w", name, err)
+		}
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("no instance named %q in 'monitor_exclude_name_list' found in VPC %s", name, getString(d, "vpc_id"))
+		}
+		instanceIDs = append(instanceIDs, ids...)
+	}
+	return instanceIDs, nil
+}
+
+// mergeMonitorExcludeList returns instanceIDs followed by the resolved instance ids that are not already in it.
+func mergeMonitorExcludeList(instanceIDs, resolvedIDs []string) []string {
+	merged := append([]string{}, instanceIDs...)
+	for _, id := range resolvedIDs {
+		if !stringInSlice(id, merged) {
+			merged = append(merged, id)
+		}
+	}
+	return merged
+}
+
+// monitorExcludeListWithoutResolved returns the excluded instances reported by the controller without the
+// instances that were only excluded by name. Instances that are also in configuredIDs are kept.
+func monitorExcludeListWithoutResolved(excludedIDs, resolvedIDs, configuredIDs []string) []string {
+	var instanceIDs []string
+	for _, id := range excludedIDs {
+		if !stringInSlice(id, resolvedIDs) || stringInSlice(id, configuredIDs) {
+			instanceIDs = append(instanceIDs, id)
+		}
+	}
+	return instanceIDs
+}
+
+func resourceAviatrixGatewayCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if err := handleIPv6SubnetForceNew(d, "subnet_ipv6_cidr"); err != nil {
+		return err
+	}
+	if err := validateGatewayTagsReadable(d); err != nil {
+		return err
+	}
+	return handleGatewaySubnetForceNew(d)
+}
+
+// handleGatewaySubnetForceNew forces recreation when subnet or insane_mode_az changes, unless
+// recreate_in_place is set and the gateway can be recreated by the update.
+func handleGatewaySubnetForceNew(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.HasChanges("subnet", "insane_mode_az") {
+		return nil
+	}
+
+	if getBool(d, "recreate_in_place") && !getBool(d, "enable_public_subnet_filtering") &&
+		!d.HasChanges("peering_ha_subnet", "peering_ha_zone", "insane_mode") {
+		return nil
+	}
+
+	for _, key := range []string{"subnet", "insane_mode_az"} {
+		if d.HasChange(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recreateGatewayInPlace recreates the gateway and its peering HA gateway with the configuration in d,
+// so that a change of subnet or insane_mode_az does not replace the resource. The controller does not
+// allow two gateways with the same name, so the peering HA gateway and the gateway are deleted first,
+// retrying while the controller reports either of them as down, and then launched again by the
+// regular create path.
+func recreateGatewayInPlace(d *schema.ResourceData, meta interface{}) error {
+	client := mustClient(meta)
+	gwName := getString(d, "gw_name")
+	names := []string{gwName}
+	if getString(d, "peering_ha_subnet") != "" || getString(d, "peering_ha_zone") != "" {
+		names = []string{gwName + "-hagw", gwName}
+	}
+
+	for _, name := range names {
+		gateway := &goaviatrix.Gateway{
+			CloudType: getInt(d, "cloud_type"),
+			GwName:    name,
+		}
+		for i := 0; ; i++ {
+			log.Printf("[INFO] Deleting gateway %s to recreate it in subnet %s", name, getString(d, "subnet"))
+			err := client.DeleteGateway(gateway)
+			if err == nil {
+				break
+			}
+			if i <= 18 && (strings.Contains(err.Error(), "when it is down") || strings.Contains(err.Error(), "hagw is down") ||
+				strings.Contains(err.Error(), "gateway is down")) {
+				time.Sleep(10 * time.Second)
+			} else {
+				return fmt.Errorf("failed to delete gateway %s to recreate it: %w", name, err)
+			}
+		}
+	}
+
+	log.Printf("[INFO] Recreating gateway %s in subnet %s", gwName, getString(d, "subnet"))
+	return resourceAviatrixGatewayCreate(d, meta)
+}
+
+// validateGatewayIPv6 checks that IPv6 can be enabled on a gateway. Unlike spoke and transit
+// gateways, IPv6 is not supported on GCP gateways. VPN clients are only assigned IPv4 addresses,
+// so vpn_cidr must remain an IPv4 CIDR on VPN gateways.
+func validateGatewayIPv6(cloudType int, vpnAccess bool, vpnCidr string) error {
+	if err := IPv6SupportedOnCloudType(cloudType); err != nil {
+		return fmt.Errorf("enable_ipv6 is not supported, %w", err)
+	}
+	if !goaviatrix.IsCloudType(cloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes) {
+		return fmt.Errorf("enable_ipv6 is only supported for AWS (1) and Azure (8) related cloud types")
+	}
+	if vpnAccess {
+		ip, _, err := net.ParseCIDR(vpnCidr)
+		if err != nil || ip.To4() == nil {
+			return fmt.Errorf("vpn_cidr must be an IPv4 CIDR when enable_ipv6 is true, got %q", vpnCidr)
+		}
+	}
+	return nil
+}
+
+// validateGatewayClientCertAuth checks that VPN client certificate authentication is only enabled on VPN
+// gateways, with a CA certificate, and not along with SAML, LDAP or MFA authentication.
+func validateGatewayClientCertAuth(d *schema.ResourceData) error {
+	caCertName := getString(d, "client_cert_ca_name")
+	if !getBool(d, "enable_client_cert_auth") {
+		if caCertName != "" {
+			return fmt.Errorf("'client_cert_ca_name' is only valid if 'enable_client_cert_auth' is true")
+		}
+		return nil
+	}
+	if !getBool(d, "vpn_access") {
+		return fmt.Errorf("'enable_client_cert_auth' is only valid if 'vpn_access' is true")
+	}
+	if caCertName == "" {
+		return fmt.Errorf("'client_cert_ca_name' must be set if 'enable_client_cert_auth' is true")
+	}
+	if getBool(d, "saml_enabled") || getBool(d, "enable_ldap") || getString(d, "otp_mode") != "" {
+		return fmt.Errorf("client certificate authentication can't be configured along with saml, ldap or mfa")
+	}
+	return nil
+}
+
+// setGatewayPeeringHaDetails sets the peering HA attributes reported in the HA details of the gateway, and
+// clears all peering HA attributes when the gateway has no peering HA gateway.
+func setGatewayPeeringHaDetails(d *schema.ResourceData, haGw *goaviatrix.HaGateway) {
+	if haGw.GwSize == "" {
+		mustSet(d, "peering_ha_availability_domain", "")
+		mustSet(d, "peering_ha_azure_eip_name_resource_group", "")
+		mustSet(d, "peering_ha_cloud_instance_id", "")
+		mustSet(d, "peering_ha_eip", "")
+		mustSet(d, "peering_ha_fault_domain", "")
+		mustSet(d, "peering_ha_gw_name", "")
+		mustSet(d, "peering_ha_gw_size", "")
+		mustSet(d, "peering_ha_image_version", "")
+		mustSet(d, "peering_ha_insane_mode_az", "")
+		mustSet(d, "peering_ha_private_ip", "")
+		mustSet(d, "peering_ha_public_ip", "")
+		mustSet(d, "peering_ha_enable_gro_gso", false)
+		mustSet(d, "peering_ha_security_group_id", "")
+		mustSet(d, "peering_ha_software_version", "")
+		mustSet(d, "peering_ha_subnet", "")
+		mustSet(d, "peering_ha_tunnel_detection_time", 0)
+		mustSet(d, "peering_ha_zone", "")
+		return
+	}
+	mustSet(d, "peering_ha_cloud_instance_id", haGw.CloudnGatewayInstID)
+	mustSet(d, "peering_ha_gw_name", haGw.GwName)
+	mustSet(d, "peering_ha_eip", haGw.PublicIP)
+	mustSet(d, "peering_ha_gw_size", haGw.GwSize)
+	mustSet(d, "peering_ha_private_ip", haGw.PrivateIP)
+	mustSet(d, "peering_ha_public_ip", haGw.PublicIP)
+	mustSet(d, "peering_ha_software_version", haGw.SoftwareVersion)
+	mustSet(d, "peering_ha_image_version", haGw.ImageVersion)
+	mustSet(d, "peering_ha_security_group_id", haGw.GwSecurityGroupID)
+	mustSet(d, "peering_ha_tunnel_detection_time", haGw.TunnelDetectionTime)
+}
+
+// readGatewayClientCertAuth sets enable_client_cert_auth and client_cert_ca_name from the VPN client
+// certificate authentication of the gateway. Non-VPN gateways never have it enabled.
+func readGatewayClientCertAuth(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) error {
+	var caCertName string
+	if gw.VpnStatus == "enabled" {
+		var err error
+		caCertName, err = client.GetVpnClientCertAuth(gw.GwName)
+		if err != nil {
+			return fmt.Errorf("couldn't get VPN client certificate authentication of gateway %s: %w", gw.GwName, err)
+		}
+	}
+	mustSet(d, "enable_client_cert_auth", caCertName != "")
+	mustSet(d, "client_cert_ca_name", caCertName)
+	return nil
+}
+
+// readGatewayEffectiveMaxVpnConn sets effective_max_vpn_conn from the peering HA gateway, which serves the VPN
+// clients after a failover, or from the gateway itself without peering HA. Non-VPN gateways have no limit. It
+// is informational only, so a failed lookup keeps the last known value instead of failing the refresh.
+func readGatewayEffectiveMaxVpnConn(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) {
+	if gw.VpnStatus != "enabled" {
+		mustSet(d, "effective_max_vpn_conn", "")
+		return
+	}
+	if gw.HaGw.GwSize == "" {
+		mustSet(d, "effective_max_vpn_conn", gw.MaxConn)
+		return
+	}
+	haGw, err := getGatewayCached(client, gw.HaGw.GwName)
+	if err != nil {
+		log.Printf("[WARN] could not get peering HA gateway %s: %v", gw.HaGw.GwName, err)
+		return
+	}
+	mustSet(d, "effective_max_vpn_conn", haGw.MaxConn)
+}
+
+// getDesignatedGatewayCidrs returns the comma separated additional CIDRs of the designated gateway from
+// additional_cidrs_designated_gateway or the set attribute replacing it.
+func getDesignatedGatewayCidrs(d *schema.ResourceData) string {
+	if cidrs := getString(d, "additional_cidrs_designated_gateway"); cidrs != "" {
+		return cidrs
+	}
+	return strings.Join(expandStringSet(getSet(d, "additional_cidrs_designated_gateway_list")), ",")
+}
+
+// setDesignatedGatewayCidrs sets the comma separated additional CIDRs of the designated gateway read from the
+// controller into additional_cidrs_designated_gateway if it is in use, keeping its order when it holds the same
+// CIDRs. Otherwise they are set into the set attribute replacing it, which is also the one populated on import.
+func setDesignatedGatewayCidrs(d *schema.ResourceData, cidrs string) {
+	var cidrList []string
+	if cidrs != "" {
+		cidrList = strings.Split(cidrs, ",")
+	}
+	if current := getString(d, "additional_cidrs_designated_gateway"); current != "" {
+		if len(cidrList) != 0 && goaviatrix.Equivalent(strings.Split(current, ","), cidrList) {
+			mustSet(d, "additional_cidrs_designated_gateway", current)
+		} else {
+			mustSet(d, "additional_cidrs_designated_gateway", cidrs)
+		}
+		mustSet(d, "additional_cidrs_designated_gateway_list", nil)
+		return
+	}
+	mustSet(d, "additional_cidrs_designated_gateway", "")
+	mustSet(d, "additional_cidrs_designated_gateway_list", cidrList)
+}
+
+// splitTunnelSaveTemplate returns the save_template value sent to the controller with split tunnel settings.
+func splitTunnelSaveTemplate(d *schema.ResourceData) string {
+	if getBool(d, "save_split_tunnel_template") {
+		return "yes"
+	}
+	return "no"
+}
+
 // Attributes that cannot be set when enabling public subnet filtering.
 var conflictingPublicSubnetFilteringGatewayConfigKeys = []string{
 	"additional_cidrs",
 	"additional_cidrs_designated_gateway",
+	"additional_cidrs_designated_gateway_list",
 	"allocate_new_eip",
+	"client_cert_ca_name",
 	"customer_managed_keys",
 	"duo_api_hostname",
 	"duo_integration_key",
@@ -2670,6 +3419,7 @@ var conflictingPublicSubnetFilteringGatewayConfigKeys = []string{
 	"duo_secret_key",
 	"eip",
 	"elb_name",
+	"enable_client_cert_auth",
 	"enable_designated_gateway",
 	"enable_elb",
 	"enable_ldap",
@@ -2688,15 +3438,18 @@ var conflictingPublicSubnetFilteringGatewayConfigKeys = []string{
 	"ldap_username_attribute",
 	"max_vpn_conn",
 	"monitor_exclude_list",
+	"monitor_exclude_name_list",
 	"name_servers",
 	"okta_token",
 	"okta_url",
 	"okta_username_suffix",
 	"otp_mode",
+	"peering_ha_customer_managed_keys",
 	"peering_ha_eip",
 	"peering_ha_insane_mode_az",
 	"renegotiation_interval",
 	"saml_enabled",
+	"save_split_tunnel_template",
 	"search_domains",
 	"single_ip_snat",
 	"split_tunnel",
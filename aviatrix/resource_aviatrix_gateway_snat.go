@@ -42,86 +42,7 @@ func resourceAviatrixGatewaySNat() *schema.Resource {
 				Default:          nil,
 				DiffSuppressFunc: goaviatrix.DiffSuppressFuncGatewaySNat,
 				Description:      "Policy rules applied for 'snat_mode'' of 'customized_snat'.'",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"src_cidr": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Description: "This is a qualifier condition that specifies a source IP address range " +
-								"where the rule applies. When left blank, this field is not used.",
-						},
-						"src_port": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Description: "This is a qualifier condition that specifies a source port that the rule applies. " +
-								"When left blank, this field is not used.",
-						},
-						"dst_cidr": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Description: "This is a qualifier condition that specifies a destination IP address range " +
-								"where the rule applies. When left blank, this field is not used.",
-						},
-						"dst_port": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Description: "This is a qualifier condition that specifies a destination port " +
-								"where the rule applies. When left blank, this field is not used.",
-						},
-						"interface": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							DiffSuppressFunc: DiffSuppressFuncNatInterface,
-							Description: "This is a qualifier condition that specifies output interface " +
-								"where the rule applies. When left blank, this field is not used.",
-						},
-						"mark": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Description: "This is a qualifier condition that specifies a tag or mark of a TCP session " +
-								"where the rule applies. When left blank, this field is not used.",
-						},
-						"snat_ips": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Description: "This is a rule field that specifies the changed source IP address " +
-								"when all specified qualifier conditions meet. When left blank, this field is not used. " +
-								"One of the rule fields must be specified for this rule to take effect.",
-						},
-						"snat_port": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Description: "This is a rule field that specifies the changed source port " +
-								"when all specified qualifier conditions meet. When left blank, this field is not used. " +
-								"One of the rule fields must be specified for this rule to take effect.",
-						},
-						"exclude_rtb": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "This field specifies which VPC private route table will not be programmed with the default route entry.",
-						},
-						"protocol": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Default:      "all",
-							ValidateFunc: validation.StringInSlice([]string{"all", "tcp", "udp", "icmp"}, false),
-							Description: "This is a qualifier condition that specifies a destination port protocol " +
-								"where the rule applies. Default: all.",
-						},
-						"connection": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "None",
-							Description: "This is a qualifier condition that specifies output connection where the rule applies. When left blank, this field is not used.",
-						},
-						"apply_route_entry": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     true,
-							Description: "This is an option to program the route entry 'DST CIDR pointing to Aviatrix Gateway' into Cloud platform routing table. Type: Boolean. Default: True.",
-						},
-					},
-				},
+				Elem:             snatPolicyResource(),
 			},
 			"sync_to_ha": {
 				Type:        schema.TypeBool,
@@ -260,27 +181,7 @@ func resourceAviatrixGatewaySNatCreate(d *schema.ResourceData, meta interface{})
 	gateway.EnableNat = "yes"
 	gateway.SnatMode = "custom"
 
-	if _, ok := d.GetOk("snat_policy"); ok {
-		policies := getList(d, "snat_policy")
-		for _, policy := range policies {
-			pl := mustMap(policy)
-			customPolicy := &goaviatrix.PolicyRule{
-				SrcIP:           mustString(pl["src_cidr"]),
-				SrcPort:         mustString(pl["src_port"]),
-				DstIP:           mustString(pl["dst_cidr"]),
-				DstPort:         mustString(pl["dst_port"]),
-				Protocol:        mustString(pl["protocol"]),
-				Interface:       mustString(pl["interface"]),
-				Connection:      mustString(pl["connection"]),
-				Mark:            mustString(pl["mark"]),
-				NewSrcIP:        mustString(pl["snat_ips"]),
-				NewSrcPort:      mustString(pl["snat_port"]),
-				ExcludeRTB:      mustString(pl["exclude_rtb"]),
-				ApplyRouteEntry: mustBool(pl["apply_route_entry"]),
-			}
-			gateway.SnatPolicy = append(gateway.SnatPolicy, *customPolicy)
-		}
-	}
+	gateway.SnatPolicy = expandSNatPolicy(getList(d, "snat_policy"))
 
 	d.SetId(gateway.GatewayName)
 	flag := false
@@ -336,44 +237,16 @@ func resourceAviatrixGatewaySNatRead(d *schema.ResourceData, meta interface{}) e
 		}
 		if gw.NatEnabled && gw.SnatMode == "customized" {
 			mustSet(d, "snat_mode", "customized_snat")
-			var snatPolicy []map[string]interface{}
-			var connectionPolicy []map[string]interface{}
-			var interfacePolicy []map[string]interface{}
-
 			// Duplicate SNAT policies can be returned from the API.
 			// Before we save the policies to state we need to deduplicate.
-			dedupMap := make(map[string]struct{})
-			for _, policy := range gwDetail.SnatPolicy {
-				sP := make(map[string]interface{})
-				sP["src_cidr"] = policy.SrcIP
-				sP["src_port"] = policy.SrcPort
-				sP["dst_cidr"] = policy.DstIP
-				sP["dst_port"] = policy.DstPort
-				sP["protocol"] = policy.Protocol
-				sP["interface"] = policy.Interface
-				sP["connection"] = policy.Connection
-				sP["mark"] = policy.Mark
-				sP["snat_ips"] = policy.NewSrcIP
-				sP["snat_port"] = policy.NewSrcPort
-				sP["exclude_rtb"] = policy.ExcludeRTB
-				sP["apply_route_entry"] = policy.ApplyRouteEntry
-
-				// To deduplicate we will generate a unique key for each policy.
-				key := fmt.Sprintf("%s~%s~%s~%s~%s~%s~%s~%s~%s~%s~%s", policy.SrcIP, policy.SrcPort, policy.DstIP,
-					policy.DstPort, policy.Protocol, policy.Interface, policy.Connection, policy.Mark, policy.NewSrcIP, policy.NewSrcPort, policy.ExcludeRTB)
-				// If the map already contains the unique key then we know this policy is a duplicate.
-				if _, ok := dedupMap[key]; ok {
-					continue
-				}
-
-				// Otherwise, its a unique policy so we write it to state and the dedupMap.
-				dedupMap[key] = struct{}{}
-				snatPolicy = append(snatPolicy, sP)
-
-				if policy.Connection != "None" {
+			snatPolicy := flattenSNatPolicy(gwDetail.SnatPolicy)
+			var connectionPolicy []map[string]interface{}
+			var interfacePolicy []map[string]interface{}
+			for _, sP := range snatPolicy {
+				if mustString(sP["connection"]) != "None" {
 					connectionPolicy = append(connectionPolicy, sP)
 				}
-				if policy.Interface != "" {
+				if mustString(sP["interface"]) != "" {
 					interfacePolicy = append(interfacePolicy, sP)
 				}
 			}
@@ -414,27 +287,7 @@ func resourceAviatrixGatewaySNatUpdate(d *schema.ResourceData, meta interface{})
 		}
 
 		gateway.SnatMode = "custom"
-		if _, ok := d.GetOk("snat_policy"); ok {
-			policies := getList(d, "snat_policy")
-			for _, policy := range policies {
-				pl := mustMap(policy)
-				customPolicy := &goaviatrix.PolicyRule{
-					SrcIP:           mustString(pl["src_cidr"]),
-					SrcPort:         mustString(pl["src_port"]),
-					DstIP:           mustString(pl["dst_cidr"]),
-					DstPort:         mustString(pl["dst_port"]),
-					Protocol:        mustString(pl["protocol"]),
-					Interface:       mustString(pl["interface"]),
-					Connection:      mustString(pl["connection"]),
-					Mark:            mustString(pl["mark"]),
-					NewSrcIP:        mustString(pl["snat_ips"]),
-					NewSrcPort:      mustString(pl["snat_port"]),
-					ExcludeRTB:      mustString(pl["exclude_rtb"]),
-					ApplyRouteEntry: mustBool(pl["apply_route_entry"]),
-				}
-				gateway.SnatPolicy = append(gateway.SnatPolicy, *customPolicy)
-			}
-		}
+		gateway.SnatPolicy = expandSNatPolicy(getList(d, "snat_policy"))
 
 		err := client.EnableCustomizedSNat(gateway)
 		if err != nil {
@@ -461,3 +314,141 @@ func resourceAviatrixGatewaySNatDelete(d *schema.ResourceData, meta interface{})
 
 	return nil
 }
+
+// snatPolicyResource returns the schema of a customized SNAT policy rule.
+func snatPolicyResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"src_cidr": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "This is a qualifier condition that specifies a source IP address range " +
+					"where the rule applies. When left blank, this field is not used.",
+			},
+			"src_port": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "This is a qualifier condition that specifies a source port that the rule applies. " +
+					"When left blank, this field is not used.",
+			},
+			"dst_cidr": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "This is a qualifier condition that specifies a destination IP address range " +
+					"where the rule applies. When left blank, this field is not used.",
+			},
+			"dst_port": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "This is a qualifier condition that specifies a destination port " +
+					"where the rule applies. When left blank, this field is not used.",
+			},
+			"interface": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: DiffSuppressFuncNatInterface,
+				Description: "This is a qualifier condition that specifies output interface " +
+					"where the rule applies. When left blank, this field is not used.",
+			},
+			"mark": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "This is a qualifier condition that specifies a tag or mark of a TCP session " +
+					"where the rule applies. When left blank, this field is not used.",
+			},
+			"snat_ips": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "This is a rule field that specifies the changed source IP address " +
+					"when all specified qualifier conditions meet. When left blank, this field is not used. " +
+					"One of the rule fields must be specified for this rule to take effect.",
+			},
+			"snat_port": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "This is a rule field that specifies the changed source port " +
+					"when all specified qualifier conditions meet. When left blank, this field is not used. " +
+					"One of the rule fields must be specified for this rule to take effect.",
+			},
+			"exclude_rtb": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "This field specifies which VPC private route table will not be programmed with the default route entry.",
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validation.StringInSlice([]string{"all", "tcp", "udp", "icmp"}, false),
+				Description: "This is a qualifier condition that specifies a destination port protocol " +
+					"where the rule applies. Default: all.",
+			},
+			"connection": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "None",
+				Description: "This is a qualifier condition that specifies output connection where the rule applies. When left blank, this field is not used.",
+			},
+			"apply_route_entry": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "This is an option to program the route entry 'DST CIDR pointing to Aviatrix Gateway' into Cloud platform routing table. Type: Boolean. Default: True.",
+			},
+		},
+	}
+}
+
+// flattenSNatPolicy converts the policy rules read from the controller into snat_policy blocks. The
+// controller can return duplicate rules, only the first occurrence of a rule is kept.
+func flattenSNatPolicy(policyRules []goaviatrix.PolicyRule) []map[string]interface{} {
+	var policies []map[string]interface{}
+	dedupMap := make(map[string]struct{})
+	for _, policy := range policyRules {
+		// To deduplicate we will generate a unique key for each policy.
+		key := fmt.Sprintf("%s~%s~%s~%s~%s~%s~%s~%s~%s~%s~%s", policy.SrcIP, policy.SrcPort, policy.DstIP,
+			policy.DstPort, policy.Protocol, policy.Interface, policy.Connection, policy.Mark, policy.NewSrcIP, policy.NewSrcPort, policy.ExcludeRTB)
+		if _, ok := dedupMap[key]; ok {
+			continue
+		}
+		dedupMap[key] = struct{}{}
+		policies = append(policies, map[string]interface{}{
+			"src_cidr":          policy.SrcIP,
+			"src_port":          policy.SrcPort,
+			"dst_cidr":          policy.DstIP,
+			"dst_port":          policy.DstPort,
+			"protocol":          policy.Protocol,
+			"interface":         policy.Interface,
+			"connection":        policy.Connection,
+			"mark":              policy.Mark,
+			"snat_ips":          policy.NewSrcIP,
+			"snat_port":         policy.NewSrcPort,
+			"exclude_rtb":       policy.ExcludeRTB,
+			"apply_route_entry": policy.ApplyRouteEntry,
+		})
+	}
+	return policies
+}
+
+// expandSNatPolicy converts snat_policy blocks into the policy rules sent to the controller.
+func expandSNatPolicy(policies []interface{}) []goaviatrix.PolicyRule {
+	var policyRules []goaviatrix.PolicyRule
+	for _, policy := range policies {
+		pl := mustMap(policy)
+		policyRules = append(policyRules, goaviatrix.PolicyRule{
+			SrcIP:           mustString(pl["src_cidr"]),
+			SrcPort:         mustString(pl["src_port"]),
+			DstIP:           mustString(pl["dst_cidr"]),
+			DstPort:         mustString(pl["dst_port"]),
+			Protocol:        mustString(pl["protocol"]),
+			Interface:       mustString(pl["interface"]),
+			Connection:      mustString(pl["connection"]),
+			Mark:            mustString(pl["mark"]),
+			NewSrcIP:        mustString(pl["snat_ips"]),
+			NewSrcPort:      mustString(pl["snat_port"]),
+			ExcludeRTB:      mustString(pl["exclude_rtb"]),
+			ApplyRouteEntry: mustBool(pl["apply_route_entry"]),
+		})
+	}
+	return policyRules
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 
 	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
 )
@@ -252,3 +253,14 @@ func preGatewaySNatCheck(t *testing.T, msgCommon string) string {
 	}
 	return ""
 }
+
+func TestFlattenSNatPolicyDeduplicates(t *testing.T) {
+	rule := goaviatrix.PolicyRule{SrcIP: "10.0.0.0/16", Protocol: "all", Connection: "None", NewSrcIP: "10.0.0.10"}
+	other := goaviatrix.PolicyRule{SrcIP: "10.1.0.0/16", Protocol: "all", Interface: "eth0", Connection: "None"}
+
+	policies := flattenSNatPolicy([]goaviatrix.PolicyRule{rule, other, rule})
+	assert.Len(t, policies, 2)
+	assert.Equal(t, "10.0.0.0/16", policies[0]["src_cidr"])
+	assert.Equal(t, "10.1.0.0/16", policies[1]["src_cidr"])
+	assert.Equal(t, "eth0", policies[1]["interface"])
+}
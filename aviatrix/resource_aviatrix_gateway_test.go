@@ -1,13 +1,21 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 
 	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
 )
@@ -202,6 +210,7 @@ func TestAccAviatrixGateway_basic(t *testing.T) {
 						resource.TestCheckResourceAttr(resourceNameAws, "vpc_id", awsVpcId),
 						resource.TestCheckResourceAttr(resourceNameAws, "subnet", awsVpcNet),
 						resource.TestCheckResourceAttr(resourceNameAws, "vpc_reg", awsRegion),
+						resource.TestMatchResourceAttr(resourceNameAws, "eip_allocation_id", regexp.MustCompile(`^eipalloc-`)),
 					),
 				},
 				{
@@ -394,6 +403,267 @@ resource "aviatrix_gateway" "test_gw_aws" {
 		awsVpcId, awsRegion, awsGwSize, awsVpcNet)
 }
 
+func TestAccAviatrixGateway_ipv6VPN(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_gateway.test_gw_ipv6_vpn"
+
+	msgCommon := ". Set SKIP_GATEWAY_IPV6 to yes to skip Gateway IPv6 tests"
+
+	if os.Getenv("SKIP_GATEWAY") == "yes" || os.Getenv("SKIP_GATEWAY_IPV6") == "yes" {
+		t.Skip("Skipping Gateway IPv6 test as SKIP_GATEWAY or SKIP_GATEWAY_IPV6 is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preAccountCheck(t, msgCommon)
+			for _, v := range []string{"AWS_VPC_ID", "AWS_REGION", "AWS_SUBNET", "AWS_SUBNET_IPV6_CIDR"} {
+				if os.Getenv(v) == "" {
+					t.Fatalf("Env Var %s required %s", v, msgCommon)
+				}
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGatewayConfigIPv6VPN(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "vpn_access", "true"),
+					resource.TestCheckResourceAttr(resourceName, "enable_ipv6", "true"),
+					resource.TestCheckResourceAttr(resourceName, "subnet_ipv6_cidr", os.Getenv("AWS_SUBNET_IPV6_CIDR")),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccGatewayConfigIPv6VPN(rName string) string {
+	awsGwSize := os.Getenv("AWS_GW_SIZE")
+	if awsGwSize == "" {
+		awsGwSize = "t2.micro"
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tf-acc-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_gateway" "test_gw_ipv6_vpn" {
+	cloud_type       = 1
+	account_name     = aviatrix_account.test_acc_aws.account_name
+	gw_name          = "tfg-aws-ipv6-%[1]s"
+	vpc_id           = "%[5]s"
+	vpc_reg          = "%[6]s"
+	gw_size          = "%[7]s"
+	subnet           = "%[8]s"
+	vpn_access       = true
+	vpn_cidr         = "192.168.43.0/24"
+	max_vpn_conn     = "100"
+	enable_ipv6      = true
+	subnet_ipv6_cidr = "%[9]s"
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), awsGwSize, os.Getenv("AWS_SUBNET"),
+		os.Getenv("AWS_SUBNET_IPV6_CIDR"))
+}
+
+func TestAccAviatrixGateway_publicSubnetFilteringImport(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_gateway.test_gw_psf"
+
+	msgCommon := ". Set SKIP_GATEWAY_PSF to yes to skip Public Subnet Filtering Gateway tests"
+
+	if os.Getenv("SKIP_GATEWAY") == "yes" || os.Getenv("SKIP_GATEWAY_PSF") == "yes" {
+		t.Skip("Skipping Public Subnet Filtering Gateway test as SKIP_GATEWAY or SKIP_GATEWAY_PSF is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preAccountCheck(t, msgCommon)
+			for _, v := range []string{
+				"AWS_VPC_ID", "AWS_REGION",
+				"AWS_PSF_SUBNET", "AWS_PSF_ZONE", "AWS_PSF_ROUTE_TABLE",
+				"AWS_PSF_HA_SUBNET", "AWS_PSF_HA_ZONE", "AWS_PSF_HA_ROUTE_TABLE",
+			} {
+				if os.Getenv(v) == "" {
+					t.Fatalf("Env Var %s required %s", v, msgCommon)
+				}
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGatewayConfigPublicSubnetFilteringWithHA(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "enable_public_subnet_filtering", "true"),
+					resource.TestCheckResourceAttr(resourceName, "public_subnet_filtering_guard_duty_enforced", "true"),
+					testCheckStringSet(resourceName, "public_subnet_filtering_route_tables", []string{os.Getenv("AWS_PSF_ROUTE_TABLE")}),
+					testCheckStringSet(resourceName, "public_subnet_filtering_ha_route_tables", []string{os.Getenv("AWS_PSF_HA_ROUTE_TABLE")}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Importing must not leave any difference with the configuration.
+				Config:             testAccGatewayConfigPublicSubnetFilteringWithHA(rName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func testAccGatewayConfigPublicSubnetFilteringWithHA(rName string) string {
+	awsGwSize := os.Getenv("AWS_GW_SIZE")
+	if awsGwSize == "" {
+		awsGwSize = "t3.small"
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tf-acc-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_gateway" "test_gw_psf" {
+	cloud_type                                  = 1
+	account_name                                = aviatrix_account.test_acc_aws.account_name
+	gw_name                                     = "tfg-aws-psf-%[1]s"
+	vpc_id                                      = "%[5]s"
+	vpc_reg                                     = "%[6]s"
+	gw_size                                     = "%[7]s"
+	subnet                                      = "%[8]s"
+	zone                                        = "%[9]s"
+	peering_ha_subnet                           = "%[10]s"
+	peering_ha_zone                             = "%[11]s"
+	peering_ha_gw_size                          = "%[7]s"
+	enable_encrypt_volume                       = true
+	enable_public_subnet_filtering              = true
+	public_subnet_filtering_route_tables        = ["%[12]s"]
+	public_subnet_filtering_ha_route_tables     = ["%[13]s"]
+	public_subnet_filtering_guard_duty_enforced = true
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), awsGwSize,
+		os.Getenv("AWS_PSF_SUBNET"), os.Getenv("AWS_PSF_ZONE"),
+		os.Getenv("AWS_PSF_HA_SUBNET"), os.Getenv("AWS_PSF_HA_ZONE"),
+		os.Getenv("AWS_PSF_ROUTE_TABLE"), os.Getenv("AWS_PSF_HA_ROUTE_TABLE"))
+}
+
+func TestAccAviatrixGateway_peeringHaEncryptVolume(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_gateway.test_gw_enc"
+
+	msgCommon := ". Set SKIP_GATEWAY_AWS to yes to skip AWS Gateway tests"
+
+	if os.Getenv("SKIP_GATEWAY") == "yes" || os.Getenv("SKIP_GATEWAY_AWS") == "yes" {
+		t.Skip("Skipping Gateway volume encryption test as SKIP_GATEWAY or SKIP_GATEWAY_AWS is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preGatewayCheck(t, msgCommon)
+			if os.Getenv("AWS_HA_SUBNET") == "" {
+				t.Fatal("Environment variable AWS_HA_SUBNET is not set" + msgCommon)
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGatewayConfigEncryptVolume(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "enable_encrypt_volume", "true"),
+				),
+			},
+			{
+				// Adding the Peering HA Gateway later must encrypt its volume as well.
+				Config: testAccGatewayConfigEncryptVolume(rName, os.Getenv("AWS_HA_SUBNET")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "peering_ha_subnet", os.Getenv("AWS_HA_SUBNET")),
+					testAccCheckGatewayVolumeEncrypted(fmt.Sprintf("tfg-aws-enc-%s-hagw", rName)),
+				),
+			},
+		},
+	})
+}
+
+func testAccGatewayConfigEncryptVolume(rName string, haSubnet string) string {
+	awsGwSize := os.Getenv("AWS_GW_SIZE")
+	if awsGwSize == "" {
+		awsGwSize = "t3.small"
+	}
+	peeringHa := ""
+	if haSubnet != "" {
+		peeringHa = fmt.Sprintf(`
+	peering_ha_subnet     = "%s"
+	peering_ha_gw_size    = "%s"`, haSubnet, awsGwSize)
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tf-acc-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_gateway" "test_gw_enc" {
+	cloud_type            = 1
+	account_name          = aviatrix_account.test_acc_aws.account_name
+	gw_name               = "tfg-aws-enc-%[1]s"
+	vpc_id                = "%[5]s"
+	vpc_reg               = "%[6]s"
+	gw_size               = "%[7]s"
+	subnet                = "%[8]s"
+	enable_encrypt_volume = true%[9]s
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), awsGwSize, os.Getenv("AWS_SUBNET"), peeringHa)
+}
+
+func testAccCheckGatewayVolumeEncrypted(gwName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := mustClient(testAccProvider.Meta())
+
+		gw, err := client.GetGateway(&goaviatrix.Gateway{GwName: gwName})
+		if err != nil {
+			return err
+		}
+		if !gw.EnableEncryptVolume {
+			return fmt.Errorf("volume of gateway %s is not encrypted", gwName)
+		}
+		return nil
+	}
+}
+
 func testAccGatewayConfigBasicGCP(rName string, gcpGwSize string, gcpVpcId string, gcpZone string, gcpSubnet string) string {
 	return fmt.Sprintf(`
 resource "aviatrix_account" "test_acc_gcp" {
@@ -535,3 +805,1040 @@ func testAccCheckGatewayDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestGetDesignatedGatewayCidrs(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "string attribute",
+			config:   map[string]interface{}{"additional_cidrs_designated_gateway": "10.9.0.0/16,10.8.0.0/16"},
+			expected: "10.9.0.0/16,10.8.0.0/16",
+		},
+		{
+			name:     "set attribute",
+			config:   map[string]interface{}{"additional_cidrs_designated_gateway_list": []interface{}{"10.9.0.0/16", "10.8.0.0/16"}},
+			expected: "10.8.0.0/16,10.9.0.0/16",
+		},
+		{
+			name:   "not configured",
+			config: map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, tt.config)
+			assert.Equal(t, tt.expected, getDesignatedGatewayCidrs(d))
+		})
+	}
+}
+
+func TestSetDesignatedGatewayCidrs(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         map[string]interface{}
+		cidrs          string
+		expectedString string
+		expectedList   []string
+	}{
+		{
+			name:           "string attribute with same CIDRs keeps its order",
+			config:         map[string]interface{}{"additional_cidrs_designated_gateway": "10.9.0.0/16,10.8.0.0/16"},
+			cidrs:          "10.8.0.0/16,10.9.0.0/16",
+			expectedString: "10.9.0.0/16,10.8.0.0/16",
+		},
+		{
+			name:           "string attribute with drifted CIDRs",
+			config:         map[string]interface{}{"additional_cidrs_designated_gateway": "10.9.0.0/16"},
+			cidrs:          "10.8.0.0/16,10.10.0.0/16",
+			expectedString: "10.8.0.0/16,10.10.0.0/16",
+		},
+		{
+			name:         "set attribute reordered by the controller",
+			config:       map[string]interface{}{"additional_cidrs_designated_gateway_list": []interface{}{"10.8.0.0/16", "10.9.0.0/16"}},
+			cidrs:        "10.9.0.0/16,10.8.0.0/16",
+			expectedList: []string{"10.8.0.0/16", "10.9.0.0/16"},
+		},
+		{
+			name:   "set attribute with CIDRs removed",
+			config: map[string]interface{}{"additional_cidrs_designated_gateway_list": []interface{}{"10.8.0.0/16"}},
+		},
+		{
+			name:         "import",
+			config:       map[string]interface{}{},
+			cidrs:        "10.8.0.0/16",
+			expectedList: []string{"10.8.0.0/16"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, tt.config)
+
+			setDesignatedGatewayCidrs(d, tt.cidrs)
+			assert.Equal(t, tt.expectedString, getString(d, "additional_cidrs_designated_gateway"))
+			assert.ElementsMatch(t, tt.expectedList, getStringSet(d, "additional_cidrs_designated_gateway_list"))
+		})
+	}
+}
+
+func TestSplitTunnelSaveTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "default",
+			raw:      map[string]interface{}{},
+			expected: "no",
+		},
+		{
+			name:     "save template",
+			raw:      map[string]interface{}{"save_split_tunnel_template": true},
+			expected: "yes",
+		},
+		{
+			name:     "do not save template",
+			raw:      map[string]interface{}{"save_split_tunnel_template": false},
+			expected: "no",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, tt.raw)
+			assert.Equal(t, tt.expected, splitTunnelSaveTemplate(d))
+		})
+	}
+}
+
+func TestValidateGatewayClientCertAuth(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name:   "disabled",
+			config: map[string]interface{}{},
+		},
+		{
+			name: "enabled on VPN gateway",
+			config: map[string]interface{}{
+				"vpn_access":              true,
+				"enable_client_cert_auth": true,
+				"client_cert_ca_name":     "vpn-client-ca",
+			},
+		},
+		{
+			name: "enabled on non-VPN gateway",
+			config: map[string]interface{}{
+				"enable_client_cert_auth": true,
+				"client_cert_ca_name":     "vpn-client-ca",
+			},
+			expectError: "'enable_client_cert_auth' is only valid if 'vpn_access' is true",
+		},
+		{
+			name: "enabled without CA",
+			config: map[string]interface{}{
+				"vpn_access":              true,
+				"enable_client_cert_auth": true,
+			},
+			expectError: "'client_cert_ca_name' must be set",
+		},
+		{
+			name: "CA without client certificate authentication",
+			config: map[string]interface{}{
+				"vpn_access":          true,
+				"client_cert_ca_name": "vpn-client-ca",
+			},
+			expectError: "'client_cert_ca_name' is only valid if 'enable_client_cert_auth' is true",
+		},
+		{
+			name: "enabled with SAML",
+			config: map[string]interface{}{
+				"vpn_access":              true,
+				"enable_client_cert_auth": true,
+				"client_cert_ca_name":     "vpn-client-ca",
+				"saml_enabled":            true,
+			},
+			expectError: "can't be configured along with saml, ldap or mfa",
+		},
+		{
+			name: "enabled with MFA",
+			config: map[string]interface{}{
+				"vpn_access":              true,
+				"enable_client_cert_auth": true,
+				"client_cert_ca_name":     "vpn-client-ca",
+				"otp_mode":                "2",
+			},
+			expectError: "can't be configured along with saml, ldap or mfa",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, tt.config)
+
+			err := validateGatewayClientCertAuth(d)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReadGatewayClientCertAuth(t *testing.T) {
+	tests := []struct {
+		name               string
+		vpnStatus          string
+		expectedActions    []string
+		expectedEnabled    bool
+		expectedCaCertName string
+	}{
+		{
+			name:               "VPN gateway",
+			vpnStatus:          "enabled",
+			expectedActions:    []string{"get_vpn_client_cert_auth"},
+			expectedEnabled:    true,
+			expectedCaCertName: "vpn-client-ca",
+		},
+		{
+			name:      "non-VPN gateway",
+			vpnStatus: "disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				body: `{"return": true, "results": {"enabled": true, "ca_cert_name": "vpn-client-ca"}, "reason": ""}`,
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, map[string]interface{}{
+				"gw_name": "vpn-gw",
+			})
+
+			err := readGatewayClientCertAuth(d, client, &goaviatrix.Gateway{GwName: "vpn-gw", VpnStatus: tt.vpnStatus})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			assert.Equal(t, tt.expectedEnabled, getBool(d, "enable_client_cert_auth"))
+			assert.Equal(t, tt.expectedCaCertName, getString(d, "client_cert_ca_name"))
+		})
+	}
+}
+
+func TestSetGatewayPeeringHaDetails(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, map[string]interface{}{
+		"gw_name": "gw",
+	})
+
+	setGatewayPeeringHaDetails(d, &goaviatrix.HaGateway{
+		GwName:    "gw-hagw",
+		GwSize:    "t3.small",
+		PublicIP:  "203.0.113.20",
+		PrivateIP: "10.0.1.10",
+	})
+	assert.Equal(t, "gw-hagw", getString(d, "peering_ha_gw_name"))
+	assert.Equal(t, "203.0.113.20", getString(d, "peering_ha_public_ip"))
+	assert.Equal(t, "10.0.1.10", getString(d, "peering_ha_private_ip"))
+
+	setGatewayPeeringHaDetails(d, &goaviatrix.HaGateway{})
+	assert.Equal(t, "", getString(d, "peering_ha_gw_name"))
+	assert.Equal(t, "", getString(d, "peering_ha_public_ip"))
+	assert.Equal(t, "", getString(d, "peering_ha_private_ip"))
+}
+
+func TestReadGatewayEffectiveMaxVpnConn(t *testing.T) {
+	tests := []struct {
+		name            string
+		gw              *goaviatrix.Gateway
+		response        string
+		expectedActions []string
+		expected        string
+	}{
+		{
+			name: "VPN gateway with peering HA",
+			gw: &goaviatrix.Gateway{
+				GwName:    "vpn-gw",
+				VpnStatus: "enabled",
+				MaxConn:   "200",
+				HaGw:      goaviatrix.HaGateway{GwName: "vpn-gw-hagw", GwSize: "t3.small"},
+			},
+			expectedActions: []string{"list_vpcs_summary"},
+			expected:        "100",
+		},
+		{
+			name: "peering HA gateway lookup fails",
+			gw: &goaviatrix.Gateway{
+				GwName:    "vpn-gw",
+				VpnStatus: "enabled",
+				MaxConn:   "200",
+				HaGw:      goaviatrix.HaGateway{GwName: "vpn-gw-hagw", GwSize: "t3.small"},
+			},
+			response:        `{"return": false, "reason": "controller busy"}`,
+			expectedActions: []string{"list_vpcs_summary"},
+			expected:        "150",
+		},
+		{
+			name:     "VPN gateway without peering HA",
+			gw:       &goaviatrix.Gateway{GwName: "vpn-gw", VpnStatus: "enabled", MaxConn: "200"},
+			expected: "200",
+		},
+		{
+			name: "non-VPN gateway",
+			gw:   &goaviatrix.Gateway{GwName: "vpn-gw", VpnStatus: "disabled"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := tt.response
+			if response == "" {
+				response = `{"return": true, "results": [{"vpc_name": "vpn-gw-hagw", "max_connections": "100"}], "reason": ""}`
+			}
+			transport := &fakeControllerTransport{body: response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, map[string]interface{}{
+				"gw_name": "vpn-gw",
+			})
+			mustSet(d, "effective_max_vpn_conn", "150")
+
+			readGatewayEffectiveMaxVpnConn(d, client, tt.gw)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			assert.Equal(t, tt.expected, getString(d, "effective_max_vpn_conn"))
+		})
+	}
+}
+
+func TestValidateGatewayIPv6(t *testing.T) {
+	tests := []struct {
+		name        string
+		cloudType   int
+		vpnAccess   bool
+		vpnCidr     string
+		expectError string
+	}{
+		{
+			name:      "AWS gateway",
+			cloudType: goaviatrix.AWS,
+		},
+		{
+			name:      "Azure VPN gateway with IPv4 vpn_cidr",
+			cloudType: goaviatrix.Azure,
+			vpnAccess: true,
+			vpnCidr:   "192.168.43.0/24",
+		},
+		{
+			name:        "GCP gateway",
+			cloudType:   goaviatrix.GCP,
+			expectError: "only supported for AWS (1) and Azure (8)",
+		},
+		{
+			name:        "OCI gateway",
+			cloudType:   goaviatrix.OCI,
+			expectError: "enable_ipv6 is not supported",
+		},
+		{
+			name:        "VPN gateway with IPv6 vpn_cidr",
+			cloudType:   goaviatrix.AWS,
+			vpnAccess:   true,
+			vpnCidr:     "fd00:43::/64",
+			expectError: "vpn_cidr must be an IPv4 CIDR",
+		},
+		{
+			name:        "VPN gateway without vpn_cidr",
+			cloudType:   goaviatrix.AWS,
+			vpnAccess:   true,
+			expectError: "vpn_cidr must be an IPv4 CIDR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGatewayIPv6(tt.cloudType, tt.vpnAccess, tt.vpnCidr)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPeeringHaRegionMismatchWarning(t *testing.T) {
+	tests := []struct {
+		name          string
+		primaryRegion string
+		haRegion      string
+		expectWarning bool
+	}{
+		{
+			name:          "same region",
+			primaryRegion: "us-west-2",
+			haRegion:      "us-west-2",
+		},
+		{
+			name:          "no peering HA region reported",
+			primaryRegion: "us-west-2",
+		},
+		{
+			name:          "different region",
+			primaryRegion: "us-west-2",
+			haRegion:      "us-east-1",
+			expectWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := peeringHaRegionMismatchWarning("gw", tt.primaryRegion, tt.haRegion)
+			if !tt.expectWarning {
+				assert.Empty(t, diags)
+				return
+			}
+			assert.Len(t, diags, 1)
+			assert.Equal(t, diag.Warning, diags[0].Severity)
+			assert.Contains(t, diags[0].Detail, tt.haRegion)
+			assert.Contains(t, diags[0].Detail, tt.primaryRegion)
+		})
+	}
+}
+
+func TestMonitorExcludeListVpcWarnings(t *testing.T) {
+	instanceVpcs := map[string]string{
+		"i-0a1b2c3d4e5f60001": "vpc-gateway",
+		"i-0a1b2c3d4e5f60002": "vpc-foreign",
+	}
+
+	tests := []struct {
+		name             string
+		config           map[string]interface{}
+		expectedWarnings []string
+		expectLookups    bool
+	}{
+		{
+			name: "instances in gateway VPC",
+			config: map[string]interface{}{
+				"enable_monitor_gateway_subnets": true,
+				"monitor_exclude_list":           []interface{}{"i-0a1b2c3d4e5f60001"},
+			},
+			expectLookups: true,
+		},
+		{
+			name: "foreign instance",
+			config: map[string]interface{}{
+				"enable_monitor_gateway_subnets": true,
+				"monitor_exclude_list":           []interface{}{"i-0a1b2c3d4e5f60001", "i-0a1b2c3d4e5f60002"},
+			},
+			expectedWarnings: []string{"i-0a1b2c3d4e5f60002"},
+			expectLookups:    true,
+		},
+		{
+			name: "unknown instance",
+			config: map[string]interface{}{
+				"enable_monitor_gateway_subnets": true,
+				"monitor_exclude_list":           []interface{}{"i-0a1b2c3d4e5f60003"},
+			},
+			expectedWarnings: []string{"i-0a1b2c3d4e5f60003"},
+			expectLookups:    true,
+		},
+		{
+			name: "monitor gateway subnets disabled",
+			config: map[string]interface{}{
+				"monitor_exclude_list": []interface{}{"i-0a1b2c3d4e5f60002"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					vpcID, ok := instanceVpcs[form.Get("instance_id")]
+					if !ok {
+						return `{"return": false, "reason": "Instance not found"}`
+					}
+					return fmt.Sprintf(`{"return": true, "results": {"vpc_id": %q}, "reason": ""}`, vpcID)
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			config := map[string]interface{}{
+				"gw_name":      "gw",
+				"account_name": "aws-account",
+				"vpc_id":       "vpc-gateway",
+				"vpc_reg":      "us-west-2",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, config)
+
+			diags := monitorExcludeListVpcWarnings(d, client)
+			assert.Len(t, diags, len(tt.expectedWarnings))
+			for i, instanceID := range tt.expectedWarnings {
+				assert.Equal(t, diag.Warning, diags[i].Severity)
+				assert.Contains(t, diags[i].Detail, instanceID)
+			}
+			if tt.expectLookups {
+				assert.Contains(t, transport.actions, "get_instance_vpc_id")
+			} else {
+				assert.Empty(t, transport.actions)
+			}
+		})
+	}
+}
+
+func TestResolveMonitorExcludeNames(t *testing.T) {
+	instanceNames := map[string]string{
+		"bastion": `["i-0a1b2c3d4e5f60001"]`,
+		"workers": `["i-0a1b2c3d4e5f60002", "i-0a1b2c3d4e5f60003"]`,
+		"retired": `[]`,
+	}
+
+	tests := []struct {
+		name                string
+		names               []interface{}
+		expectedInstanceIDs []string
+		expectError         string
+	}{
+		{
+			name:                "single instance",
+			names:               []interface{}{"bastion"},
+			expectedInstanceIDs: []string{"i-0a1b2c3d4e5f60001"},
+		},
+		{
+			name:                "name shared by several instances",
+			names:               []interface{}{"workers"},
+			expectedInstanceIDs: []string{"i-0a1b2c3d4e5f60002", "i-0a1b2c3d4e5f60003"},
+		},
+		{
+			name:        "no matching instance",
+			names:       []interface{}{"retired"},
+			expectError: "no instance named \"retired\" in 'monitor_exclude_name_list' found in VPC vpc-gateway",
+		},
+		{
+			name:        "lookup error",
+			names:       []interface{}{"unknown"},
+			expectError: "could not resolve instance name \"unknown\" in 'monitor_exclude_name_list'",
+		},
+		{
+			name: "no names",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					instanceIDs, ok := instanceNames[form.Get("instance_name")]
+					if !ok || form.Get("vpc_id") != "vpc-gateway" {
+						return `{"return": false, "reason": "Account not found"}`
+					}
+					return fmt.Sprintf(`{"return": true, "results": {"instance_ids": %s}, "reason": ""}`, instanceIDs)
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, map[string]interface{}{
+				"gw_name":                        "gw",
+				"account_name":                   "aws-account",
+				"vpc_id":                         "vpc-gateway",
+				"vpc_reg":                        "us-west-2",
+				"enable_monitor_gateway_subnets": true,
+				"monitor_exclude_name_list":      tt.names,
+			})
+
+			instanceIDs, err := resolveMonitorExcludeNames(d, client)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedInstanceIDs, instanceIDs)
+		})
+	}
+}
+
+func TestMergeMonitorExcludeList(t *testing.T) {
+	merged := mergeMonitorExcludeList(
+		[]string{"i-0a1b2c3d4e5f60001", "i-0a1b2c3d4e5f60002"},
+		[]string{"i-0a1b2c3d4e5f60002", "i-0a1b2c3d4e5f60003"},
+	)
+	assert.Equal(t, []string{"i-0a1b2c3d4e5f60001", "i-0a1b2c3d4e5f60002", "i-0a1b2c3d4e5f60003"}, merged)
+}
+
+func TestMonitorExcludeListWithoutResolved(t *testing.T) {
+	tests := []struct {
+		name                string
+		excludedIDs         []string
+		resolvedIDs         []string
+		configuredIDs       []string
+		expectedInstanceIDs []string
+	}{
+		{
+			name:                "only excluded by name",
+			excludedIDs:         []string{"i-0a1b2c3d4e5f60001", "i-0a1b2c3d4e5f60002"},
+			resolvedIDs:         []string{"i-0a1b2c3d4e5f60002"},
+			configuredIDs:       []string{"i-0a1b2c3d4e5f60001"},
+			expectedInstanceIDs: []string{"i-0a1b2c3d4e5f60001"},
+		},
+		{
+			name:                "excluded by name and id",
+			excludedIDs:         []string{"i-0a1b2c3d4e5f60001"},
+			resolvedIDs:         []string{"i-0a1b2c3d4e5f60001"},
+			configuredIDs:       []string{"i-0a1b2c3d4e5f60001"},
+			expectedInstanceIDs: []string{"i-0a1b2c3d4e5f60001"},
+		},
+		{
+			// An instance that was replaced under the same name is still excluded by its old id, which is
+			// reported so that the next apply excludes the new instance.
+			name:                "stale instance excluded by name",
+			excludedIDs:         []string{"i-0a1b2c3d4e5f60002"},
+			resolvedIDs:         []string{"i-0a1b2c3d4e5f60003"},
+			expectedInstanceIDs: []string{"i-0a1b2c3d4e5f60002"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instanceIDs := monitorExcludeListWithoutResolved(tt.excludedIDs, tt.resolvedIDs, tt.configuredIDs)
+			assert.Equal(t, tt.expectedInstanceIDs, instanceIDs)
+		})
+	}
+}
+
+func TestSetGatewayGroGso(t *testing.T) {
+	tests := []struct {
+		name          string
+		haEnabled     bool
+		enable        bool
+		expectedCalls []string
+	}{
+		{
+			name:          "disable with HA present",
+			haEnabled:     true,
+			enable:        false,
+			expectedCalls: []string{"disable_gro_gso gw", "disable_gro_gso gw-hagw"},
+		},
+		{
+			name:          "enable with HA present",
+			haEnabled:     true,
+			enable:        true,
+			expectedCalls: []string{"enable_gro_gso gw", "enable_gro_gso gw-hagw"},
+		},
+		{
+			name:          "disable without HA",
+			enable:        false,
+			expectedCalls: []string{"disable_gro_gso gw"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					calls = append(calls, form.Get("action")+" "+form.Get("gateway_name"))
+					return `{"return": true, "results": "", "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := setGatewayGroGso(client, "gw", tt.haEnabled, tt.enable)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestSetGatewayGroGsoHaError(t *testing.T) {
+	transport := &fakeControllerTransport{
+		respond: func(form url.Values) string {
+			if form.Get("gateway_name") == "gw-hagw" {
+				return `{"return": false, "reason": "Gateway gw-hagw is down"}`
+			}
+			return `{"return": true, "results": "", "reason": ""}`
+		},
+	}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+	err := setGatewayGroGso(client, "gw", true, true)
+	assert.ErrorContains(t, err, "gw-hagw")
+	assert.Equal(t, []string{"enable_gro_gso", "enable_gro_gso"}, transport.actions)
+}
+
+func TestSetPeeringHaTunnelDetectionTime(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		resetDefault  bool
+		expectedCalls []string
+	}{
+		{
+			name:          "set detection time",
+			config:        map[string]interface{}{"peering_ha_tunnel_detection_time": 30},
+			expectedCalls: []string{"modify_detection_time gw-hagw 30"},
+		},
+		{
+			name:          "unset on create",
+			config:        map[string]interface{}{},
+			expectedCalls: nil,
+		},
+		{
+			name:         "unset on update restores controller default",
+			config:       map[string]interface{}{},
+			resetDefault: true,
+			expectedCalls: []string{
+				"show_tunnel_status_change_detection_time Controller",
+				"modify_detection_time gw-hagw 60",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					calls = append(calls, strings.TrimSpace(form.Get("action")+" "+form.Get("entity")+" "+form.Get("detection_time")))
+					return `{"return": true, "results": {"detection_time": 60}, "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			config := map[string]interface{}{"gw_name": "gw"}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, config)
+
+			err := setPeeringHaTunnelDetectionTime(d, client, "gw-hagw", tt.resetDefault)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestValidateCustomerManagedKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		expectError bool
+	}{
+		{
+			name: "key ID",
+			key:  "1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		{
+			name: "alias ARN",
+			key:  "arn:aws:kms:us-west-2:111122223333:alias/gateway-volumes",
+		},
+		{
+			name: "GovCloud alias ARN",
+			key:  "arn:aws-us-gov:kms:us-gov-west-1:111122223333:alias/team/gateway-volumes",
+		},
+		{
+			name:        "alias name without ARN",
+			key:         "alias/gateway-volumes",
+			expectError: true,
+		},
+		{
+			name:        "alias ARN without account",
+			key:         "arn:aws:kms:us-west-2::alias/gateway-volumes",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validateCustomerManagedKeys(tt.key, "customer_managed_keys")
+			if tt.expectError {
+				assert.Len(t, errs, 1)
+				assert.NotContains(t, errs[0].Error(), tt.key)
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+func TestResolveCustomerManagedKeys(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		response      string
+		expectedKey   string
+		expectError   string
+		expectedCalls []string
+	}{
+		{
+			name:        "key ID",
+			key:         "1234abcd-12ab-34cd-56ef-1234567890ab",
+			expectedKey: "1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		{
+			name:          "alias ARN",
+			key:           "arn:aws:kms:us-west-2:111122223333:alias/gateway-volumes",
+			response:      `{"return": true, "results": {"key_id": "1234abcd-12ab-34cd-56ef-1234567890ab"}, "reason": ""}`,
+			expectedKey:   "1234abcd-12ab-34cd-56ef-1234567890ab",
+			expectedCalls: []string{"get_kms_key_id_by_alias"},
+		},
+		{
+			name:          "unknown alias",
+			key:           "arn:aws:kms:us-west-2:111122223333:alias/missing",
+			response:      `{"return": false, "reason": "Alias arn:aws:kms:us-west-2:111122223333:alias/missing is not found"}`,
+			expectError:   "could not resolve KMS key alias",
+			expectedCalls: []string{"get_kms_key_id_by_alias"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					assert.Equal(t, "aws-account", form.Get("account_name"))
+					assert.Equal(t, tt.key, form.Get("key_alias"))
+					return tt.response
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			key, err := resolveCustomerManagedKeys(client, "aws-account", tt.key)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedKey, key)
+			}
+			assert.Equal(t, tt.expectedCalls, transport.actions)
+		})
+	}
+}
+
+func TestResolveHaCustomerManagedKeys(t *testing.T) {
+	tests := []struct {
+		name          string
+		haKey         string
+		expectedKey   string
+		expectedCalls []string
+	}{
+		{
+			name:        "falls back to primary key",
+			expectedKey: "1111aaaa-12ab-34cd-56ef-1234567890ab",
+		},
+		{
+			name:        "HA key ID",
+			haKey:       "2222bbbb-12ab-34cd-56ef-1234567890ab",
+			expectedKey: "2222bbbb-12ab-34cd-56ef-1234567890ab",
+		},
+		{
+			name:          "HA key alias ARN",
+			haKey:         "arn:aws:kms:us-west-2:111122223333:alias/ha-gateway-volumes",
+			expectedKey:   "3333cccc-12ab-34cd-56ef-1234567890ab",
+			expectedCalls: []string{"get_kms_key_id_by_alias"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				body: `{"return": true, "results": {"key_id": "3333cccc-12ab-34cd-56ef-1234567890ab"}, "reason": ""}`,
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			key, err := resolveHaCustomerManagedKeys(client, "aws-account", tt.haKey, "1111aaaa-12ab-34cd-56ef-1234567890ab")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedKey, key)
+			assert.Equal(t, tt.expectedCalls, transport.actions)
+		})
+	}
+}
+
+func TestEncryptPeeringHaGatewayVolume(t *testing.T) {
+	tests := []struct {
+		name          string
+		primaryKeyID  string
+		haKey         string
+		expectedCalls []string
+	}{
+		{
+			name:          "default key",
+			expectedCalls: []string{"encrypt_gateway_volume gw-hagw"},
+		},
+		{
+			name:          "inherits primary key",
+			primaryKeyID:  "1111aaaa-12ab-34cd-56ef-1234567890ab",
+			expectedCalls: []string{"encrypt_gateway_volume gw-hagw 1111aaaa-12ab-34cd-56ef-1234567890ab"},
+		},
+		{
+			name:         "HA key alias ARN",
+			primaryKeyID: "1111aaaa-12ab-34cd-56ef-1234567890ab",
+			haKey:        "arn:aws:kms:us-west-2:111122223333:alias/ha-gateway-volumes",
+			expectedCalls: []string{
+				"get_kms_key_id_by_alias",
+				"encrypt_gateway_volume gw-hagw 3333cccc-12ab-34cd-56ef-1234567890ab",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					calls = append(calls, strings.TrimSpace(form.Get("action")+" "+form.Get("gateway_name")+" "+form.Get("customer_managed_keys")))
+					return `{"return": true, "results": {"key_id": "3333cccc-12ab-34cd-56ef-1234567890ab"}, "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, map[string]interface{}{
+				"gw_name":                          "gw",
+				"account_name":                     "aws-account",
+				"enable_encrypt_volume":            true,
+				"peering_ha_customer_managed_keys": tt.haKey,
+			})
+
+			err := encryptPeeringHaGatewayVolume(d, client, tt.primaryKeyID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestGatewaySubnetChangeRecreateInPlace(t *testing.T) {
+	tests := []struct {
+		name            string
+		recreateInPlace bool
+		peeringHaSubnet string
+		expectForceNew  bool
+	}{
+		{
+			name:            "recreate in place with peering HA",
+			recreateInPlace: true,
+			peeringHaSubnet: "10.0.2.0/24",
+		},
+		{
+			name:            "recreate in place without peering HA",
+			recreateInPlace: true,
+		},
+		{
+			name:            "default behavior",
+			peeringHaSubnet: "10.0.2.0/24",
+			expectForceNew:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "gw",
+				Attributes: map[string]string{
+					"cloud_type":        "1",
+					"account_name":      "aws-account",
+					"gw_name":           "gw",
+					"vpc_id":            "vpc-0a1b2c3d",
+					"vpc_reg":           "us-west-2",
+					"gw_size":           "t3.small",
+					"subnet":            "10.0.1.0/24",
+					"insane_mode":       "false",
+					"peering_ha_subnet": tt.peeringHaSubnet,
+				},
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"cloud_type":        goaviatrix.AWS,
+				"account_name":      "aws-account",
+				"gw_name":           "gw",
+				"vpc_id":            "vpc-0a1b2c3d",
+				"vpc_reg":           "us-west-2",
+				"gw_size":           "t3.small",
+				"subnet":            "10.0.3.0/24",
+				"peering_ha_subnet": tt.peeringHaSubnet,
+				"recreate_in_place": tt.recreateInPlace,
+			})
+
+			diff, err := resourceAviatrixGateway().Diff(context.Background(), state, config, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "10.0.3.0/24", diff.Attributes["subnet"].New)
+			assert.Equal(t, tt.expectForceNew, diff.RequiresNew())
+		})
+	}
+}
+
+func TestGatewayInsaneModeGwSizeWarnings(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, map[string]interface{}{
+		"cloud_type":         goaviatrix.AWS,
+		"gw_name":            "test-gw",
+		"gw_size":            "c5.xlarge",
+		"peering_ha_gw_size": "t3.medium",
+		"insane_mode":        true,
+	})
+
+	diags := insaneModeGwSizeWarnings(d, "gw_size", "peering_ha_gw_size")
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, `peering_ha_gw_size "t3.medium" of gateway test-gw is smaller than c5.large`)
+}
+
+func TestRecreateGatewayInPlaceDeletesHaGatewayFirst(t *testing.T) {
+	var deleted []string
+	transport := &fakeControllerTransport{
+		respond: func(form url.Values) string {
+			switch form.Get("action") {
+			case "check_task_status":
+				return `{"return": true, "results": "done", "reason": ""}`
+			case "delete_container":
+				deleted = append(deleted, form.Get("gw_name"))
+				return `{"return": true, "results": "request-1", "reason": ""}`
+			}
+			return `{"return": false, "reason": "launch failed"}`
+		},
+	}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, map[string]interface{}{
+		"cloud_type":        goaviatrix.AWS,
+		"account_name":      "aws-account",
+		"gw_name":           "gw",
+		"vpc_id":            "vpc-0a1b2c3d",
+		"vpc_reg":           "us-west-2",
+		"gw_size":           "t3.small",
+		"subnet":            "10.0.3.0/24",
+		"peering_ha_subnet": "10.0.2.0/24",
+		"recreate_in_place": true,
+	})
+
+	err := recreateGatewayInPlace(d, client)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"gw-hagw", "gw"}, deleted)
+}
+
+func TestRecreateGatewayInPlaceDeleteError(t *testing.T) {
+	transport := &fakeControllerTransport{body: `{"return": false, "reason": "Gateway gw not found"}`}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, map[string]interface{}{
+		"cloud_type": goaviatrix.AWS,
+		"gw_name":    "gw",
+		"subnet":     "10.0.3.0/24",
+	})
+
+	err := recreateGatewayInPlace(d, client)
+	assert.ErrorContains(t, err, "failed to delete gateway gw to recreate it")
+	assert.Equal(t, []string{"delete_container"}, transport.actions)
+}
+
+func TestSpotPriceUpdatableInPlace(t *testing.T) {
+	for name, r := range map[string]*schema.Resource{
+		"aviatrix_gateway":       resourceAviatrixGateway(),
+		"aviatrix_spoke_gateway": resourceAviatrixSpokeGateway(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.False(t, r.Schema["spot_price"].ForceNew)
+			assert.True(t, r.Schema["enable_spot_instance"].ForceNew)
+			assert.True(t, r.Schema["delete_spot"].ForceNew)
+		})
+	}
+}
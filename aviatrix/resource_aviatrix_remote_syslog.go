@@ -16,6 +16,9 @@ import (
 
 var remoteSyslogMatcher = regexp.MustCompile(`\bremote_syslog_[0-9]\b`)
 
+// remoteSyslogSeverities are the syslog severity keywords (RFC 5424) accepted as the minimum forwarded severity.
+var remoteSyslogSeverities = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
 func resourceAviatrixRemoteSyslog() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAviatrixRemoteSyslogCreate,
@@ -105,6 +108,14 @@ func resourceAviatrixRemoteSyslog() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"log_severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(remoteSyslogSeverities, false),
+				Description:  "Minimum syslog severity forwarded to the remote syslog server.",
+			},
 			"notls": {
 				Type:        schema.TypeBool,
 				Computed:    true,
@@ -130,6 +141,7 @@ func marshalRemoteSyslogInput(d *schema.ResourceData) *goaviatrix.RemoteSyslog {
 		CaCertificate:     getString(d, "ca_certificate_file"),
 		PublicCertificate: getString(d, "public_certificate_file"),
 		PrivateKey:        getString(d, "private_key_file"),
+		Severity:          getString(d, "log_severity"),
 	}
 
 	var excludeGateways []string
@@ -203,6 +215,7 @@ func resourceAviatrixRemoteSyslogRead(d *schema.ResourceData, meta interface{})
 	mustSet(d, "port", port)
 	mustSet(d, "protocol", remoteSyslogStatus.Protocol)
 	mustSet(d, "template", remoteSyslogStatus.Template)
+	mustSet(d, "log_severity", remoteSyslogStatus.Severity)
 	mustSet(d, "notls", remoteSyslogStatus.Notls)
 	mustSet(d, "status", remoteSyslogStatus.Status)
 	if len(remoteSyslogStatus.ExcludedGateways) != 0 {
@@ -39,6 +39,7 @@ func TestAccAviatrixRemoteSyslog_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "server", "1.2.3.4"),
 					resource.TestCheckResourceAttr(resourceName, "port", "10"),
 					resource.TestCheckResourceAttr(resourceName, "protocol", "TCP"),
+					resource.TestCheckResourceAttr(resourceName, "log_severity", "warning"),
 					testAccCheckRemoteSyslogExcludedGatewaysMatch(rIndex, []string{"a", "b"}),
 				),
 			},
@@ -52,6 +53,24 @@ func TestAccAviatrixRemoteSyslog_basic(t *testing.T) {
 	})
 }
 
+func TestRemoteSyslogLogSeverityValidation(t *testing.T) {
+	validateFunc := resourceAviatrixRemoteSyslog().Schema["log_severity"].ValidateFunc
+
+	for _, severity := range []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"} {
+		_, errs := validateFunc(severity, "log_severity")
+		if len(errs) != 0 {
+			t.Errorf("expected %q to be a valid log_severity, got %v", severity, errs)
+		}
+	}
+
+	for _, severity := range []string{"", "WARNING", "error", "informational", "7"} {
+		_, errs := validateFunc(severity, "log_severity")
+		if len(errs) == 0 {
+			t.Errorf("expected %q to be rejected as log_severity", severity)
+		}
+	}
+}
+
 func testAccRemoteSyslogBasic(rIndex int, rName string) string {
 	return fmt.Sprintf(`
 resource "aviatrix_remote_syslog" "test_remote_syslog" {
@@ -61,6 +80,7 @@ resource "aviatrix_remote_syslog" "test_remote_syslog" {
 	port              = 10
 	protocol          = "TCP"
 	excluded_gateways = ["a", "b"]
+	log_severity      = "warning"
 }
 `, rIndex, rName)
 }
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -26,6 +28,25 @@ func resourceAviatrixSegmentationNetworkDomain() *schema.Resource {
 				ForceNew:    true,
 				Description: "Network domain name.",
 			},
+			"associated_connections": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Attachments associated to the network domain.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attachment_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the attachment, in the format used by aviatrix_segmentation_network_domain_association.",
+						},
+						"transit_gateway_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the transit gateway of the attachment.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -84,10 +105,31 @@ func resourceAviatrixSegmentationNetworkDomainRead(d *schema.ResourceData, meta
 		return fmt.Errorf("could not find segmentation_network_domain %s: %w", domainName, err)
 	}
 	mustSet(d, "domain_name", domain.DomainName)
+	if err := d.Set("associated_connections", flattenSegmentationNetworkDomainAssociations(domain.AssociatedConnections)); err != nil {
+		return fmt.Errorf("could not set associated_connections: %w", err)
+	}
 	d.SetId(domain.DomainName)
 	return nil
 }
 
+// flattenSegmentationNetworkDomainAssociations returns the associated_connections of the given associations,
+// sorted by attachment name.
+func flattenSegmentationNetworkDomainAssociations(associations []goaviatrix.SegmentationSecurityDomainAssociation) []map[string]interface{} {
+	sorted := slices.Clone(associations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].AttachmentName < sorted[j].AttachmentName
+	})
+
+	connections := make([]map[string]interface{}, 0, len(sorted))
+	for _, association := range sorted {
+		connections = append(connections, map[string]interface{}{
+			"attachment_name":      association.AttachmentName,
+			"transit_gateway_name": association.TransitGatewayName,
+		})
+	}
+	return connections
+}
+
 func resourceAviatrixSegmentationNetworkDomainDelete(d *schema.ResourceData, meta interface{}) error {
 	client := mustClient(meta)
 
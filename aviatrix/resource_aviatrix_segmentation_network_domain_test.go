@@ -2,12 +2,16 @@ package aviatrix
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 
 	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
 )
@@ -96,3 +100,55 @@ func testAccCheckSegmentationNetworkDomainDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestResourceAviatrixSegmentationNetworkDomainRead(t *testing.T) {
+	tests := []struct {
+		name                string
+		domains             string
+		expectedID          string
+		expectedConnections []interface{}
+	}{
+		{
+			name:       "import with associations",
+			domains:    `["domain-a", "domain-b"]`,
+			expectedID: "domain-a",
+			expectedConnections: []interface{}{
+				map[string]interface{}{"attachment_name": "site-1:100", "transit_gateway_name": "transit-gw"},
+				map[string]interface{}{"attachment_name": "spoke-gw", "transit_gateway_name": "transit-gw"},
+			},
+		},
+		{
+			name:    "domain deleted outside of Terraform",
+			domains: `["domain-b"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					if form.Get("action") == "list_multi_cloud_security_domain_names" {
+						return `{"return": true, "results": ` + tt.domains + `, "reason": ""}`
+					}
+					return `{"return": true, "results": {"attachments": [
+						{"name": "spoke-gw", "domain": "domain-a", "transit_name": "transit-gw", "type": "SPOKE"},
+						{"name": "site-1:vlan:100", "domain": "domain-a", "transit_name": "transit-gw", "type": "EDGEVLAN"},
+						{"name": "other-spoke-gw", "domain": "domain-b", "transit_name": "transit-gw", "type": "SPOKE"}
+					]}, "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSegmentationNetworkDomain().Schema, map[string]interface{}{})
+			d.SetId("domain-a")
+
+			err := resourceAviatrixSegmentationNetworkDomainRead(d, client)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, d.Id())
+			if tt.expectedID == "" {
+				return
+			}
+			assert.Equal(t, "domain-a", getString(d, "domain_name"))
+			assert.Equal(t, tt.expectedConnections, getList(d, "associated_connections"))
+		})
+	}
+}
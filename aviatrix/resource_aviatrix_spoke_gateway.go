@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
+	"slices"
+	"sort"
 	"strings"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,16 +22,19 @@ const subnetSeparator = "~~"
 
 func resourceAviatrixSpokeGateway() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAviatrixSpokeGatewayCreate,
-		Read:   resourceAviatrixSpokeGatewayRead,
-		Update: resourceAviatrixSpokeGatewayUpdate,
-		Delete: resourceAviatrixSpokeGatewayDelete,
+		CreateContext: resourceAviatrixSpokeGatewayCreateContext,
+		Read:          resourceAviatrixSpokeGatewayRead,
+		UpdateContext: resourceAviatrixSpokeGatewayUpdateContext,
+		Delete:        resourceAviatrixSpokeGatewayDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough, //nolint:staticcheck // SA1019: deprecated but requires structural changes to migrate,
 		},
 
 		// CustomizeDiff handles custom diff logic during plan operations:
 		// - Forces resource recreation when IPv6 subnet fields change (if previously set and enable_ipv6 is true)
+		// - Rejects gw_size and ha_gw_size values not available in the gateway's region
+		// - Rejects tags on cloud types whose tags are not read back
+		// - Rejects IPv6 enabled gateways whose subnets are not IPv4 and IPv6 networks respectively
 		CustomizeDiff: resourceAviatrixSpokeGatewayCustomizeDiff,
 
 		SchemaVersion: 2,
@@ -122,6 +128,19 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Default:     false,
 				Description: "Specify whether to enable Source NAT feature in 'single_ip' mode on the gateway or not.",
 			},
+			"snat_mode": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringInSlice([]string{"single_ip", "customized"}, false),
+				ConflictsWith: []string{"single_ip_snat"},
+				Description:   "Source NAT mode of the gateway. Valid values: 'single_ip', 'customized'.",
+			},
+			"snat_policy": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        snatPolicyResource(),
+				Description: "Policy rules applied when 'snat_mode' is 'customized'.",
+			},
 			"allocate_new_eip": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -182,6 +201,99 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 					"using the aviatrix_spoke_gateway resource. If this is set to false, managing spoke ha gateway " +
 					"must be done using the aviatrix_spoke_ha_gateway resource. Valid values: true, false. Default value: true.",
 			},
+			"delete_primary_first": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Delete the spoke gateway before its HA gateway on destroy, as required by some controller releases. " +
+					"Only used on delete. Valid values: true, false. Default value: false.",
+			},
+			"attach_to_transit_gws": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				Description: "Transit gateways to attach the spoke gateway to, in failover priority order. The first " +
+					"transit gateway is preferred. Must not be used together with aviatrix_spoke_transit_attachment.",
+			},
+			"transit_route_table": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"attach_to_transit_gws"},
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description: "Route table of the transit gateways in 'attach_to_transit_gws' the spoke gateway attachments " +
+					"are associated with. Must exist on every transit gateway. Unset to use the default association.",
+			},
+			"ha_gateways": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "HA gateways of the spoke gateway, sorted by gw_name. Only valid when 'manage_ha_gateway' is false. " +
+					"Changing any attribute other than gw_size replaces that HA gateway only.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"gw_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Name of the HA gateway.",
+						},
+						"subnet": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCanonicalIPv4CIDR,
+							Description:  "Subnet of the HA gateway.",
+						},
+						"gw_size": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Size of the HA gateway instance.",
+						},
+						"zone": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Availability Zone. Required for GCP, example: 'us-west1-c'. Optional for Azure in the form 'az-n', example: 'az-2'.",
+						},
+						"insane_mode_az": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "AZ of subnet being created for Insane Mode HA gateway. Required for AWS if insane_mode is enabled.",
+						},
+						"eip": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsIPAddress,
+							Description:  "If set, the specified EIP is used for the HA gateway. Not supported for Azure.",
+						},
+						"availability_domain": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Availability domain. Required for OCI.",
+						},
+						"fault_domain": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Fault domain. Required for OCI.",
+						},
+						"cloud_instance_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Cloud instance ID of the HA gateway.",
+						},
+						"private_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Private IP address of the HA gateway.",
+						},
+						"public_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Public IP address of the HA gateway.",
+						},
+					},
+				},
+			},
 			"insane_mode": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -208,26 +320,83 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Description: "Enable preserve as_path when advertising manual summary cidrs on BGP spoke gateway.",
 			},
 			"customized_spoke_vpc_routes": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ValidateFunc:  validateCIDRList,
+				ConflictsWith: []string{"customized_routes", "customized_spoke_vpc_routes_list"},
+				Deprecated:    "Use 'customized_spoke_vpc_routes_list' instead.",
 				Description: "A list of comma separated CIDRs to be customized for the spoke VPC routes. When configured, " +
 					"it will replace all learned routes in VPC routing tables, including RFC1918 and non-RFC1918 CIDRs. " +
 					"It applies to this spoke gateway only.",
 			},
+			"customized_spoke_vpc_routes_list": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsCIDRNetwork(0, 32)},
+				ConflictsWith: []string{"customized_routes", "customized_spoke_vpc_routes"},
+				Description: "A set of CIDRs to be customized for the spoke VPC routes. When configured, it will replace all " +
+					"learned routes in VPC routing tables, including RFC1918 and non-RFC1918 CIDRs. " +
+					"It applies to this spoke gateway only.",
+			},
+			"customized_routes": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"customized_spoke_vpc_routes", "customized_spoke_vpc_routes_list"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCanonicalIPv4CIDR,
+							Description:  "Destination CIDR of the customized route.",
+						},
+						"next_hop": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsIPAddress,
+							Description:  "Next hop IP address of the customized route.",
+						},
+					},
+				},
+				Description: "Customized spoke VPC routes with their next hops. When configured, they replace all learned " +
+					"routes in VPC routing tables. It applies to this spoke gateway only.",
+			},
 			"filtered_spoke_vpc_routes": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ValidateFunc:  validateCIDRList,
+				ConflictsWith: []string{"filtered_spoke_vpc_routes_list"},
+				Deprecated:    "Use 'filtered_spoke_vpc_routes_list' instead.",
 				Description: "A list of comma separated CIDRs to be filtered from the spoke VPC route table. When configured, " +
 					"filtering CIDR(s) or it’s subnet will be deleted from VPC routing tables as well as from spoke gateway’s " +
 					"routing table. It applies to this spoke gateway only.",
 			},
+			"filtered_spoke_vpc_routes_list": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsCIDRNetwork(0, 32)},
+				ConflictsWith: []string{"filtered_spoke_vpc_routes"},
+				Description: "A set of CIDRs to be filtered from the spoke VPC route table. When configured, filtering CIDR(s) " +
+					"or it’s subnet will be deleted from VPC routing tables as well as from spoke gateway’s routing table. " +
+					"It applies to this spoke gateway only.",
+			},
 			"included_advertised_spoke_routes": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "A list of comma separated CIDRs to be advertised to on-prem as 'Included CIDR List'. When configured, it will replace all advertised routes from this VPC.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ValidateFunc:  validateCIDRList,
+				ConflictsWith: []string{"included_advertised_spoke_routes_list"},
+				Deprecated:    "Use 'included_advertised_spoke_routes_list' instead.",
+				Description:   "A list of comma separated CIDRs to be advertised to on-prem as 'Included CIDR List'. When configured, it will replace all advertised routes from this VPC.",
+			},
+			"included_advertised_spoke_routes_list": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsCIDRNetwork(0, 32)},
+				ConflictsWith: []string{"included_advertised_spoke_routes"},
+				Description:   "A set of CIDRs to be advertised to on-prem as 'Included CIDR List'. When configured, it will replace all advertised routes from this VPC.",
 			},
 			"customer_managed_keys": {
 				Type:        schema.TypeString,
@@ -235,6 +404,14 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Sensitive:   true,
 				Description: "Customer managed key ID.",
 			},
+			"ha_customer_managed_keys": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validateCustomerManagedKeys,
+				Description: "Customer managed key ID or the ARN of a key alias to encrypt the volumes of the HA gateways with. " +
+					"Defaults to 'customer_managed_keys'.",
+			},
 			"enable_monitor_gateway_subnets": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -286,18 +463,151 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Default:     true,
 				Description: "Enable jumbo frame support for spoke gateway. Valid values: true or false. Default value: true.",
 			},
+			"maintenance_window": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Weekly window, in UTC, in which the controller may upgrade the spoke gateway.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"day_of_week": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(maintenanceWindowDays, false),
+							Description:  "Day of the week the window starts on, e.g. 'Sunday'.",
+						},
+						"hour": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 23),
+							Description:  "Hour of the day, in UTC, the window starts at. Valid values are between 0 and 23.",
+						},
+					},
+				},
+			},
+			"snmp": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "SNMP agent configuration of the spoke gateway.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"v1", "v2c"}, false),
+							Description:  "SNMP version. Valid values: 'v1', 'v2c'.",
+						},
+						"community": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "SNMP community string.",
+						},
+						"allowed_cidrs": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Description: "CIDRs of the SNMP managers allowed to query the gateway.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateCanonicalCIDR,
+							},
+						},
+					},
+				},
+			},
+			"ha_enable_jumbo_frame": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether jumbo frame support is enabled on the HA spoke gateway.",
+			},
+			"peering_tunnel_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names the controller assigned to the peering tunnels of the spoke gateway, sorted.",
+			},
 			"enable_gro_gso": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
 				Description: "Specify whether to disable GRO/GSO or not.",
 			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 255),
+				Description:  "Free-text description label of the spoke gateway, for inventory purposes. Maximum 255 characters.",
+			},
+			"tcp_mss_clamp": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(536, 1460),
+				Description:  "TCP MSS clamping value for traffic through the spoke gateway. Valid range: 536-1460. Unset to disable MSS clamping.",
+			},
+			"peering_keepalive_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 60),
+				Description:  "Keepalive interval in seconds of the spoke gateway's transit peering connections. Valid range: 1-60. Unset to use the controller default.",
+			},
+			"timezone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTimezone,
+				Description:  "IANA time zone of the spoke gateway's log timestamps, e.g. \"America/New_York\". Unset to use the time zone of the controller.",
+			},
+			"fqdn_gateway_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Name of the FQDN gateway used for egress filtering of the spoke gateway's traffic.",
+			},
+			"egress_via_connection": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				ConflictsWith: []string{"fqdn_gateway_name"},
+				Description:   "Name of the site2cloud or external device connection of the spoke gateway's VPC that the default egress traffic is directed through.",
+			},
+			"default_egress_action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "allow",
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+				Description: "Action applied to egress traffic of the spoke gateway that is not matched by any policy. " +
+					"Valid values: \"allow\", \"deny\". Only supported for AWS, Azure and GCP related cloud types.",
+			},
+			"enable_dns_forwarding": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable the spoke gateway as a DNS forwarder to the resolvers in 'dns_forwarding_targets'. Default: false.",
+			},
+			"dns_forwarding_targets": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPAddress,
+				},
+				Description: "IP addresses of the resolvers DNS queries are forwarded to, in order of preference. " +
+					"Required if 'enable_dns_forwarding' is true.",
+			},
 			"tags": {
 				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "A map of tags to assign to the spoke gateway.",
 			},
+			"all_tags": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "Every tag on the spoke gateway's cloud instance, including controller managed tags and tags matched by the provider's ignore_tags.",
+			},
 			"enable_private_vpc_default_route": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -310,6 +620,18 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Default:     false,
 				Description: "Skip Public Route Table Update.",
 			},
+			"propagate_to_cloud_route_tables": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the controller programs the cloud-native route tables of the spoke VPC/VNet. Only AWS and Azure related cloud types support disabling it.",
+			},
+			"enable_admin_ssh": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether admin SSH access to the spoke gateway instance is allowed. Set to false to harden the gateway.",
+			},
 			"enable_auto_advertise_s2c_cidrs": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -328,7 +650,7 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 				ForceNew:    true,
-				Description: "Enable BGP. Default: false.",
+				Description: "Enable BGP. Only supported for AWS, GCP, Azure and OCI. Default: false.",
 			},
 			"enable_learned_cidrs_approval": {
 				Type:        schema.TypeBool,
@@ -340,10 +662,10 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Default:      defaultLearnedCidrApprovalMode,
-				ValidateFunc: validation.StringInSlice([]string{"gateway"}, false),
-				Description: "Set the learned CIDRs approval mode for BGP Spoke Gateway. Only valid when 'enable_learned_cidrs_approval' is " +
-					"set to true. Currently, only 'gateway' is supported: learned CIDR approval applies to " +
-					"ALL connections. Default value: 'gateway'.",
+				ValidateFunc: validation.StringInSlice([]string{"gateway", "connection"}, false),
+				Description: "Set the learned CIDRs approval mode for BGP Spoke Gateway. If set to 'gateway', learned CIDR " +
+					"approval applies to ALL connections. If set to 'connection', learned CIDR approval is configured " +
+					"per connection with 'connection_approved_cidrs'. Valid values: 'gateway', 'connection'. Default value: 'gateway'.",
 			},
 			"approved_learned_cidrs": {
 				Type: schema.TypeSet,
@@ -354,6 +676,30 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Optional:    true,
 				Description: "Approved learned CIDRs for BGP Spoke Gateway. Available as of provider version R2.21+.",
 			},
+			"connection_approved_cidrs": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Approved learned CIDRs of individual BGP connections of the Spoke Gateway.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connection_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "Name of the BGP connection.",
+						},
+						"approved_cidrs": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "Approved learned CIDRs of the connection.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: ValidateCIDRRule,
+							},
+						},
+					},
+				},
+			},
 			"bgp_ecmp": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -372,6 +718,21 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Default:     false,
 				Description: "Enables Preemptive Mode for Active-Standby, available only with Active-Standby enabled.",
 			},
+			"active_standby_failover_delay": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 300),
+				Description: "Seconds to wait before failing over to the standby gateway, available only with Active-Standby enabled. " +
+					"If not set, the controller default is used.",
+			},
+			"ha_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"standby", "active_active"}, false),
+				Description: "How the BGP Spoke Gateway and its HA gateway share traffic. Valid values: 'standby', 'active_active'. " +
+					"Only valid for AWS and Azure related BGP Spoke Gateways with HA enabled.",
+			},
 			"disable_route_propagation": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -437,6 +798,50 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				ValidateFunc: validation.IntBetween(12, 360),
 				Description:  "BGP Hold Time for BGP Spoke Gateway. Unit is in seconds. Valid values are between 12 and 360.",
 			},
+			"bgp_neighbor_passive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait for BGP neighbors to initiate the BGP session instead of connecting to them. Only valid for BGP enabled Spoke Gateways.",
+			},
+			"bgp_summary_cidrs": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDRNetwork(0, 32),
+				},
+				Description: "Set of summary CIDRs advertised to BGP peers in place of the more specific routes they contain. " +
+					"Only valid for BGP enabled Spoke Gateways.",
+			},
+			"bgp_community_outbound_filter": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateBgpCommunity,
+				},
+				Description: "Set of BGP communities, in the format 'AA:NN', the gateway is allowed to advertise to its BGP peers. " +
+					"Only valid for BGP enabled Spoke Gateways.",
+			},
+			"bgp_import_route_map": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Name of the BGP route map applied to routes learned from BGP peers. Only valid for BGP enabled Spoke Gateways.",
+			},
+			"bgp_export_route_map": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Name of the BGP route map applied to routes advertised to BGP peers. Only valid for BGP enabled Spoke Gateways.",
+			},
+			"bgp_next_hop_self": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set the gateway as the next hop of the routes it advertises to its iBGP peers. Only valid for BGP enabled Spoke Gateways.",
+			},
 			"enable_bgp_over_lan": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -473,8 +878,7 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 			"spot_price": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
-				Description:  "Price for spot instance. NOT supported for production deployment.",
+				Description:  "Price for spot instance. Can be updated without recreating the gateway. NOT supported for production deployment.",
 				RequiredWith: []string{"enable_spot_instance"},
 			},
 			"delete_spot": {
@@ -503,6 +907,13 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				ForceNew:    true,
 				Description: "Fault domain for OCI.",
 			},
+			"additional_fault_domains": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotWhiteSpace},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Set of fault domains for OCI to spread the gateway across in addition to 'fault_domain'.",
+			},
 			"ha_availability_domain": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -515,6 +926,15 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Computed:    true,
 				Description: "HA fault domain for OCI.",
 			},
+			"aws_iam_instance_profile": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateAwsIamInstanceProfile,
+				DiffSuppressFunc: DiffSuppressFuncAwsIamInstanceProfile,
+				Description:      "Name or ARN of the IAM instance profile attached to the spoke gateway. Only supported for AWS related cloud types.",
+			},
 			"eip": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -595,11 +1015,68 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Cloud instance ID.",
 			},
+			"eip_allocation_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "AWS allocation ID of the Elastic IP assigned to the spoke gateway. Only available for AWS related cloud types.",
+			},
 			"private_ip": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Private IP address of the spoke gateway created.",
 			},
+			"private_ip_cidr": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "CIDR of the subnet the private IP addresses of the spoke gateway are allocated from.",
+			},
+			"allocated_private_ips": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Sorted private IP addresses currently allocated to the spoke gateway.",
+			},
+			"subnet_is_public": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the gateway subnet has a default route to an internet gateway. Only set for AWS.",
+			},
+			"snat_public_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Public IP the egress traffic of the spoke gateway is source NATed to. Only set when SNAT is enabled.",
+			},
+			"attached_route_table_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Sorted IDs of the route tables programmed by the gateway. Only set for AWS.",
+			},
+			"read_metrics": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Read the current resource utilization of the spoke gateway into 'utilization' on every refresh.",
+			},
+			"utilization": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Current resource utilization of the spoke gateway. Only available when 'read_metrics' is true.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cpu_percent": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "CPU utilization in percent.",
+						},
+						"memory_percent": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Memory utilization in percent.",
+						},
+					},
+				},
+			},
 			"ha_cloud_instance_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -625,6 +1102,11 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Public IP address of the HA Spoke Gateway.",
 			},
+			"ha_public_ip_v6": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Public IPv6 address of the HA Spoke Gateway. Only set when enable_ipv6 is true.",
+			},
 			"bgp_lan_ip_list": {
 				Type:     schema.TypeList,
 				Elem:     &schema.Schema{Type: schema.TypeString},
@@ -645,6 +1127,49 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Default:     false,
 				Description: "Set to true to enable global VPC. Only supported for GCP.",
 			},
+			"external_bgp_peers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "On-prem BGP peers connected to the BGP spoke gateway through external device connections.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"remote_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "BGP neighbor IP address.",
+						},
+						"remote_as": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "BGP neighbor AS number.",
+						},
+						"bfd_enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether BFD is enabled for the BGP neighbor.",
+						},
+					},
+				},
+			},
+			"advertised_routes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Routes currently advertised by the BGP spoke gateway to its BGP peers.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Advertised CIDR.",
+						},
+					},
+				},
+			},
+			"active_gateway_instance": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Instance currently active when Active-Standby Mode is enabled, either \"primary\" or \"ha\".",
+			},
 			"bgp_send_communities": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -657,12 +1182,42 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Description: "BGP communities gateway accept configuration.",
 				Default:     false,
 			},
+			"bgp_communities": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "BGP communities attached to individual advertised CIDRs. Requires 'bgp_send_communities' to be true.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsCIDRNetwork(0, 32),
+							Description:  "Advertised CIDR the communities are attached to.",
+						},
+						"communities": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Description: "BGP communities, in the format 'AA:NN', attached to the CIDR.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateBgpCommunity,
+							},
+						},
+					},
+				},
+			},
 			"enable_ipv6": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 				Description: "Enable IPv6 for the gateway. Only supported for AWS (1), Azure (8).",
 			},
+			"ipv6_operational": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the IPv6 stack of the spoke gateway is up. Always false when enable_ipv6 is false.",
+			},
 			"insertion_gateway": {
 				Type:          schema.TypeBool,
 				Optional:      true,
@@ -693,12 +1248,44 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"enable", "disable"}, false),
 				Default:      "disable",
 			},
+			"ike_proposals": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateIkeProposal,
+				},
+				Description: "IKE proposals offered for the gateway's IPsec tunnels, in order of preference, in the format " +
+					"'encryption/integrity/dh_group'. Take precedence over 'tunnel_encryption_cipher' and 'tunnel_forward_secrecy'.",
+			},
+			"esp_proposals": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateEspProposal,
+				},
+				Description: "ESP proposals offered for the gateway's IPsec tunnels, in order of preference, in the format " +
+					"'encryption/integrity[/dh_group]'. Take precedence over 'tunnel_encryption_cipher' and 'tunnel_forward_secrecy'.",
+			},
 			"private_route_table_config": {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 				Description: "Set of Azure route table selectors to treat as private route tables for the spoke VNet. Each entry is in the format \"<route_table_name>:<resource_group_name>\". Only applicable for Azure (8), AzureGov (32) and AzureChina (2048).",
 			},
+			"gcp_private_routes": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotWhiteSpace},
+				Optional:    true,
+				Description: "Set of names of the routes of the spoke VPC network to treat as private routes. Only applicable for GCP (4).",
+			},
+			"firenet_inspection_exclude_cidrs": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateCanonicalIPv4CIDR},
+				Optional:    true,
+				Description: "Set of CIDRs whose traffic bypasses FireNet inspection. Requires the spoke gateway to be attached to a FireNet enabled transit gateway.",
+			},
 		},
 	}
 }
@@ -718,37 +1305,1028 @@ func handleIPv6SubnetForceNew(d *schema.ResourceDiff, fieldName string) error {
 	return nil
 }
 
-func resourceAviatrixSpokeGatewayCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
-	// Only force recreation for primary gateway's IPv6 CIDR changes
-	// HA gateway IPv6 CIDR changes are handled by Update function (recreates only HA gateway)
-	if err := handleIPv6SubnetForceNew(d, "subnet_ipv6_cidr"); err != nil {
-		return err
+// validateSpokeGwSizesAvailable rejects a plan setting gw_size or ha_gw_size to an instance size that is
+// not available for the cloud type in the gateway's region. Checking at plan time avoids a partial apply
+// where the primary gateway was resized before resizing the HA gateway failed.
+func validateSpokeGwSizesAvailable(d *schema.ResourceDiff, client *goaviatrix.Client) error {
+	var keys []string
+	for _, key := range []string{"gw_size", "ha_gw_size"} {
+		if !d.NewValueKnown(key) || getString(d, key) == "" {
+			continue
+		}
+		if d.Id() == "" || d.HasChange(key) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 || !d.NewValueKnown("cloud_type") || !d.NewValueKnown("vpc_reg") {
+		return nil
 	}
 
+	cloudType := getInt(d, "cloud_type")
+	region := getString(d, "vpc_reg")
+	sizes, err := client.ListGatewayInstanceSizes(cloudType, region)
+	if err != nil {
+		// not every controller can list the sizes, leave the check to the launch or resize instead
+		log.Printf("[WARN] could not list gateway instance sizes available in %s, skipping the size check: %v", region, err)
+		return nil
+	}
+	// nothing to check against if the controller doesn't know the sizes of the region
+	if len(sizes) == 0 {
+		return nil
+	}
+	for _, key := range keys {
+		size := getString(d, key)
+		if !slices.ContainsFunc(sizes, func(s string) bool { return strings.EqualFold(s, size) }) {
+			return fmt.Errorf("invalid %s: instance size %q is not available for cloud type %d in %s", key, size, cloudType, region)
+		}
+	}
 	return nil
 }
 
-func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}) error {
-	client := mustClient(meta)
-
-	gateway := &goaviatrix.SpokeVpc{
-		CloudType:              getInt(d, "cloud_type"),
-		AccountName:            getString(d, "account_name"),
-		GwName:                 getString(d, "gw_name"),
-		VpcSize:                getString(d, "gw_size"),
-		Subnet:                 getString(d, "subnet"),
-		HASubnet:               getString(d, "ha_subnet"),
-		AvailabilityDomain:     getString(d, "availability_domain"),
-		FaultDomain:            getString(d, "fault_domain"),
-		ApprovedLearnedCidrs:   getStringSet(d, "approved_learned_cidrs"),
-		EnableGlobalVpc:        getBool(d, "enable_global_vpc"),
-		TunnelEncryptionCipher: getString(d, "tunnel_encryption_cipher"),
-		TunnelForwardSecrecy:   getString(d, "tunnel_forward_secrecy"),
+// validateSpokeIPv6SubnetFamilies rejects a plan enabling IPv6 where subnet or ha_subnet is not an IPv4 network,
+// or subnet_ipv6_cidr or ha_subnet_ipv6_cidr is not an IPv6 network, instead of failing the gateway launch.
+func validateSpokeIPv6SubnetFamilies(d *schema.ResourceDiff) error {
+	if !getBool(d, "enable_ipv6") {
+		return nil
 	}
-
-	if !getBool(d, "manage_ha_gateway") {
-		haSubnet := getString(d, "ha_subnet")
-		haZone := getString(d, "ha_zone")
+	for _, prefix := range []string{"", "ha_"} {
+		if err := validateSpokeSubnetFamily(d, prefix+"subnet", false); err != nil {
+			return err
+		}
+		if err := validateSpokeSubnetFamily(d, prefix+"subnet_ipv6_cidr", true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSpokeSubnetFamily checks that the CIDR planned for key is an IPv6 network if ipv6 is true and an IPv4
+// network otherwise. Unset and unknown values are skipped.
+func validateSpokeSubnetFamily(d *schema.ResourceDiff, key string, ipv6 bool) error {
+	cidr := getString(d, key)
+	if !d.NewValueKnown(key) || cidr == "" {
+		return nil
+	}
+	ip, _, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	if isIPv6 := ip.To4() == nil; isIPv6 != ipv6 {
+		family := "IPv4"
+		if ipv6 {
+			family = "IPv6"
+		}
+		return fmt.Errorf("invalid %s: expected an %s CIDR when enable_ipv6 is true, got %s", key, family, cidr)
+	}
+	return nil
+}
+
+func resourceAviatrixSpokeGatewayCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Only force recreation for primary gateway's IPv6 CIDR changes
+	// HA gateway IPv6 CIDR changes are handled by Update function (recreates only HA gateway)
+	if err := handleIPv6SubnetForceNew(d, "subnet_ipv6_cidr"); err != nil {
+		return err
+	}
+
+	// meta is only nil when the diff is computed without a configured provider
+	if client, ok := meta.(*goaviatrix.Client); ok && client != nil {
+		if err := validateSpokeGwSizesAvailable(d, client); err != nil {
+			return err
+		}
+	}
+
+	if err := validateGatewayTagsReadable(d); err != nil {
+		return err
+	}
+
+	if err := validateSpokeIPv6SubnetFamilies(d); err != nil {
+		return err
+	}
+
+	if err := validateSpokeHaCustomerManagedKeys(d); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSpokeHaCustomerManagedKeys rejects ha_customer_managed_keys unless the volumes are encrypted and the
+// spoke gateway has an HA gateway, through ha_subnet, ha_zone or ha_gateways, to use the key.
+func validateSpokeHaCustomerManagedKeys(d *schema.ResourceDiff) error {
+	if getString(d, "ha_customer_managed_keys") == "" {
+		return nil
+	}
+	if !d.NewValueKnown("enable_encrypt_volume") || !d.NewValueKnown("ha_subnet") || !d.NewValueKnown("ha_zone") ||
+		!d.NewValueKnown("ha_gateways") {
+		return nil
+	}
+	if !getBool(d, "enable_encrypt_volume") {
+		return fmt.Errorf("'ha_customer_managed_keys' should be empty since Encrypt Volume is not enabled")
+	}
+	if getString(d, "ha_subnet") == "" && getString(d, "ha_zone") == "" && len(getList(d, "ha_gateways")) == 0 {
+		return fmt.Errorf("'ha_customer_managed_keys' requires an HA gateway: set 'ha_subnet', 'ha_zone' or 'ha_gateways'")
+	}
+	return nil
+}
+
+// encryptSpokeHaGatewayVolumes encrypts the volumes of the named HA gateways of the spoke gateway in d with
+// ha_customer_managed_keys, or with customer_managed_keys if it is not set.
+func encryptSpokeHaGatewayVolumes(d *schema.ResourceData, client *goaviatrix.Client, haGwNames []string) error {
+	if len(haGwNames) == 0 {
+		return nil
+	}
+	haKey := getString(d, "ha_customer_managed_keys")
+	if haKey == "" {
+		haKey = getString(d, "customer_managed_keys")
+	}
+	keyID, err := resolveCustomerManagedKeys(client, getString(d, "account_name"), haKey)
+	if err != nil {
+		return err
+	}
+	for _, haGwName := range haGwNames {
+		gwHAEncVolume := &goaviatrix.Gateway{
+			GwName:              haGwName,
+			CustomerManagedKeys: keyID,
+		}
+		err := client.EnableEncryptVolume(gwHAEncVolume)
+		if err != nil {
+			return fmt.Errorf("failed to enable encrypt gateway volume for %s due to %w", gwHAEncVolume.GwName, err)
+		}
+	}
+	return nil
+}
+
+// validateFqdnGatewayExists returns an error if the named FQDN gateway is not known to the controller or
+// is not an FQDN gateway.
+func validateFqdnGatewayExists(client *goaviatrix.Client, fqdnGwName string) error {
+	gw, err := client.GetGateway(&goaviatrix.Gateway{GwName: fqdnGwName})
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			return fmt.Errorf("invalid fqdn_gateway_name: FQDN gateway %q does not exist", fqdnGwName)
+		}
+		return fmt.Errorf("could not look up FQDN gateway %q: %w", fqdnGwName, err)
+	}
+	if !isFqdnGateway(gw) {
+		return fmt.Errorf("invalid fqdn_gateway_name: gateway %q is not an FQDN gateway", fqdnGwName)
+	}
+	return nil
+}
+
+// isFqdnGateway reports whether gw can filter egress traffic by FQDN: either an FQDN gateway attached to
+// FireNet, or a gateway other than a transit gateway with SNAT enabled.
+func isFqdnGateway(gw *goaviatrix.Gateway) bool {
+	if len(gw.FqdnInterfaces[gw.GwName]) != 0 {
+		return true
+	}
+	return gw.TransitVpc != "yes" && (gw.EnableNat == "yes" || gw.NatEnabled)
+}
+
+// validateSpokeEgressConnectionExists returns an error if the named connection does not exist in the
+// VPC of the spoke gateway.
+func validateSpokeEgressConnectionExists(client *goaviatrix.Client, vpcID, connName string) error {
+	_, err := client.GetSite2Cloud(&goaviatrix.Site2Cloud{VpcID: vpcID, TunnelName: connName})
+	if err != nil {
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			return fmt.Errorf("invalid egress_via_connection: connection %q does not exist in VPC %s", connName, vpcID)
+		}
+		return fmt.Errorf("could not look up connection %q: %w", connName, err)
+	}
+	return nil
+}
+
+// expandSpokeConnectionApprovedCidrs returns the approved CIDRs of the given connection_approved_cidrs
+// blocks keyed by connection name.
+func expandSpokeConnectionApprovedCidrs(blocks []interface{}) (map[string][]string, error) {
+	approvedCidrs := make(map[string][]string, len(blocks))
+	for _, v := range blocks {
+		block := mustMap(v)
+		connName := mustString(block["connection_name"])
+		if _, ok := approvedCidrs[connName]; ok {
+			return nil, fmt.Errorf("connection %q is listed more than once in 'connection_approved_cidrs'", connName)
+		}
+		var cidrs []string
+		for _, cidr := range mustSchemaSet(block["approved_cidrs"]).List() {
+			cidrs = append(cidrs, mustString(cidr))
+		}
+		sort.Strings(cidrs)
+		approvedCidrs[connName] = cidrs
+	}
+	return approvedCidrs, nil
+}
+
+// flattenSpokeConnectionApprovedCidrs returns the connection_approved_cidrs blocks of the managed connections
+// found in the connection approval info of the spoke gateway.
+func flattenSpokeConnectionApprovedCidrs(managed map[string][]string, approvalInfo []goaviatrix.LearnedCIDRApprovalInfo) []map[string]interface{} {
+	var blocks []map[string]interface{}
+	for _, info := range approvalInfo {
+		if _, ok := managed[info.ConnName]; !ok {
+			continue
+		}
+		blocks = append(blocks, map[string]interface{}{
+			"connection_name": info.ConnName,
+			"approved_cidrs":  info.ApprovedLearnedCidrs,
+		})
+	}
+	return blocks
+}
+
+// validateSpokeLearnedCidrsApprovalMode checks that learned_cidrs_approval_mode is consistent with the rest of
+// the learned CIDRs approval configuration.
+func validateSpokeLearnedCidrsApprovalMode(d *schema.ResourceData) error {
+	if getString(d, "learned_cidrs_approval_mode") != "connection" {
+		return nil
+	}
+	if !getBool(d, "enable_bgp") {
+		return fmt.Errorf("'learned_cidrs_approval_mode' can only be set to 'connection' for BGP enabled Spoke Gateways")
+	}
+	if getBool(d, "enable_learned_cidrs_approval") {
+		return fmt.Errorf("'enable_learned_cidrs_approval' must be false if 'learned_cidrs_approval_mode' is set to 'connection'")
+	}
+	return nil
+}
+
+// validateSpokeConnectionApprovedCidrs checks that connection_approved_cidrs can be applied to the spoke gateway.
+func validateSpokeConnectionApprovedCidrs(d *schema.ResourceData) (map[string][]string, error) {
+	approvedCidrs, err := expandSpokeConnectionApprovedCidrs(getSet(d, "connection_approved_cidrs").List())
+	if err != nil {
+		return nil, err
+	}
+	if len(approvedCidrs) == 0 {
+		return approvedCidrs, nil
+	}
+	if !getBool(d, "enable_bgp") {
+		return nil, fmt.Errorf("'connection_approved_cidrs' is only supported for BGP enabled Spoke Gateways")
+	}
+	if getBool(d, "enable_learned_cidrs_approval") {
+		return nil, fmt.Errorf("'connection_approved_cidrs' must be empty if 'enable_learned_cidrs_approval' is true, " +
+			"use 'approved_learned_cidrs' for gateway based approval")
+	}
+	if getString(d, "learned_cidrs_approval_mode") != "connection" {
+		return nil, fmt.Errorf("'connection_approved_cidrs' requires 'learned_cidrs_approval_mode' to be set to 'connection'")
+	}
+	return approvedCidrs, nil
+}
+
+// validateSpokeDnsForwarding checks that dns_forwarding_targets is consistent with enable_dns_forwarding.
+func validateSpokeDnsForwarding(enable bool, targets []string) error {
+	if !enable {
+		if len(targets) != 0 {
+			return fmt.Errorf("'dns_forwarding_targets' must be empty if 'enable_dns_forwarding' is false")
+		}
+		return nil
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("'dns_forwarding_targets' is required if 'enable_dns_forwarding' is true")
+	}
+	seen := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		if seen[target] {
+			return fmt.Errorf("DNS forwarding target %q is listed more than once in 'dns_forwarding_targets'", target)
+		}
+		seen[target] = true
+	}
+	return nil
+}
+
+// expandSpokeBgpCommunities returns the communities of the given bgp_communities blocks keyed by CIDR.
+func expandSpokeBgpCommunities(blocks []interface{}) (map[string][]string, error) {
+	bgpCommunities := make(map[string][]string, len(blocks))
+	for _, v := range blocks {
+		block := mustMap(v)
+		cidr := mustString(block["cidr"])
+		if _, ok := bgpCommunities[cidr]; ok {
+			return nil, fmt.Errorf("CIDR %q is listed more than once in 'bgp_communities'", cidr)
+		}
+		var communities []string
+		for _, community := range mustSchemaSet(block["communities"]).List() {
+			communities = append(communities, mustString(community))
+		}
+		sort.Strings(communities)
+		bgpCommunities[cidr] = communities
+	}
+	return bgpCommunities, nil
+}
+
+// flattenSpokeBgpCommunities returns the bgp_communities blocks of the given communities keyed by CIDR,
+// sorted by CIDR.
+func flattenSpokeBgpCommunities(bgpCommunities map[string][]string) []map[string]interface{} {
+	cidrs := make([]string, 0, len(bgpCommunities))
+	for cidr := range bgpCommunities {
+		cidrs = append(cidrs, cidr)
+	}
+	sort.Strings(cidrs)
+
+	var blocks []map[string]interface{}
+	for _, cidr := range cidrs {
+		blocks = append(blocks, map[string]interface{}{
+			"cidr":        cidr,
+			"communities": bgpCommunities[cidr],
+		})
+	}
+	return blocks
+}
+
+// validateSpokeBgpCommunities checks that bgp_communities can be applied to the spoke gateway. Communities
+// are only advertised if the gateway sends BGP communities.
+func validateSpokeBgpCommunities(d *schema.ResourceData) (map[string][]string, error) {
+	bgpCommunities, err := expandSpokeBgpCommunities(getSet(d, "bgp_communities").List())
+	if err != nil {
+		return nil, err
+	}
+	if len(bgpCommunities) != 0 && !getBool(d, "bgp_send_communities") {
+		return nil, fmt.Errorf("'bgp_communities' must be empty if 'bgp_send_communities' is false")
+	}
+	return bgpCommunities, nil
+}
+
+// setSpokeGatewayJumboFrame enables or disables jumbo frames on the given spoke gateways, skipping empty names,
+// so that the primary and the HA gateway can be updated together.
+func setSpokeGatewayJumboFrame(client *goaviatrix.Client, enable bool, gwNames ...string) error {
+	for _, gwName := range gwNames {
+		if gwName == "" {
+			continue
+		}
+		gw := &goaviatrix.Gateway{GwName: gwName}
+		if enable {
+			if err := client.EnableJumboFrame(gw); err != nil {
+				return fmt.Errorf("could not enable jumbo frame for %s: %w", gwName, err)
+			}
+		} else {
+			if err := client.DisableJumboFrame(gw); err != nil {
+				return fmt.Errorf("could not disable jumbo frame for %s: %w", gwName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// readSpokeGatewayJumboFrame sets enable_jumbo_frame and ha_enable_jumbo_frame, which is false without HA gateway.
+func readSpokeGatewayJumboFrame(d *schema.ResourceData, gw *goaviatrix.Gateway) {
+	mustSet(d, "enable_jumbo_frame", gw.JumboFrame)
+	mustSet(d, "ha_enable_jumbo_frame", gw.HaGw.GwSize != "" && gw.HaGw.JumboFrame)
+}
+
+// validateSpokeSnat checks that snat_policy is only set, and then required, in 'customized' SNAT mode.
+func validateSpokeSnat(mode string, policies []interface{}) error {
+	if mode == "customized" && len(policies) == 0 {
+		return fmt.Errorf("'snat_policy' is required if 'snat_mode' is 'customized'")
+	}
+	if mode != "customized" && len(policies) != 0 {
+		return fmt.Errorf("'snat_policy' is only valid if 'snat_mode' is 'customized'")
+	}
+	return nil
+}
+
+// spokeSnatMode returns the SNAT mode configured through either single_ip_snat or snat_mode, or an empty
+// string when SNAT is disabled.
+func spokeSnatMode(singleIPSnat bool, snatMode string) string {
+	if singleIPSnat {
+		return "single_ip"
+	}
+	return snatMode
+}
+
+// updateSpokeGatewaySnat switches the SNAT of the spoke gateway from oldMode to newMode, disabling the old
+// mode first. The customized policies are also pushed when they changed while staying in 'customized' mode.
+func updateSpokeGatewaySnat(client *goaviatrix.Client, gwName string, cloudType int, oldMode, newMode string,
+	policies []goaviatrix.PolicyRule, policyChanged bool,
+) error {
+	if oldMode != newMode {
+		switch oldMode {
+		case "single_ip":
+			err := client.DisableSNat(&goaviatrix.Gateway{CloudType: cloudType, GatewayName: gwName})
+			if err != nil {
+				return fmt.Errorf("failed to disable 'single_ip' mode SNAT: %w", err)
+			}
+		case "customized":
+			err := client.DisableCustomSNat(&goaviatrix.Gateway{GatewayName: gwName, SnatMode: "custom"})
+			if err != nil {
+				return fmt.Errorf("failed to disable 'customized' mode SNAT: %w", err)
+			}
+		}
+	}
+
+	switch {
+	case newMode == "single_ip" && oldMode != newMode:
+		err := client.EnableSNat(&goaviatrix.Gateway{CloudType: cloudType, GatewayName: gwName})
+		if err != nil {
+			return fmt.Errorf("failed to enable 'single_ip' mode SNAT: %w", err)
+		}
+	case newMode == "customized" && (oldMode != newMode || policyChanged):
+		err := client.EnableCustomizedSNat(&goaviatrix.Gateway{
+			GatewayName: gwName,
+			EnableNat:   "yes",
+			SnatMode:    "custom",
+			SnatPolicy:  policies,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to enable 'customized' mode SNAT: %w", err)
+		}
+	}
+	return nil
+}
+
+// readSpokeGatewaySnat sets the SNAT configuration of the spoke gateway. The mode is reported through
+// single_ip_snat unless snat_mode is in use, in which case customized policies are loaded as well.
+func readSpokeGatewaySnat(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) error {
+	singleIP := gw.EnableNat == "yes" && gw.SnatMode == "primary"
+	if getString(d, "snat_mode") == "" {
+		mustSet(d, "single_ip_snat", singleIP)
+		mustSet(d, "snat_policy", nil)
+		return nil
+	}
+
+	mustSet(d, "single_ip_snat", false)
+	switch {
+	case singleIP:
+		mustSet(d, "snat_mode", "single_ip")
+		mustSet(d, "snat_policy", nil)
+	case gw.NatEnabled && gw.SnatMode != "primary":
+		gwDetail, err := client.GetGatewayDetail(&goaviatrix.Gateway{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("couldn't get SNAT policies of spoke gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "snat_mode", "customized")
+		mustSet(d, "snat_policy", flattenSNatPolicy(gwDetail.SnatPolicy))
+	default:
+		mustSet(d, "snat_mode", "")
+		mustSet(d, "snat_policy", nil)
+	}
+	return nil
+}
+
+// readSpokeGatewaySnatPublicIP sets the public IP external resources see as the source of the egress
+// traffic of the spoke gateway when SNAT is enabled.
+func readSpokeGatewaySnatPublicIP(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) error {
+	if gw.EnableNat != "yes" && !gw.NatEnabled {
+		mustSet(d, "snat_public_ip", "")
+		return nil
+	}
+	publicIP, err := client.GetGatewaySnatPublicIP(gw.GwName)
+	if err != nil {
+		return fmt.Errorf("couldn't get SNAT public IP of spoke gateway %s: %w", gw.GwName, err)
+	}
+	mustSet(d, "snat_public_ip", publicIP)
+	return nil
+}
+
+// setSpokeGatewayAdminSSH enables or disables admin SSH access on the given spoke gateways, skipping empty names,
+// so that the primary and the HA gateway can be updated together.
+func setSpokeGatewayAdminSSH(client *goaviatrix.Client, enable bool, gwNames ...string) error {
+	for _, gwName := range gwNames {
+		if gwName == "" {
+			continue
+		}
+		if err := client.SetGatewayAdminSSH(gwName, enable); err != nil {
+			return fmt.Errorf("could not set admin SSH for %s: %w", gwName, err)
+		}
+	}
+	return nil
+}
+
+// updateSpokeGatewayAdminSSH applies a change of enable_admin_ssh to the spoke gateway and its HA gateway. A
+// newly created HA gateway comes up with admin SSH enabled, so only it needs updating if the setting did not change.
+func updateSpokeGatewayAdminSSH(d *schema.ResourceData, client *goaviatrix.Client, newHaGwEnabled bool) error {
+	enable := getBool(d, "enable_admin_ssh")
+	if !d.HasChange("enable_admin_ssh") && (!newHaGwEnabled || enable) {
+		return nil
+	}
+	var gwName, haGwName string
+	if d.HasChange("enable_admin_ssh") {
+		gwName = getString(d, "gw_name")
+	}
+	if getBool(d, "manage_ha_gateway") && (getString(d, "ha_subnet") != "" || getString(d, "ha_zone") != "") {
+		haGwName = getString(d, "gw_name") + "-hagw"
+	}
+	if err := setSpokeGatewayAdminSSH(client, enable, gwName, haGwName); err != nil {
+		return fmt.Errorf("could not update admin SSH during spoke gateway update: %w", err)
+	}
+	return nil
+}
+
+// readSpokeGatewayAdminSSH sets whether admin SSH access to the spoke gateway is enabled. The status is not part of
+// the gateway list, so it is queried on every refresh to catch changes in either direction. A failed lookup keeps the
+// last known value instead of failing the refresh.
+func readSpokeGatewayAdminSSH(d *schema.ResourceData, client *goaviatrix.Client, gwName string) {
+	enabled, err := client.GetGatewayAdminSSH(gwName)
+	if err != nil {
+		log.Printf("[WARN] could not get admin SSH status of spoke gateway %s: %v", gwName, err)
+		return
+	}
+	mustSet(d, "enable_admin_ssh", enabled)
+}
+
+// setSpokeHaRxQueueSize applies the rx queue size to the HA gateway of the spoke gateway. It is skipped when the HA
+// gateway is not managed by this resource, e.g. when it is created by aviatrix_spoke_ha_gateway.
+func setSpokeHaRxQueueSize(client *goaviatrix.Client, gwName, rxQueueSize string, manageHaGw, haConfigured bool) error {
+	if !manageHaGw || !haConfigured {
+		return nil
+	}
+	haGwName := gwName + "-hagw"
+	_, err := client.GetGateway(&goaviatrix.Gateway{GwName: haGwName})
+	if errors.Is(err, goaviatrix.ErrNotFound) {
+		return fmt.Errorf("spoke ha gateway %s is configured with 'ha_subnet' or 'ha_zone' but does not exist", haGwName)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get spoke ha gateway %s: %w", haGwName, err)
+	}
+	return client.SetRxQueueSize(&goaviatrix.Gateway{GwName: haGwName, RxQueueSize: rxQueueSize})
+}
+
+// spokeDefaultEgressActionCloudTypes are the cloud types supporting a default egress action other than "allow".
+const spokeDefaultEgressActionCloudTypes = goaviatrix.AWSRelatedCloudTypes | goaviatrix.AzureArmRelatedCloudTypes | goaviatrix.GCPRelatedCloudTypes
+
+// validateSpokeDefaultEgressAction checks that the default egress action is supported by the cloud type of the spoke gateway.
+func validateSpokeDefaultEgressAction(cloudType int, action string) error {
+	if action != "allow" && !goaviatrix.IsCloudType(cloudType, spokeDefaultEgressActionCloudTypes) {
+		return fmt.Errorf("default_egress_action %q is only supported for AWS (1), GCP (4), Azure (8), AzureGov (32), AWSGov (256), "+
+			"AWSChina (1024), AzureChina (2048), AWS Top Secret (16384) and AWS Secret (32768)", action)
+	}
+	return nil
+}
+
+// spokeHaPublicIPv6 returns the public IPv6 address of the HA gateway of the spoke gateway, or an empty
+// string when IPv6 is not enabled on the spoke gateway.
+func spokeHaPublicIPv6(gw *goaviatrix.Gateway) string {
+	if !gw.EnableIPv6 {
+		return ""
+	}
+	return gw.HaGw.PublicIPv6
+}
+
+// validateSpokeGcpPrivateRoutes checks that gcp_private_routes is only set for GCP spoke gateways.
+func validateSpokeGcpPrivateRoutes(cloudType int, routes []string) error {
+	if len(routes) != 0 && !goaviatrix.IsCloudType(cloudType, goaviatrix.GCPRelatedCloudTypes) {
+		return fmt.Errorf("'gcp_private_routes' is only supported for GCP (4)")
+	}
+	return nil
+}
+
+// setSpokePrivateRoutes sets the private routes of the spoke gateway into the attribute of its cloud type,
+// private_route_table_config for Azure and gcp_private_routes for GCP, and clears the other.
+func setSpokePrivateRoutes(d *schema.ResourceData, gw *goaviatrix.Gateway) {
+	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AzureArmRelatedCloudTypes) {
+		mustSet(d, "private_route_table_config", gw.PrivateRouteTableConfig)
+	} else {
+		mustSet(d, "private_route_table_config", nil)
+	}
+	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.GCPRelatedCloudTypes) {
+		mustSet(d, "gcp_private_routes", gw.GcpPrivateRoutes)
+	} else {
+		mustSet(d, "gcp_private_routes", nil)
+	}
+}
+
+// readSpokeGatewayEipAllocationID sets the allocation ID of the Elastic IP of an AWS related spoke gateway. It must
+// be called before eip is refreshed, as the allocation ID is only looked up again when the EIP changed. A failed
+// lookup keeps the last known value instead of failing the refresh.
+func readSpokeGatewayEipAllocationID(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) {
+	if !goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes) || gw.PublicIP == "" {
+		mustSet(d, "eip_allocation_id", "")
+		return
+	}
+	if getString(d, "eip") == gw.PublicIP && getString(d, "eip_allocation_id") != "" {
+		return
+	}
+	allocationID, err := client.GetGatewayEipAllocationID(gw.GwName)
+	if err != nil {
+		log.Printf("[WARN] could not get EIP allocation ID for spoke gateway %s: %v", gw.GwName, err)
+		return
+	}
+	mustSet(d, "eip_allocation_id", allocationID)
+}
+
+// readSpokeGatewayActiveGatewayInstance sets which instance of an active-standby spoke gateway pair is active. The
+// status is informational only, so a failed lookup keeps the last known value instead of failing the refresh.
+func readSpokeGatewayActiveGatewayInstance(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) {
+	if !gw.EnableActiveStandby {
+		mustSet(d, "active_gateway_instance", "")
+		return
+	}
+	activeGatewayInstance, err := client.GetSpokeActiveGatewayInstance(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+	if err != nil {
+		log.Printf("[WARN] could not get active-standby status for spoke gateway %s: %v", gw.GwName, err)
+		return
+	}
+	mustSet(d, "active_gateway_instance", activeGatewayInstance)
+}
+
+// readSpokeGatewayIPv6Operational sets ipv6_operational from the IPv6 status of the spoke gateway, which
+// is only queried when IPv6 is enabled.
+func readSpokeGatewayIPv6Operational(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) error {
+	if !gw.EnableIPv6 {
+		mustSet(d, "ipv6_operational", false)
+		return nil
+	}
+	operational, err := client.GetGatewayIPv6Operational(gw.GwName)
+	if err != nil {
+		return fmt.Errorf("could not get IPv6 status of spoke gateway %s: %w", gw.GwName, err)
+	}
+	mustSet(d, "ipv6_operational", operational)
+	return nil
+}
+
+// validateSpokeAdditionalFaultDomains checks that additional_fault_domains is only used for OCI, does not
+// repeat fault_domain and only lists fault domains available in the VPC of the spoke gateway.
+func validateSpokeAdditionalFaultDomains(client *goaviatrix.Client, gateway *goaviatrix.SpokeVpc, additionalFaultDomains []string) error {
+	if !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.OCIRelatedCloudTypes) {
+		return fmt.Errorf("'additional_fault_domains' is only valid for OCI")
+	}
+	faultDomains, err := client.ListOciVpcFaultDomains(&goaviatrix.Vpc{
+		AccountName: gateway.AccountName,
+		Region:      gateway.VpcRegion,
+		VpcID:       gateway.VpcID,
+	})
+	if err != nil {
+		return fmt.Errorf("could not get OCI fault domains: %w", err)
+	}
+	for _, faultDomain := range additionalFaultDomains {
+		if faultDomain == gateway.FaultDomain {
+			return fmt.Errorf("'additional_fault_domains' must not contain 'fault_domain' %q", faultDomain)
+		}
+		if !stringInSlice(faultDomain, faultDomains) {
+			return fmt.Errorf("fault domain %q in 'additional_fault_domains' is not available in VPC %s, available fault domains are: %s",
+				faultDomain, gateway.VpcID, strings.Join(faultDomains, ", "))
+		}
+	}
+	return nil
+}
+
+// readSpokeGatewayUtilization sets the current resource utilization of the spoke gateway when read_metrics
+// is enabled, as the controller has to query the gateway instance for it.
+func readSpokeGatewayUtilization(d *schema.ResourceData, client *goaviatrix.Client, gwName string) error {
+	if !getBool(d, "read_metrics") {
+		mustSet(d, "utilization", nil)
+		return nil
+	}
+	utilization, err := client.GetGatewayUtilization(gwName)
+	if err != nil {
+		return fmt.Errorf("could not get utilization of spoke gateway %s: %w", gwName, err)
+	}
+	mustSet(d, "utilization", []map[string]interface{}{
+		{
+			"cpu_percent":    utilization.CpuPercent,
+			"memory_percent": utilization.MemoryPercent,
+		},
+	})
+	return nil
+}
+
+var maintenanceWindowDays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// expandSpokeMaintenanceWindow returns the configured maintenance_window, or nil if it is not set.
+func expandSpokeMaintenanceWindow(d *schema.ResourceData) *goaviatrix.GatewayMaintenanceWindow {
+	windows := getList(d, "maintenance_window")
+	if len(windows) == 0 || windows[0] == nil {
+		return nil
+	}
+	window := mustMap(windows[0])
+	return &goaviatrix.GatewayMaintenanceWindow{
+		DayOfWeek: mustString(window["day_of_week"]),
+		Hour:      mustInt(window["hour"]),
+	}
+}
+
+// readSpokeGatewayMaintenanceWindow sets maintenance_window from the window registered on the controller.
+func readSpokeGatewayMaintenanceWindow(d *schema.ResourceData, client *goaviatrix.Client, gwName string) error {
+	window, err := client.GetGatewayMaintenanceWindow(gwName)
+	if err != nil {
+		return fmt.Errorf("could not get maintenance window of spoke gateway %s: %w", gwName, err)
+	}
+	if window == nil {
+		mustSet(d, "maintenance_window", nil)
+		return nil
+	}
+	mustSet(d, "maintenance_window", []map[string]interface{}{
+		{
+			"day_of_week": window.DayOfWeek,
+			"hour":        window.Hour,
+		},
+	})
+	return nil
+}
+
+// readSpokeGatewayPeeringTunnelNames sets peering_tunnel_names from the peering tunnels of the gateway. It
+// is informational only, so a failed lookup keeps the last known value instead of failing the refresh.
+func readSpokeGatewayPeeringTunnelNames(d *schema.ResourceData, client *goaviatrix.Client, gwName string) {
+	names, err := client.GetGatewayPeeringTunnelNames(gwName)
+	if err != nil {
+		log.Printf("[WARN] could not get peering tunnels of spoke gateway %s: %v", gwName, err)
+		return
+	}
+	sort.Strings(names)
+	mustSet(d, "peering_tunnel_names", names)
+}
+
+// readSpokeGatewayPrivateIPAllocation sets private_ip_cidr and allocated_private_ips from the private IP
+// allocation of the gateway. Both are informational only, so a failed lookup keeps the last known values
+// instead of failing the refresh.
+func readSpokeGatewayPrivateIPAllocation(d *schema.ResourceData, client *goaviatrix.Client, gwName string) {
+	allocation, err := client.GetGatewayPrivateIPAllocation(gwName)
+	if err != nil {
+		log.Printf("[WARN] could not get private IP allocation of spoke gateway %s: %v", gwName, err)
+		return
+	}
+	ips := append([]string(nil), allocation.AllocatedPrivateIPs...)
+	sort.Strings(ips)
+	mustSet(d, "private_ip_cidr", allocation.PrivateIPCidr)
+	mustSet(d, "allocated_private_ips", ips)
+}
+
+// readSpokeGatewayExternalBgpPeers sets external_bgp_peers from the external BGP neighbors of the BGP spoke
+// gateway. The peers are informational only, so a failed lookup keeps the last known peers instead of failing
+// the refresh.
+func readSpokeGatewayExternalBgpPeers(d *schema.ResourceData, client *goaviatrix.Client, gwName string) {
+	peers, err := client.GetSpokeExternalBgpPeers(&goaviatrix.SpokeVpc{GwName: gwName})
+	if err != nil {
+		log.Printf("[WARN] could not get external BGP peers of spoke gateway %s: %v", gwName, err)
+		return
+	}
+	var externalBgpPeers []map[string]interface{}
+	for _, peer := range peers {
+		externalBgpPeers = append(externalBgpPeers, map[string]interface{}{
+			"remote_ip":   peer.RemoteIP,
+			"remote_as":   peer.RemoteAS,
+			"bfd_enabled": peer.BfdEnabled,
+		})
+	}
+	mustSet(d, "external_bgp_peers", externalBgpPeers)
+}
+
+// readSpokeGatewayAdvertisedRoutes sets advertised_routes from the routes the BGP spoke gateway advertises.
+// The routes are informational only, so a failed lookup keeps the last known routes instead of failing the
+// refresh.
+func readSpokeGatewayAdvertisedRoutes(d *schema.ResourceData, client *goaviatrix.Client, gwName string) {
+	routes, err := client.GetSpokeBgpAdvertisedRoutes(&goaviatrix.SpokeVpc{GwName: gwName})
+	if err != nil {
+		log.Printf("[WARN] could not get BGP advertised routes of spoke gateway %s: %v", gwName, err)
+		return
+	}
+	var advertisedRoutes []map[string]interface{}
+	for _, route := range routes {
+		advertisedRoutes = append(advertisedRoutes, map[string]interface{}{
+			"cidr": route.Cidr,
+		})
+	}
+	mustSet(d, "advertised_routes", advertisedRoutes)
+}
+
+// spokeBgpCloudTypes are the cloud types supporting enable_bgp on spoke gateways.
+const spokeBgpCloudTypes = goaviatrix.AWS | goaviatrix.GCPRelatedCloudTypes | goaviatrix.Azure | goaviatrix.OCIRelatedCloudTypes
+
+// validateSpokeBgp checks that enable_bgp is only enabled for cloud types supporting BGP spoke gateways.
+func validateSpokeBgp(cloudType int, enable bool) error {
+	if !enable || goaviatrix.IsCloudType(cloudType, spokeBgpCloudTypes) {
+		return nil
+	}
+	return fmt.Errorf("enabling BGP is only supported for AWS (1), GCP (4), Azure (8) and OCI (16)")
+}
+
+// validateSpokeBgpRouteMaps checks that the BGP route maps are only set for BGP enabled spoke gateways and
+// that the route maps they reference exist on the controller.
+func validateSpokeBgpRouteMaps(client *goaviatrix.Client, enableBgp bool, importRouteMap, exportRouteMap string) error {
+	if importRouteMap == "" && exportRouteMap == "" {
+		return nil
+	}
+	if !enableBgp {
+		return fmt.Errorf("'bgp_import_route_map' and 'bgp_export_route_map' are not supported for Non-BGP Spoke Gateways")
+	}
+	routeMaps, err := client.ListBgpRouteMapNames()
+	if err != nil {
+		return fmt.Errorf("could not list BGP route maps: %w", err)
+	}
+	for _, routeMap := range []string{importRouteMap, exportRouteMap} {
+		if routeMap != "" && !goaviatrix.Contains(routeMaps, routeMap) {
+			return fmt.Errorf("BGP route map %q does not exist", routeMap)
+		}
+	}
+	return nil
+}
+
+// spokeVpcDnsServerCloudTypes are the cloud types supporting enable_vpc_dns_server on spoke gateways.
+const spokeVpcDnsServerCloudTypes = goaviatrix.AWSRelatedCloudTypes | goaviatrix.GCPRelatedCloudTypes |
+	goaviatrix.AzureArmRelatedCloudTypes | goaviatrix.AliCloudRelatedCloudTypes
+
+// validateSpokeVpcDnsServer checks that enable_vpc_dns_server is only enabled for cloud types supporting it.
+func validateSpokeVpcDnsServer(cloudType int, enable bool) error {
+	if !enable || goaviatrix.IsCloudType(cloudType, spokeVpcDnsServerCloudTypes) {
+		return nil
+	}
+	return fmt.Errorf("'enable_vpc_dns_server' only supported by AWS (1), GCP (4), Azure (8), AzureGov (32), AWSGov (256), AWSChina (1024), AzureChina (2048), Alibaba Cloud (8192), AWS Top Secret (16384) and AWS Secret (32768)")
+}
+
+// validateSpokeHaMode checks that ha_mode is only set for BGP spoke gateways with HA enabled on a cloud
+// supporting it, and that active_active is not combined with enable_active_standby.
+func validateSpokeHaMode(d *schema.ResourceData) error {
+	haMode := getString(d, "ha_mode")
+	if haMode == "" {
+		return nil
+	}
+	if !goaviatrix.IsCloudType(getInt(d, "cloud_type"), goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes) {
+		return fmt.Errorf("'ha_mode' is only supported for AWS (1), Azure (8), AzureGov (32), AWSGov (256), AWSChina (1024), AzureChina (2048), AWS Top Secret (16384) and AWS Secret (32768)")
+	}
+	if !getBool(d, "enable_bgp") {
+		return fmt.Errorf("'ha_mode' is only supported for BGP enabled Spoke Gateways")
+	}
+	if getString(d, "ha_subnet") == "" && getString(d, "ha_zone") == "" {
+		return fmt.Errorf("'ha_mode' requires HA to be enabled")
+	}
+	if haMode == "active_active" && getBool(d, "enable_active_standby") {
+		return fmt.Errorf("'ha_mode' can't be 'active_active' when 'enable_active_standby' is true")
+	}
+	return nil
+}
+
+// updateSpokeHaMode applies a change of ha_mode. Removing ha_mode restores the default 'standby' mode as long
+// as the BGP spoke gateway still has HA enabled.
+func updateSpokeHaMode(d *schema.ResourceData, client *goaviatrix.Client) error {
+	if !d.HasChange("ha_mode") {
+		return nil
+	}
+	haMode := getString(d, "ha_mode")
+	if haMode == "" {
+		if !getBool(d, "enable_bgp") || (getString(d, "ha_subnet") == "" && getString(d, "ha_zone") == "") {
+			return nil
+		}
+		haMode = "standby"
+	}
+	err := client.SetSpokeHaMode(&goaviatrix.SpokeVpc{GwName: getString(d, "gw_name")}, haMode)
+	if err != nil {
+		return fmt.Errorf("could not set HA mode during Spoke Gateway update: %w", err)
+	}
+	return nil
+}
+
+// updateSpokeBgpNextHopSelf applies a change of bgp_next_hop_self to the BGP spoke gateway.
+func updateSpokeBgpNextHopSelf(d *schema.ResourceData, client *goaviatrix.Client) error {
+	if !d.HasChange("bgp_next_hop_self") {
+		return nil
+	}
+	nextHopSelf := getBool(d, "bgp_next_hop_self")
+	if nextHopSelf && !getBool(d, "enable_bgp") {
+		return fmt.Errorf("'bgp_next_hop_self' is not supported for Non-BGP Spoke Gateways")
+	}
+	err := client.SetSpokeBgpNextHopSelf(&goaviatrix.SpokeVpc{GwName: getString(d, "gw_name")}, nextHopSelf)
+	if err != nil {
+		return fmt.Errorf("could not update BGP next-hop-self during Spoke Gateway update: %w", err)
+	}
+	return nil
+}
+
+// validateSpokeCloudRouteTablePropagation checks that cloud route table propagation is only disabled for
+// cloud types whose route tables the controller programs.
+func validateSpokeCloudRouteTablePropagation(cloudType int, propagate bool) error {
+	if propagate || goaviatrix.IsCloudType(cloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes) {
+		return nil
+	}
+	return fmt.Errorf("'propagate_to_cloud_route_tables' can only be disabled for AWS (1), Azure (8), AzureGov (32), AWSGov (256), AWSChina (1024), AzureChina (2048), AWS Top Secret (16384) and AWS Secret (32768)")
+}
+
+// updateSpokeCloudRouteTablePropagation enables or disables cloud route table propagation when
+// propagate_to_cloud_route_tables changed.
+func updateSpokeCloudRouteTablePropagation(d *schema.ResourceData, client *goaviatrix.Client, gateway *goaviatrix.Gateway) error {
+	if !d.HasChange("propagate_to_cloud_route_tables") {
+		return nil
+	}
+	err := client.SetCloudRouteTablePropagation(gateway, getBool(d, "propagate_to_cloud_route_tables"))
+	if err != nil {
+		return fmt.Errorf("could not update cloud route table propagation during spoke gateway update: %w", err)
+	}
+	return nil
+}
+
+// expandSpokeSnmp returns the configured snmp block, or nil if it is not set.
+func expandSpokeSnmp(d *schema.ResourceData) *goaviatrix.GatewaySnmpConfig {
+	snmps := getList(d, "snmp")
+	if len(snmps) == 0 || snmps[0] == nil {
+		return nil
+	}
+	snmp := mustMap(snmps[0])
+	var allowedCidrs []string
+	for _, cidr := range mustSchemaSet(snmp["allowed_cidrs"]).List() {
+		allowedCidrs = append(allowedCidrs, mustString(cidr))
+	}
+	sort.Strings(allowedCidrs)
+	return &goaviatrix.GatewaySnmpConfig{
+		Version:      mustString(snmp["version"]),
+		Community:    mustString(snmp["community"]),
+		AllowedCidrs: allowedCidrs,
+	}
+}
+
+// readSpokeGatewaySnmp sets snmp from the SNMP agent configuration of the gateway. The community is kept
+// from the configuration if the controller does not report it.
+func readSpokeGatewaySnmp(d *schema.ResourceData, client *goaviatrix.Client, gwName string) error {
+	snmp, err := client.GetGatewaySnmpConfig(gwName)
+	if err != nil {
+		return fmt.Errorf("could not get SNMP configuration of spoke gateway %s: %w", gwName, err)
+	}
+	if snmp == nil {
+		mustSet(d, "snmp", nil)
+		return nil
+	}
+	community := snmp.Community
+	if community == "" {
+		if configured := expandSpokeSnmp(d); configured != nil {
+			community = configured.Community
+		}
+	}
+	mustSet(d, "snmp", []map[string]interface{}{
+		{
+			"version":       snmp.Version,
+			"community":     community,
+			"allowed_cidrs": snmp.AllowedCidrs,
+		},
+	})
+	return nil
+}
+
+// readSpokeGatewaySubnetIsPublic sets whether the subnet of an AWS spoke gateway is public, i.e. whether its
+// route table has a default route to an internet gateway. A failed lookup keeps the last known value instead
+// of failing the refresh.
+func readSpokeGatewaySubnetIsPublic(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) {
+	if !goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes) {
+		mustSet(d, "subnet_is_public", false)
+		return
+	}
+	vpc := &goaviatrix.Vpc{
+		AccountName: gw.AccountName,
+		Region:      gw.VpcRegion,
+		VpcID:       strings.Split(gw.VpcID, subnetSeparator)[0],
+	}
+	routeTable, err := client.GetSubnetRouteTable(vpc, gw.VpcNet)
+	if err != nil {
+		log.Printf("[WARN] could not get route table of subnet %s for spoke gateway %s: %v", gw.VpcNet, gw.GwName, err)
+		return
+	}
+	mustSet(d, "subnet_is_public", routeTable.HasInternetGatewayDefaultRoute())
+}
+
+// updateSpokeActiveStandbyFailoverDelay sets a changed active_standby_failover_delay. The attribute is computed,
+// so leaving it out of the configuration keeps the delay last read from the controller and changes nothing.
+func updateSpokeActiveStandbyFailoverDelay(d *schema.ResourceData, client *goaviatrix.Client) error {
+	activeStandbyFailoverDelay := getInt(d, "active_standby_failover_delay")
+	if !d.HasChange("active_standby_failover_delay") || activeStandbyFailoverDelay == 0 {
+		return nil
+	}
+	if !getBool(d, "enable_active_standby") {
+		return fmt.Errorf("could not configure 'active_standby_failover_delay' with Active-Standby disabled")
+	}
+	gateway := &goaviatrix.SpokeVpc{
+		GwName: getString(d, "gw_name"),
+	}
+	if err := client.SetSpokeActiveStandbyFailoverDelay(gateway, activeStandbyFailoverDelay); err != nil {
+		return fmt.Errorf("could not set Active-Standby failover delay during Spoke Gateway update: %w", err)
+	}
+	return nil
+}
+
+// readSpokeGatewayAttachedRouteTables sets the sorted IDs of the route tables an AWS spoke gateway programs.
+// A failed lookup keeps the last known value instead of failing the refresh.
+func readSpokeGatewayAttachedRouteTables(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) {
+	if !goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes) {
+		mustSet(d, "attached_route_table_ids", nil)
+		return
+	}
+	routeTableIDs, err := client.GetGatewayAttachedRouteTables(gw.GwName)
+	if err != nil {
+		log.Printf("[WARN] could not get attached route tables of spoke gateway %s: %v", gw.GwName, err)
+		return
+	}
+	sort.Strings(routeTableIDs)
+	mustSet(d, "attached_route_table_ids", routeTableIDs)
+}
+
+func resourceAviatrixSpokeGatewayCreateContext(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := monitorExcludeListVpcWarnings(d, mustClient(meta))
+	diags = append(diags, insaneModeGwSizeWarnings(d, "gw_size", "ha_gw_size")...)
+	if err := resourceAviatrixSpokeGatewayCreate(d, meta); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	client := mustClient(meta)
+
+	gateway := &goaviatrix.SpokeVpc{
+		CloudType:              getInt(d, "cloud_type"),
+		AccountName:            getString(d, "account_name"),
+		GwName:                 getString(d, "gw_name"),
+		VpcSize:                getString(d, "gw_size"),
+		Subnet:                 getString(d, "subnet"),
+		HASubnet:               getString(d, "ha_subnet"),
+		AvailabilityDomain:     getString(d, "availability_domain"),
+		FaultDomain:            getString(d, "fault_domain"),
+		ApprovedLearnedCidrs:   getStringSet(d, "approved_learned_cidrs"),
+		EnableGlobalVpc:        getBool(d, "enable_global_vpc"),
+		TunnelEncryptionCipher: getString(d, "tunnel_encryption_cipher"),
+		TunnelForwardSecrecy:   getString(d, "tunnel_forward_secrecy"),
+	}
+
+	if !getBool(d, "manage_ha_gateway") {
+		haSubnet := getString(d, "ha_subnet")
+		haZone := getString(d, "ha_zone")
 		haInsaneModeAz := getString(d, "ha_insane_mode_az")
 		haEip := getString(d, "ha_eip")
 		haAzureEipNameResourceGroup := getString(d, "ha_azure_eip_name_resource_group")
@@ -767,6 +2345,24 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	haGateways := getList(d, "ha_gateways")
+	if err := validateSpokeHaGateways(d, haGateways); err != nil {
+		return err
+	}
+
+	transitGwNames := getStringList(d, "attach_to_transit_gws")
+	if err := validateSpokeTransitGws(transitGwNames); err != nil {
+		return err
+	}
+	if err := validateSpokeTransitRouteTable(client, transitGwNames, getString(d, "transit_route_table")); err != nil {
+		return err
+	}
+
+	customizedRoutes, err := expandSpokeCustomizedRoutes(d)
+	if err != nil {
+		return err
+	}
+
 	if getBool(d, "enable_private_vpc_default_route") && !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
 		return fmt.Errorf("enable_private_vpc_default_route is only valid for AWS (1), AWSGov (256), AWSChina (1024), AWS Top Secret (16384) and AWS Secret (32768)")
 	}
@@ -774,6 +2370,33 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 	if getBool(d, "enable_skip_public_route_table_update") && !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
 		return fmt.Errorf("enable_skip_public_route_update is only valid for AWS (1), AWSGov (256), AWSChina (1024), AWS Top Secret (16384) and AWS Secret (32768)")
 	}
+	if err := validateSpokeCloudRouteTablePropagation(gateway.CloudType, getBool(d, "propagate_to_cloud_route_tables")); err != nil {
+		return err
+	}
+
+	if err := validateSpokeDnsForwarding(getBool(d, "enable_dns_forwarding"), getStringList(d, "dns_forwarding_targets")); err != nil {
+		return err
+	}
+	if err := validateSpokeSnat(getString(d, "snat_mode"), getList(d, "snat_policy")); err != nil {
+		return err
+	}
+	bgpCommunities, err := validateSpokeBgpCommunities(d)
+	if err != nil {
+		return err
+	}
+	if err := validateSpokeDefaultEgressAction(gateway.CloudType, getString(d, "default_egress_action")); err != nil {
+		return err
+	}
+	if err := validateSpokeGcpPrivateRoutes(gateway.CloudType, getStringSet(d, "gcp_private_routes")); err != nil {
+		return err
+	}
+
+	if iamInstanceProfile := getString(d, "aws_iam_instance_profile"); iamInstanceProfile != "" {
+		if !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
+			return fmt.Errorf("aws_iam_instance_profile is only valid for AWS (1), AWSGov (256), AWSChina (1024), AWS Top Secret (16384) and AWS Secret (32768)")
+		}
+		gateway.IamInstanceProfile = iamInstanceProfile
+	}
 
 	if _, hasSetZone := d.GetOk("zone"); !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AzureArmRelatedCloudTypes) && hasSetZone {
 		return fmt.Errorf("attribute 'zone' is only valid for Azure (8), Azure GOV (32) and Azure CHINA (2048)")
@@ -783,8 +2406,7 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		gateway.Subnet = fmt.Sprintf("%s~~%s~~", getString(d, "subnet"), getString(d, "zone"))
 	}
 
-	enableSNat := getBool(d, "single_ip_snat")
-	if enableSNat {
+	if spokeSnatMode(getBool(d, "single_ip_snat"), getString(d, "snat_mode")) == "single_ip" {
 		gateway.EnableNat = "yes"
 	}
 
@@ -797,21 +2419,40 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 
 	enableBgp := getBool(d, "enable_bgp")
 	disableRoutePropagation := getBool(d, "disable_route_propagation")
+	if err := validateSpokeBgp(gateway.CloudType, enableBgp); err != nil {
+		return err
+	}
 	if enableBgp {
-		if !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWS|goaviatrix.Azure) {
-			return fmt.Errorf("enabling BGP is only supported for AWS (1) and Azure (8)")
-		}
 		gateway.EnableBgp = "yes"
 	} else {
 		if disableRoutePropagation {
 			return fmt.Errorf("disable route propagation is not supported on Non-BGP Spoke")
 		}
+		if getBool(d, "bgp_neighbor_passive") {
+			return fmt.Errorf("bgp_neighbor_passive is not supported for Non-BGP Spoke Gateways")
+		}
+		if len(getStringSet(d, "bgp_summary_cidrs")) != 0 {
+			return fmt.Errorf("bgp_summary_cidrs is not supported for Non-BGP Spoke Gateways")
+		}
+		if len(getStringSet(d, "bgp_community_outbound_filter")) != 0 {
+			return fmt.Errorf("bgp_community_outbound_filter is not supported for Non-BGP Spoke Gateways")
+		}
+		if getBool(d, "bgp_next_hop_self") {
+			return fmt.Errorf("'bgp_next_hop_self' is not supported for Non-BGP Spoke Gateways")
+		}
 	}
 
 	learnedCidrsApproval := getBool(d, "enable_learned_cidrs_approval")
 	if !learnedCidrsApproval && len(gateway.ApprovedLearnedCidrs) != 0 {
 		return fmt.Errorf("'approved_learned_cidrs' must be empty if 'enable_learned_cidrs_approval' is false")
 	}
+	if err := validateSpokeLearnedCidrsApprovalMode(d); err != nil {
+		return err
+	}
+	connectionApprovedCidrs, err := validateSpokeConnectionApprovedCidrs(d)
+	if err != nil {
+		return err
+	}
 
 	if goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.GCPRelatedCloudTypes|goaviatrix.OCIRelatedCloudTypes|goaviatrix.AliCloudRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes) {
 		gateway.VpcID = getString(d, "vpc_id")
@@ -837,6 +2478,28 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 	if !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.OCIRelatedCloudTypes) && (gateway.AvailabilityDomain != "" || gateway.FaultDomain != "") {
 		return fmt.Errorf("'availability_domain' and 'fault_domain' are only valid for OCI")
 	}
+	if err := validateSpokeVpcDnsServer(gateway.CloudType, getBool(d, "enable_vpc_dns_server")); err != nil {
+		return err
+	}
+	if additionalFaultDomains := getStringSet(d, "additional_fault_domains"); len(additionalFaultDomains) != 0 {
+		if err := validateSpokeAdditionalFaultDomains(client, gateway, additionalFaultDomains); err != nil {
+			return err
+		}
+		gateway.AdditionalFaultDomains = strings.Join(additionalFaultDomains, ",")
+	}
+	if fqdnGwName := getString(d, "fqdn_gateway_name"); fqdnGwName != "" {
+		if err := validateFqdnGatewayExists(client, fqdnGwName); err != nil {
+			return err
+		}
+	}
+	if connName := getString(d, "egress_via_connection"); connName != "" {
+		if err := validateSpokeEgressConnectionExists(client, getString(d, "vpc_id"), connName); err != nil {
+			return err
+		}
+	}
+	if err := validateSpokeBgpRouteMaps(client, enableBgp, getString(d, "bgp_import_route_map"), getString(d, "bgp_export_route_map")); err != nil {
+		return err
+	}
 
 	insaneMode := getBool(d, "insane_mode")
 	insaneModeAz := getString(d, "insane_mode_az")
@@ -908,6 +2571,10 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		}
 		gateway.CustomerManagedKeys = customerManagedKeys
 	}
+	haCustomerManagedKeys := getString(d, "ha_customer_managed_keys")
+	if haCustomerManagedKeys != "" && !enableEncryptVolume {
+		return fmt.Errorf("'ha_customer_managed_keys' should be empty since Encrypt Volume is not enabled")
+	}
 	if !enableEncryptVolume && goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
 		gateway.EncVolume = "no"
 	}
@@ -1029,6 +2696,13 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 	if !enableActiveStandby && enableActiveStandbyPreemptive {
 		return fmt.Errorf("could not configure Preemptive Mode with Active-Standby disabled")
 	}
+	activeStandbyFailoverDelay := getInt(d, "active_standby_failover_delay")
+	if !enableActiveStandby && activeStandbyFailoverDelay != 0 {
+		return fmt.Errorf("could not configure 'active_standby_failover_delay' with Active-Standby disabled")
+	}
+	if err := validateSpokeHaMode(d); err != nil {
+		return err
+	}
 
 	enableSpotInstance := getBool(d, "enable_spot_instance")
 	spotPrice := getString(d, "spot_price")
@@ -1163,7 +2837,7 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 	flag := false
 	defer func() { _ = resourceAviatrixSpokeGatewayReadIfRequired(d, meta, &flag) }() //nolint:errcheck // read on deferred path
 
-	err := client.LaunchSpokeVpc(gateway)
+	err = client.LaunchSpokeVpc(gateway)
 	if err != nil {
 		return fmt.Errorf("failed to create Aviatrix Spoke Gateway: %w", err)
 	}
@@ -1202,6 +2876,12 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if len(bgpCommunities) != 0 {
+		if err := client.SetSpokeBgpPrefixCommunities(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, bgpCommunities); err != nil {
+			return fmt.Errorf("failed to set BGP communities of advertised CIDRs for gateway %s: %w", gateway.GwName, err)
+		}
+	}
+
 	if haSubnet != "" || haZone != "" {
 		spokeHaGw := &goaviatrix.SpokeHaGateway{
 			PrimaryGwName: getString(d, "gw_name"),
@@ -1303,10 +2983,24 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 			}
 			mustSet(d, "ha_gw_size", haGwSize)
 		}
+
+		if haCustomerManagedKeys != "" {
+			if err := encryptSpokeHaGatewayVolumes(d, client, []string{getString(d, "gw_name") + "-hagw"}); err != nil {
+				return err
+			}
+		}
 	}
 
-	enableVpcDnsServer := getBool(d, "enable_vpc_dns_server")
-	if goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes|goaviatrix.AliCloudRelatedCloudTypes) && enableVpcDnsServer {
+	if err := createSpokeHaGateways(d, client, haGateways); err != nil {
+		return err
+	}
+	if haCustomerManagedKeys != "" {
+		if err := encryptSpokeHaGatewayVolumes(d, client, spokeHaGatewayNames(haGateways)); err != nil {
+			return err
+		}
+	}
+
+	if getBool(d, "enable_vpc_dns_server") {
 		gwVpcDnsServer := &goaviatrix.Gateway{
 			GwName: getString(d, "gw_name"),
 		}
@@ -1317,67 +3011,48 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		if err != nil {
 			return fmt.Errorf("failed to enable VPC DNS Server: %w", err)
 		}
-	} else if enableVpcDnsServer {
-		return fmt.Errorf("'enable_vpc_dns_server' only supported by AWS (1), Azure (8), AzureGov (32), AWSGov (256), AWSChina (1024), AzureChina (2048), Alibaba Cloud (8192), AWS Top Secret (16384) or AWS Secret (32768)")
 	}
 
-	if customizedSpokeVpcRoutes := getString(d, "customized_spoke_vpc_routes"); customizedSpokeVpcRoutes != "" {
-		transitGateway := &goaviatrix.Gateway{
-			GwName:                   getString(d, "gw_name"),
-			CustomizedSpokeVpcRoutes: strings.Split(customizedSpokeVpcRoutes, ","),
-		}
-		for i := 0; ; i++ {
-			log.Printf("[INFO] Editing customized routes of spoke gateway: %s ", transitGateway.GwName)
-			err := client.EditGatewayCustomRoutes(transitGateway)
-			if err == nil {
-				break
-			}
-			if i <= 18 && (strings.Contains(err.Error(), "when it is down") || strings.Contains(err.Error(), "hagw is down") ||
-				strings.Contains(err.Error(), "gateway is down")) {
-				time.Sleep(10 * time.Second)
-			} else {
-				return fmt.Errorf("failed to customize spoke vpc routes of spoke gateway: %s due to: %w", transitGateway.GwName, err)
-			}
+	if customizedSpokeVpcRoutes := getSpokeRoutes(d, "customized_spoke_vpc_routes"); len(customizedSpokeVpcRoutes) != 0 || len(customizedRoutes) != 0 {
+		transitGateway := &goaviatrix.Gateway{
+			GwName:                   getString(d, "gw_name"),
+			CustomizedSpokeVpcRoutes: customizedSpokeVpcRoutes,
+			CustomizedRoutes:         customizedRoutes,
+		}
+		log.Printf("[INFO] Editing customized routes of spoke gateway: %s ", transitGateway.GwName)
+		err := client.RetryableDo(goaviatrix.IsGatewayDownError, func() error {
+			return client.EditGatewayCustomRoutes(transitGateway)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to customize spoke vpc routes of spoke gateway: %s due to: %w", transitGateway.GwName, err)
 		}
 	}
 
-	if filteredSpokeVpcRoutes := getString(d, "filtered_spoke_vpc_routes"); filteredSpokeVpcRoutes != "" {
+	if filteredSpokeVpcRoutes := getSpokeRoutes(d, "filtered_spoke_vpc_routes"); len(filteredSpokeVpcRoutes) != 0 {
 		transitGateway := &goaviatrix.Gateway{
 			GwName:                 getString(d, "gw_name"),
-			FilteredSpokeVpcRoutes: strings.Split(filteredSpokeVpcRoutes, ","),
+			FilteredSpokeVpcRoutes: filteredSpokeVpcRoutes,
 		}
-		for i := 0; ; i++ {
-			log.Printf("[INFO] Editing filtered routes of spoke gateway: %s ", transitGateway.GwName)
-			err := client.EditGatewayFilterRoutes(transitGateway)
-			if err == nil {
-				break
-			}
-			if i <= 18 && (strings.Contains(err.Error(), "when it is down") || strings.Contains(err.Error(), "hagw is down") ||
-				strings.Contains(err.Error(), "gateway is down")) {
-				time.Sleep(10 * time.Second)
-			} else {
-				return fmt.Errorf("failed to edit filtered spoke vpc routes of spoke gateway: %s due to: %w", transitGateway.GwName, err)
-			}
+		log.Printf("[INFO] Editing filtered routes of spoke gateway: %s ", transitGateway.GwName)
+		err := client.RetryableDo(goaviatrix.IsGatewayDownError, func() error {
+			return client.EditGatewayFilterRoutes(transitGateway)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to edit filtered spoke vpc routes of spoke gateway: %s due to: %w", transitGateway.GwName, err)
 		}
 	}
 
-	if includedAdvertisedSpokeRoutes := getString(d, "included_advertised_spoke_routes"); includedAdvertisedSpokeRoutes != "" {
+	if includedAdvertisedSpokeRoutes := getSpokeRoutes(d, "included_advertised_spoke_routes"); len(includedAdvertisedSpokeRoutes) != 0 {
 		transitGateway := &goaviatrix.Gateway{
 			GwName:                getString(d, "gw_name"),
-			AdvertisedSpokeRoutes: strings.Split(includedAdvertisedSpokeRoutes, ","),
+			AdvertisedSpokeRoutes: includedAdvertisedSpokeRoutes,
 		}
-		for i := 0; ; i++ {
-			log.Printf("[INFO] Editing customized routes advertisement of spoke gateway: %s ", transitGateway.GwName)
-			err := client.EditGatewayAdvertisedCidr(transitGateway)
-			if err == nil {
-				break
-			}
-			if i <= 30 && (strings.Contains(err.Error(), "when it is down") || strings.Contains(err.Error(), "hagw is down") ||
-				strings.Contains(err.Error(), "gateway is down")) {
-				time.Sleep(10 * time.Second)
-			} else {
-				return fmt.Errorf("failed to edit advertised spoke vpc routes of spoke gateway: %s due to: %w", transitGateway.GwName, err)
-			}
+		log.Printf("[INFO] Editing customized routes advertisement of spoke gateway: %s ", transitGateway.GwName)
+		err := client.RetryableDo(goaviatrix.IsGatewayDownError, func() error {
+			return client.EditGatewayAdvertisedCidr(transitGateway)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to edit advertised spoke vpc routes of spoke gateway: %s due to: %w", transitGateway.GwName, err)
 		}
 	}
 
@@ -1389,16 +3064,30 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 	}
 
 	if !getBool(d, "enable_jumbo_frame") {
-		gw := &goaviatrix.Gateway{
-			GwName: getString(d, "gw_name"),
+		var haGwName string
+		if haSubnet != "" || haZone != "" {
+			haGwName = getString(d, "gw_name") + "-hagw"
 		}
-
-		err := client.DisableJumboFrame(gw)
+		err := setSpokeGatewayJumboFrame(client, false, getString(d, "gw_name"), haGwName)
 		if err != nil {
 			return fmt.Errorf("could not disable jumbo frame for spoke gateway: %w", err)
 		}
 	}
 
+	if maintenanceWindow := expandSpokeMaintenanceWindow(d); maintenanceWindow != nil {
+		err := client.SetGatewayMaintenanceWindow(getString(d, "gw_name"), maintenanceWindow)
+		if err != nil {
+			return fmt.Errorf("could not set maintenance window for spoke gateway: %w", err)
+		}
+	}
+
+	if snmp := expandSpokeSnmp(d); snmp != nil {
+		err := client.SetGatewaySnmpConfig(getString(d, "gw_name"), snmp)
+		if err != nil {
+			return fmt.Errorf("could not enable SNMP for spoke gateway: %w", err)
+		}
+	}
+
 	if !getBool(d, "enable_gro_gso") {
 		gw := &goaviatrix.Gateway{
 			GwName: getString(d, "gw_name"),
@@ -1409,6 +3098,83 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if description := getString(d, "description"); description != "" {
+		err := client.SetGatewayDescription(context.Background(), getString(d, "gw_name"), description)
+		if err != nil {
+			return fmt.Errorf("could not set description for spoke gateway: %w", err)
+		}
+	}
+
+	if tcpMss := getInt(d, "tcp_mss_clamp"); tcpMss != 0 {
+		err := client.SetSpokeTcpMssClamp(&goaviatrix.SpokeVpc{GwName: getString(d, "gw_name")}, tcpMss)
+		if err != nil {
+			return fmt.Errorf("could not set TCP MSS clamp for spoke gateway: %w", err)
+		}
+	}
+
+	if interval := getInt(d, "peering_keepalive_interval"); interval != 0 {
+		err := client.SetSpokePeeringKeepaliveInterval(&goaviatrix.SpokeVpc{GwName: getString(d, "gw_name")}, interval)
+		if err != nil {
+			return fmt.Errorf("could not set peering keepalive interval for spoke gateway: %w", err)
+		}
+	}
+
+	if timezone := getString(d, "timezone"); timezone != "" {
+		err := client.SetSpokeTimezone(&goaviatrix.SpokeVpc{GwName: getString(d, "gw_name")}, timezone)
+		if err != nil {
+			return fmt.Errorf("could not set time zone for spoke gateway: %w", err)
+		}
+	}
+
+	ikeProposals := getStringList(d, "ike_proposals")
+	espProposals := getStringList(d, "esp_proposals")
+	if len(ikeProposals) != 0 || len(espProposals) != 0 {
+		err := client.SetSpokeIpsecProposals(&goaviatrix.SpokeVpc{GwName: getString(d, "gw_name")}, ikeProposals, espProposals)
+		if err != nil {
+			return fmt.Errorf("could not set IPsec proposals for spoke gateway: %w", err)
+		}
+	}
+
+	if getBool(d, "enable_dns_forwarding") {
+		err := client.SetSpokeDnsForwarding(&goaviatrix.SpokeVpc{GwName: getString(d, "gw_name")}, true, getStringList(d, "dns_forwarding_targets"))
+		if err != nil {
+			return fmt.Errorf("could not enable DNS forwarding for spoke gateway: %w", err)
+		}
+	}
+
+	if getString(d, "snat_mode") == "customized" {
+		err := client.EnableCustomizedSNat(&goaviatrix.Gateway{
+			GatewayName: getString(d, "gw_name"),
+			EnableNat:   "yes",
+			SnatMode:    "custom",
+			SnatPolicy:  expandSNatPolicy(getList(d, "snat_policy")),
+		})
+		if err != nil {
+			return fmt.Errorf("could not enable 'customized' mode SNAT for spoke gateway: %w", err)
+		}
+	}
+
+	if defaultEgressAction := getString(d, "default_egress_action"); defaultEgressAction != "allow" {
+		err := client.SetSpokeDefaultEgressAction(&goaviatrix.SpokeVpc{GwName: getString(d, "gw_name")}, defaultEgressAction)
+		if err != nil {
+			return fmt.Errorf("could not set default egress action for spoke gateway: %w", err)
+		}
+	}
+
+	if fqdnGwName := getString(d, "fqdn_gateway_name"); fqdnGwName != "" {
+		err := client.SetSpokeEgressFqdnGateway(&goaviatrix.SpokeVpc{GwName: getString(d, "gw_name")}, fqdnGwName)
+		if err != nil {
+			return fmt.Errorf("could not associate FQDN gateway %s for egress of spoke gateway: %w", fqdnGwName, err)
+		}
+	}
+
+	if connName := getString(d, "egress_via_connection"); connName != "" {
+		err := client.SetSpokeEgressViaConnection(&goaviatrix.SpokeVpc{GwName: getString(d, "gw_name")}, connName)
+		if err != nil {
+			return fmt.Errorf("could not direct egress of spoke gateway through connection %s: %w", connName, err)
+		}
+	}
+
 	if getBool(d, "enable_private_vpc_default_route") {
 		gw := &goaviatrix.Gateway{
 			GwName: getString(d, "gw_name"),
@@ -1429,6 +3195,27 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if !getBool(d, "propagate_to_cloud_route_tables") {
+		gw := &goaviatrix.Gateway{
+			GwName: getString(d, "gw_name"),
+		}
+		err := client.SetCloudRouteTablePropagation(gw, false)
+		if err != nil {
+			return fmt.Errorf("could not disable cloud route table propagation after spoke gateway creation: %w", err)
+		}
+	}
+
+	if !getBool(d, "enable_admin_ssh") {
+		var haGwName string
+		if haSubnet != "" || haZone != "" {
+			haGwName = getString(d, "gw_name") + "-hagw"
+		}
+		err := setSpokeGatewayAdminSSH(client, false, getString(d, "gw_name"), haGwName)
+		if err != nil {
+			return fmt.Errorf("could not disable admin SSH after spoke gateway creation: %w", err)
+		}
+	}
+
 	if getBool(d, "enable_auto_advertise_s2c_cidrs") {
 		gw := &goaviatrix.Gateway{
 			GwName: getString(d, "gw_name"),
@@ -1446,6 +3233,13 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	approvalMode := getString(d, "learned_cidrs_approval_mode")
+	if approvalMode != defaultLearnedCidrApprovalMode {
+		err := client.SetSpokeLearnedCIDRsApprovalMode(gateway, approvalMode)
+		if err != nil {
+			return fmt.Errorf("could not set learned CIDRs approval mode to %q: %w", approvalMode, err)
+		}
+	}
 	if learnedCidrsApproval {
 		gateway.LearnedCidrsApproval = "on"
 		err := client.EnableSpokeLearnedCidrsApproval(gateway)
@@ -1459,6 +3253,12 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 			return fmt.Errorf("failed to update approved CIDRs: %w", err)
 		}
 	}
+	for connName, cidrs := range connectionApprovedCidrs {
+		err := client.UpdateSpokeConnectionPendingApprovedCidrs(gateway.GwName, connName, cidrs)
+		if err != nil {
+			return fmt.Errorf("failed to update approved CIDRs of connection %s: %w", connName, err)
+		}
+	}
 
 	if val, ok := d.GetOk("spoke_bgp_manual_advertise_cidrs"); ok {
 		var spokeBgpManualSpokeAdvertiseCidrs []string
@@ -1490,6 +3290,11 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 				return fmt.Errorf("could not enable Active-Standby: %w", err)
 			}
 		}
+		if activeStandbyFailoverDelay != 0 {
+			if err := client.SetSpokeActiveStandbyFailoverDelay(gateway, activeStandbyFailoverDelay); err != nil {
+				return fmt.Errorf("could not set Active-Standby failover delay: %w", err)
+			}
+		}
 	}
 
 	if disableRoutePropagation {
@@ -1544,6 +3349,49 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if getBool(d, "bgp_neighbor_passive") {
+		err := client.SetSpokeBgpNeighborPassive(gateway, true)
+		if err != nil {
+			return fmt.Errorf("could not enable BGP neighbor passive mode after Spoke Gateway creation: %w", err)
+		}
+	}
+
+	if haMode := getString(d, "ha_mode"); haMode != "" {
+		err := client.SetSpokeHaMode(gateway, haMode)
+		if err != nil {
+			return fmt.Errorf("could not set HA mode after Spoke Gateway creation: %w", err)
+		}
+	}
+
+	if bgpSummaryCidrs := getStringSet(d, "bgp_summary_cidrs"); len(bgpSummaryCidrs) != 0 {
+		err := client.SetSpokeBgpSummaryCidrs(gateway, bgpSummaryCidrs)
+		if err != nil {
+			return fmt.Errorf("could not set BGP summary CIDRs after Spoke Gateway creation: %w", err)
+		}
+	}
+
+	if bgpCommunityOutboundFilter := getStringSet(d, "bgp_community_outbound_filter"); len(bgpCommunityOutboundFilter) != 0 {
+		err := client.SetSpokeBgpCommunityOutboundFilter(gateway, bgpCommunityOutboundFilter)
+		if err != nil {
+			return fmt.Errorf("could not set BGP community outbound filter after Spoke Gateway creation: %w", err)
+		}
+	}
+
+	importRouteMap, exportRouteMap := getString(d, "bgp_import_route_map"), getString(d, "bgp_export_route_map")
+	if importRouteMap != "" || exportRouteMap != "" {
+		err := client.SetSpokeBgpRouteMaps(gateway, importRouteMap, exportRouteMap)
+		if err != nil {
+			return fmt.Errorf("could not set BGP route maps after Spoke Gateway creation: %w", err)
+		}
+	}
+
+	if getBool(d, "bgp_next_hop_self") {
+		err := client.SetSpokeBgpNextHopSelf(gateway, true)
+		if err != nil {
+			return fmt.Errorf("could not enable BGP next-hop-self after Spoke Gateway creation: %w", err)
+		}
+	}
+
 	enableSpokePreserveAsPath := getBool(d, "enable_preserve_as_path")
 	if enableSpokePreserveAsPath {
 		if enableBgp {
@@ -1565,21 +3413,14 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		if err != nil {
 			return fmt.Errorf("failed to set rx queue size for spoke %s: %w", gateway.GwName, err)
 		}
-		if haSubnet != "" || haZone != "" {
-			haGwRxQueueSize := &goaviatrix.Gateway{
-				GwName:      getString(d, "gw_name") + "-hagw",
-				RxQueueSize: rxQueueSize,
-			}
-			err := client.SetRxQueueSize(haGwRxQueueSize)
-			if err != nil {
-				return fmt.Errorf("failed to set rx queue size for spoke ha %s : %w", haGwRxQueueSize.GwName, err)
-			}
+		err = setSpokeHaRxQueueSize(client, getString(d, "gw_name"), rxQueueSize, getBool(d, "manage_ha_gateway"), haSubnet != "" || haZone != "")
+		if err != nil {
+			return fmt.Errorf("failed to set rx queue size for spoke ha: %w", err)
 		}
 	}
 
 	if goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AzureArmRelatedCloudTypes) {
 		routeTables := getStringSet(d, "private_route_table_config")
-		fmt.Println("######## routeTables", routeTables)
 		if len(routeTables) > 0 {
 			gw := &goaviatrix.Gateway{GwName: getString(d, "gw_name")}
 			err := client.EditPrivateRouteTableConfig(gw, routeTables)
@@ -1589,12 +3430,35 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if routes := getStringSet(d, "gcp_private_routes"); len(routes) > 0 {
+		err := client.EditGcpPrivateRoutes(&goaviatrix.Gateway{GwName: getString(d, "gw_name")}, routes)
+		if err != nil {
+			return fmt.Errorf("could not edit GCP private routes: %w", err)
+		}
+	}
+
+	if err := reconcileSpokeTransitGws(client, getString(d, "gw_name"), nil, transitGwNames); err != nil {
+		return err
+	}
+	if routeTable := getString(d, "transit_route_table"); routeTable != "" {
+		if err := applySpokeTransitRouteTable(client, getString(d, "gw_name"), transitGwNames, routeTable); err != nil {
+			return err
+		}
+	}
+
+	if getSet(d, "firenet_inspection_exclude_cidrs").Len() != 0 {
+		if err := applySpokeFireNetInspectionExcludeCidrs(d, client); err != nil {
+			return err
+		}
+	}
+
 	return resourceAviatrixSpokeGatewayReadIfRequired(d, meta, &flag)
 }
 
 func resourceAviatrixSpokeGatewayReadIfRequired(d *schema.ResourceData, meta interface{}, flag *bool) error {
 	if !(*flag) {
 		*flag = true
+		mustClient(meta).ForgetCachedGateway(getString(d, "gw_name"))
 		return resourceAviatrixSpokeGatewayRead(d, meta)
 	}
 	return nil
@@ -1602,7 +3466,6 @@ func resourceAviatrixSpokeGatewayReadIfRequired(d *schema.ResourceData, meta int
 
 func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{}) error {
 	client := mustClient(meta)
-	ignoreTagsConfig := client.IgnoreTagsConfig
 
 	var isImport bool
 	gwName := getString(d, "gw_name")
@@ -1612,15 +3475,11 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 		log.Printf("[DEBUG] Looks like an import, no gateway name received. Import Id is %s", id)
 		mustSet(d, "gw_name", id)
 		mustSet(d, "manage_ha_gateway", true)
+		mustSet(d, "delete_primary_first", false)
 		d.SetId(id)
 	}
 
-	gateway := &goaviatrix.Gateway{
-		AccountName: getString(d, "account_name"),
-		GwName:      getString(d, "gw_name"),
-	}
-
-	gw, err := client.GetGateway(gateway)
+	gw, err := getGatewayCached(client, getString(d, "gw_name"))
 	if err != nil {
 		if errors.Is(err, goaviatrix.ErrNotFound) {
 			d.SetId("")
@@ -1635,21 +3494,52 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 	mustSet(d, "enable_encrypt_volume", gw.EnableEncryptVolume)
 	mustSet(d, "enable_private_vpc_default_route", gw.PrivateVpcDefaultEnabled)
 	mustSet(d, "enable_skip_public_route_table_update", gw.SkipPublicVpcUpdateEnabled)
-	mustSet(d, "private_route_table_config", gw.PrivateRouteTableConfig)
+	mustSet(d, "propagate_to_cloud_route_tables", !gw.CloudRouteTablePropagationOff)
+	setSpokePrivateRoutes(d, gw)
 	mustSet(d, "enable_auto_advertise_s2c_cidrs", gw.AutoAdvertiseCidrsEnabled)
+	readSpokeGatewayEipAllocationID(d, client, gw)
 	mustSet(d, "eip", gw.PublicIP)
+	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes) {
+		mustSet(d, "aws_iam_instance_profile", gw.IamInstanceProfile)
+	} else {
+		mustSet(d, "aws_iam_instance_profile", "")
+	}
+	if err := readSpokeGatewayUtilization(d, client, gw.GwName); err != nil {
+		return err
+	}
+	if _, ok := d.GetOk("maintenance_window"); ok || isImport {
+		if err := readSpokeGatewayMaintenanceWindow(d, client, gw.GwName); err != nil {
+			return err
+		}
+	}
+	if _, ok := d.GetOk("snmp"); ok || isImport {
+		if err := readSpokeGatewaySnmp(d, client, gw.GwName); err != nil {
+			return err
+		}
+	}
+	readSpokeGatewayPeeringTunnelNames(d, client, gw.GwName)
+	readSpokeGatewayPrivateIPAllocation(d, client, gw.GwName)
 	mustSet(d, "subnet", gw.VpcNet)
 	mustSet(d, "gw_size", gw.GwSize)
 	mustSet(d, "cloud_instance_id", gw.CloudnGatewayInstID)
 	mustSet(d, "security_group_id", gw.GwSecurityGroupID)
 	mustSet(d, "private_ip", gw.PrivateIP)
 	mustSet(d, "single_az_ha", gw.SingleAZ == "yes")
-	mustSet(d, "enable_vpc_dns_server", goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes|goaviatrix.AliCloudRelatedCloudTypes) && gw.EnableVpcDnsServer == "Enabled")
-	mustSet(d, "single_ip_snat", gw.EnableNat == "yes" && gw.SnatMode == "primary")
-	mustSet(d, "enable_jumbo_frame", gw.JumboFrame)
+	mustSet(d, "enable_vpc_dns_server", goaviatrix.IsCloudType(gw.CloudType, spokeVpcDnsServerCloudTypes) && gw.EnableVpcDnsServer == "Enabled")
+	if err := readSpokeGatewaySnat(d, client, gw); err != nil {
+		return err
+	}
+	if err := readSpokeGatewaySnatPublicIP(d, client, gw); err != nil {
+		return err
+	}
+	readSpokeGatewayAdminSSH(d, client, gw.GwName)
+	readSpokeGatewayJumboFrame(d, gw)
 	mustSet(d, "enable_bgp", gw.EnableBgp)
 	mustSet(d, "enable_bgp_over_lan", goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AzureArmRelatedCloudTypes) && gw.EnableBgpOverLan)
 	mustSet(d, "enable_ipv6", gw.EnableIPv6)
+	if err := readSpokeGatewayIPv6Operational(d, client, gw); err != nil {
+		return err
+	}
 	mustSet(d, "insertion_gateway", gw.InsertionGateway)
 	mustSet(d, "subnet_ipv6_cidr", gw.SubnetIPv6Cidr)
 
@@ -1662,9 +3552,9 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 	mustSet(d, "tunnel_forward_secrecy", gw.TunnelForwardSecrecy)
 
 	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AzureArmRelatedCloudTypes) && gw.EnableBgpOverLan {
-		bgpLanIpInfo, err := client.GetBgpLanIPList(&goaviatrix.TransitVpc{GwName: gateway.GwName})
+		bgpLanIpInfo, err := client.GetBgpLanIPList(&goaviatrix.TransitVpc{GwName: gw.GwName})
 		if err != nil {
-			return fmt.Errorf("could not get BGP LAN IP info for Azure spoke gateway %s: %w", gateway.GwName, err)
+			return fmt.Errorf("could not get BGP LAN IP info for Azure spoke gateway %s: %w", gw.GwName, err)
 		}
 		if err = d.Set("bgp_lan_ip_list", bgpLanIpInfo.AzureBgpLanIpList); err != nil {
 			log.Printf("[WARN] could not set bgp_lan_ip_list into state: %s", err)
@@ -1704,10 +3594,96 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 	} else {
 		mustSet(d, "approved_learned_cidrs", nil)
 	}
+
+	managedConnections, err := expandSpokeConnectionApprovedCidrs(getSet(d, "connection_approved_cidrs").List())
+	if err != nil {
+		return err
+	}
+	if gw.EnableBgp && len(managedConnections) != 0 {
+		spokeAdvancedConfig, err := client.GetSpokeGatewayAdvancedConfig(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("could not get advanced config for spoke gateway: %w", err)
+		}
+		connectionApprovedCidrs := flattenSpokeConnectionApprovedCidrs(managedConnections, spokeAdvancedConfig.ConnectionLearnedCIDRApprovalInfo)
+		if err = d.Set("connection_approved_cidrs", connectionApprovedCidrs); err != nil {
+			return fmt.Errorf("could not set connection_approved_cidrs into state: %w", err)
+		}
+	} else {
+		mustSet(d, "connection_approved_cidrs", nil)
+	}
 	mustSet(d, "local_as_number", gw.LocalASNumber)
+	if gw.EnableBgp {
+		readSpokeGatewayExternalBgpPeers(d, client, gw.GwName)
+
+		readSpokeGatewayAdvertisedRoutes(d, client, gw.GwName)
+
+		if getBool(d, "bgp_neighbor_passive") || isImport {
+			passive, err := client.GetSpokeBgpNeighborPassive(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+			if err != nil {
+				return fmt.Errorf("could not get BGP neighbor passive mode for spoke gateway %s: %w", gw.GwName, err)
+			}
+			mustSet(d, "bgp_neighbor_passive", passive)
+		}
+
+		if _, ok := d.GetOk("ha_mode"); ok {
+			haMode, err := client.GetSpokeHaMode(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+			if err != nil {
+				return fmt.Errorf("could not get HA mode for spoke gateway %s: %w", gw.GwName, err)
+			}
+			mustSet(d, "ha_mode", haMode)
+		}
+
+		if _, ok := d.GetOk("bgp_summary_cidrs"); ok || isImport {
+			bgpSummaryCidrs, err := client.GetSpokeBgpSummaryCidrs(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+			if err != nil {
+				return fmt.Errorf("could not get BGP summary CIDRs for spoke gateway %s: %w", gw.GwName, err)
+			}
+			mustSet(d, "bgp_summary_cidrs", bgpSummaryCidrs)
+		}
+
+		if _, ok := d.GetOk("bgp_community_outbound_filter"); ok || isImport {
+			bgpCommunityOutboundFilter, err := client.GetSpokeBgpCommunityOutboundFilter(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+			if err != nil {
+				return fmt.Errorf("could not get BGP community outbound filter for spoke gateway %s: %w", gw.GwName, err)
+			}
+			mustSet(d, "bgp_community_outbound_filter", bgpCommunityOutboundFilter)
+		}
+
+		if getString(d, "bgp_import_route_map") != "" || getString(d, "bgp_export_route_map") != "" || isImport {
+			importRouteMap, exportRouteMap, err := client.GetSpokeBgpRouteMaps(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+			if err != nil {
+				return fmt.Errorf("could not get BGP route maps for spoke gateway %s: %w", gw.GwName, err)
+			}
+			mustSet(d, "bgp_import_route_map", importRouteMap)
+			mustSet(d, "bgp_export_route_map", exportRouteMap)
+		}
+
+		if getBool(d, "bgp_next_hop_self") || isImport {
+			nextHopSelf, err := client.GetSpokeBgpNextHopSelf(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+			if err != nil {
+				return fmt.Errorf("could not get BGP next-hop-self for spoke gateway %s: %w", gw.GwName, err)
+			}
+			mustSet(d, "bgp_next_hop_self", nextHopSelf)
+		}
+	} else {
+		mustSet(d, "external_bgp_peers", nil)
+		mustSet(d, "advertised_routes", nil)
+		mustSet(d, "bgp_neighbor_passive", false)
+		mustSet(d, "bgp_summary_cidrs", nil)
+		mustSet(d, "bgp_community_outbound_filter", nil)
+		mustSet(d, "bgp_import_route_map", "")
+		mustSet(d, "bgp_export_route_map", "")
+		mustSet(d, "bgp_next_hop_self", false)
+	}
 	mustSet(d, "bgp_ecmp", gw.BgpEcmp)
 	mustSet(d, "enable_active_standby", gw.EnableActiveStandby)
 	mustSet(d, "enable_active_standby_preemptive", gw.EnableActiveStandbyPreemptive)
+	readSpokeGatewayActiveGatewayInstance(d, client, gw)
+	if gw.EnableActiveStandby {
+		mustSet(d, "active_standby_failover_delay", gw.ActiveStandbyFailoverDelay)
+	} else {
+		mustSet(d, "active_standby_failover_delay", 0)
+	}
 	mustSet(d, "disable_route_propagation", gw.DisableRoutePropagation)
 	var prependAsPath []string
 	for _, p := range strings.Split(gw.PrependASPath, " ") {
@@ -1775,6 +3751,8 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 		mustSet(d, "vpc_reg", gw.VpcRegion)
 		mustSet(d, "allocate_new_eip", true)
 	}
+	readSpokeGatewaySubnetIsPublic(d, client, gw)
+	readSpokeGatewayAttachedRouteTables(d, client, gw)
 
 	if gw.InsaneMode == "yes" {
 		mustSet(d, "insane_mode", true)
@@ -1788,64 +3766,23 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 		mustSet(d, "insane_mode_az", "")
 	}
 
-	if len(gw.CustomizedSpokeVpcRoutes) != 0 {
-		if customizedSpokeVpcRoutes := getString(d, "customized_spoke_vpc_routes"); customizedSpokeVpcRoutes != "" {
-			customizedRoutesArray := strings.Split(customizedSpokeVpcRoutes, ",")
-			if len(goaviatrix.Difference(customizedRoutesArray, gw.CustomizedSpokeVpcRoutes)) == 0 &&
-				len(goaviatrix.Difference(gw.CustomizedSpokeVpcRoutes, customizedRoutesArray)) == 0 {
-				mustSet(d, "customized_spoke_vpc_routes", customizedSpokeVpcRoutes)
-			} else {
-				mustSet(d, "customized_spoke_vpc_routes", strings.Join(gw.CustomizedSpokeVpcRoutes, ","))
-			}
-		} else {
-			mustSet(d, "customized_spoke_vpc_routes", strings.Join(gw.CustomizedSpokeVpcRoutes, ","))
-		}
-	} else {
+	if hasSpokeCustomizedRouteNextHops(gw.CustomizedRoutes) || getSet(d, "customized_routes").Len() != 0 {
+		mustSet(d, "customized_routes", flattenSpokeCustomizedRoutes(gw.CustomizedRoutes))
 		mustSet(d, "customized_spoke_vpc_routes", "")
-	}
-
-	if len(gw.FilteredSpokeVpcRoutes) != 0 {
-		if filteredSpokeVpcRoutes := getString(d, "filtered_spoke_vpc_routes"); filteredSpokeVpcRoutes != "" {
-			filteredSpokeVpcRoutesArray := strings.Split(filteredSpokeVpcRoutes, ",")
-			if len(goaviatrix.Difference(filteredSpokeVpcRoutesArray, gw.FilteredSpokeVpcRoutes)) == 0 &&
-				len(goaviatrix.Difference(gw.FilteredSpokeVpcRoutes, filteredSpokeVpcRoutesArray)) == 0 {
-				mustSet(d, "filtered_spoke_vpc_routes", filteredSpokeVpcRoutes)
-			} else {
-				mustSet(d, "filtered_spoke_vpc_routes", strings.Join(gw.FilteredSpokeVpcRoutes, ","))
-			}
-		} else {
-			mustSet(d, "filtered_spoke_vpc_routes", strings.Join(gw.FilteredSpokeVpcRoutes, ","))
-		}
-	} else {
-		mustSet(d, "filtered_spoke_vpc_routes", "")
-	}
-
-	if len(gw.IncludeCidrList) != 0 {
-		if includedAdvertisedSpokeRoutes := getString(d, "included_advertised_spoke_routes"); includedAdvertisedSpokeRoutes != "" {
-			advertisedSpokeRoutesArray := strings.Split(includedAdvertisedSpokeRoutes, ",")
-			if len(goaviatrix.Difference(advertisedSpokeRoutesArray, gw.IncludeCidrList)) == 0 &&
-				len(goaviatrix.Difference(gw.IncludeCidrList, advertisedSpokeRoutesArray)) == 0 {
-				mustSet(d, "included_advertised_spoke_routes", includedAdvertisedSpokeRoutes)
-			} else {
-				mustSet(d, "included_advertised_spoke_routes", strings.Join(gw.IncludeCidrList, ","))
-			}
-		} else {
-			mustSet(d, "included_advertised_spoke_routes", strings.Join(gw.AdvertisedSpokeRoutes, ","))
-		}
+		mustSet(d, "customized_spoke_vpc_routes_list", nil)
 	} else {
-		mustSet(d, "included_advertised_spoke_routes", "")
+		mustSet(d, "customized_routes", nil)
+		setSpokeRoutes(d, "customized_spoke_vpc_routes", gw.CustomizedSpokeVpcRoutes)
 	}
+	setSpokeRoutes(d, "filtered_spoke_vpc_routes", gw.FilteredSpokeVpcRoutes)
+	setSpokeRoutes(d, "included_advertised_spoke_routes", gw.IncludeCidrList)
 	mustSet(d, "enable_monitor_gateway_subnets", gw.MonitorSubnetsAction == "enable")
 	if err := d.Set("monitor_exclude_list", gw.MonitorExcludeGWList); err != nil {
 		return fmt.Errorf("setting 'monitor_exclude_list' to state: %w", err)
 	}
 
-	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes) {
-		tags := goaviatrix.KeyValueTags(gw.Tags).IgnoreConfig(ignoreTagsConfig)
-		if err := d.Set("tags", tags); err != nil {
-			log.Printf("[WARN] Error setting tags for (%s): %s", d.Id(), err)
-		}
-	}
+	setGatewayTags(d, client, gw.CloudType, gw.Tags)
+	setGatewayAllTags(d, client, gw.CloudType, gw.GwName)
 
 	var spokeBgpManualAdvertiseCidrs []string
 	if val, ok := d.GetOk("spoke_bgp_manual_advertise_cidrs"); ok {
@@ -1880,6 +3817,7 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 			mustSet(d, "availability_domain", getString(d, "availability_domain"))
 		}
 		mustSet(d, "fault_domain", gw.FaultDomain)
+		mustSet(d, "additional_fault_domains", gw.AdditionalFaultDomains)
 	}
 
 	if gw.EnableSpotInstance {
@@ -1904,7 +3842,110 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 	if err != nil {
 		return fmt.Errorf("failed to get GRO/GSO status of spoke gateway %s: %w", gw.GwName, err)
 	}
-	mustSet(d, "enable_gro_gso", enableGroGso)
+	mustSet(d, "enable_gro_gso", enableGroGso)
+
+	if _, ok := d.GetOk("description"); ok || isImport {
+		description, err := client.GetGatewayDescription(context.Background(), gw.GwName)
+		if err != nil {
+			return fmt.Errorf("failed to get description of spoke gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "description", description)
+	}
+
+	if _, ok := d.GetOk("tcp_mss_clamp"); ok || isImport {
+		tcpMss, err := client.GetSpokeTcpMssClamp(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("failed to get TCP MSS clamp of spoke gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "tcp_mss_clamp", tcpMss)
+	}
+
+	if _, ok := d.GetOk("peering_keepalive_interval"); ok || isImport {
+		keepaliveInterval, err := client.GetSpokePeeringKeepaliveInterval(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("failed to get peering keepalive interval of spoke gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "peering_keepalive_interval", keepaliveInterval)
+	}
+
+	if _, ok := d.GetOk("timezone"); ok || isImport {
+		timezone, err := client.GetSpokeTimezone(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("failed to get time zone of spoke gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "timezone", timezone)
+	}
+
+	_, ikeProposalsSet := d.GetOk("ike_proposals")
+	_, espProposalsSet := d.GetOk("esp_proposals")
+	if ikeProposalsSet || espProposalsSet || isImport {
+		ikeProposals, espProposals, err := client.GetSpokeIpsecProposals(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("failed to get IPsec proposals of spoke gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "ike_proposals", ikeProposals)
+		mustSet(d, "esp_proposals", espProposals)
+	}
+
+	if getBool(d, "enable_dns_forwarding") || isImport {
+		dnsForwarding, dnsForwardingTargets, err := client.GetSpokeDnsForwarding(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("failed to get DNS forwarding of spoke gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "enable_dns_forwarding", dnsForwarding)
+		if dnsForwarding {
+			mustSet(d, "dns_forwarding_targets", dnsForwardingTargets)
+		} else {
+			mustSet(d, "dns_forwarding_targets", nil)
+		}
+	}
+
+	if !goaviatrix.IsCloudType(gw.CloudType, spokeDefaultEgressActionCloudTypes) {
+		mustSet(d, "default_egress_action", "allow")
+	} else {
+		defaultEgressAction, err := client.GetSpokeDefaultEgressAction(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("failed to get default egress action of spoke gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "default_egress_action", defaultEgressAction)
+	}
+
+	if _, ok := d.GetOk("fqdn_gateway_name"); ok || isImport {
+		fqdnGwName, err := client.GetSpokeEgressFqdnGateway(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("failed to get egress FQDN gateway of spoke gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "fqdn_gateway_name", fqdnGwName)
+	}
+
+	if _, ok := d.GetOk("egress_via_connection"); ok || isImport {
+		egressConnName, err := client.GetSpokeEgressViaConnection(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("failed to get egress connection of spoke gateway %s: %w", gw.GwName, err)
+		}
+		mustSet(d, "egress_via_connection", egressConnName)
+	}
+
+	if err := readSpokeHaGateways(d, client, isImport); err != nil {
+		return err
+	}
+	if err := readSpokeTransitGws(d, client); err != nil {
+		return err
+	}
+	if _, ok := d.GetOk("firenet_inspection_exclude_cidrs"); ok || isImport {
+		if err := readSpokeFireNetInspectionExcludeCidrs(d, client, gw); err != nil {
+			return err
+		}
+	}
+	if _, ok := d.GetOk("bgp_communities"); gw.EnableBgp && (ok || isImport) {
+		bgpCommunities, err := client.GetSpokeBgpPrefixCommunities(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+		if err != nil {
+			return fmt.Errorf("failed to get BGP communities of advertised CIDRs for gateway %s: %w", gw.GwName, err)
+		}
+		if err = d.Set("bgp_communities", flattenSpokeBgpCommunities(bgpCommunities)); err != nil {
+			return fmt.Errorf("failed to set bgp_communities: %w", err)
+		}
+	}
 
 	if getBool(d, "manage_ha_gateway") {
 		if gw.HaGw.GwSize == "" {
@@ -1926,6 +3967,7 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 			mustSet(d, "ha_subnet_ipv6_cidr", "")
 			mustSet(d, "ha_zone", "")
 			mustSet(d, "ha_public_ip", "")
+			mustSet(d, "ha_public_ip_v6", "")
 			mustSet(d, "ha_private_mode_subnet_zone", "")
 			mustSet(d, "ha_bgp_lan_ip_list", nil)
 			return nil
@@ -1970,6 +4012,7 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 		mustSet(d, "ha_image_version", gw.HaGw.ImageVersion)
 		mustSet(d, "ha_security_group_id", gw.HaGw.GwSecurityGroupID)
 		mustSet(d, "ha_public_ip", gw.HaGw.PublicIP)
+		mustSet(d, "ha_public_ip_v6", spokeHaPublicIPv6(gw))
 		if gw.HaGw.InsaneMode == "yes" && goaviatrix.IsCloudType(gw.HaGw.CloudType, goaviatrix.AWSRelatedCloudTypes) {
 			mustSet(d, "ha_insane_mode_az", gw.HaGw.GatewayZone)
 		} else {
@@ -1998,9 +4041,9 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	sendComm, acceptComm, err := client.GetGatewayBgpCommunities(gateway.GwName)
+	sendComm, acceptComm, err := client.GetGatewayBgpCommunities(gw.GwName)
 	if err != nil {
-		return fmt.Errorf("failed to get BGP communities for gateway %s: %w", gateway.GwName, err)
+		return fmt.Errorf("failed to get BGP communities for gateway %s: %w", gw.GwName, err)
 	}
 	err = d.Set("bgp_send_communities", sendComm)
 	if err != nil {
@@ -2014,6 +4057,15 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
+func resourceAviatrixSpokeGatewayUpdateContext(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := monitorExcludeListVpcWarnings(d, mustClient(meta))
+	diags = append(diags, insaneModeGwSizeWarnings(d, "gw_size", "ha_gw_size")...)
+	if err := resourceAviatrixSpokeGatewayUpdate(d, meta); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
 func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := mustClient(meta)
 
@@ -2041,6 +4093,25 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if d.HasChanges("ha_mode", "enable_bgp", "ha_subnet", "ha_zone", "enable_active_standby") {
+		if err := validateSpokeHaMode(d); err != nil {
+			return err
+		}
+	}
+
+	var newSpokeHaGwNames []string
+	if d.HasChanges("ha_gateways", "manage_ha_gateway") {
+		oldHaGateways, newHaGateways := d.GetChange("ha_gateways")
+		if err := validateSpokeHaGateways(d, mustSlice(newHaGateways)); err != nil {
+			return err
+		}
+		var err error
+		newSpokeHaGwNames, err = reconcileSpokeHaGateways(d, client, mustSlice(oldHaGateways), mustSlice(newHaGateways))
+		if err != nil {
+			return fmt.Errorf("failed to update 'ha_gateways' during Spoke Gateway update: %w", err)
+		}
+	}
+
 	haGateway := &goaviatrix.Gateway{
 		CloudType: getInt(d, "cloud_type"),
 		GwName:    getString(d, "gw_name") + "-hagw",
@@ -2050,6 +4121,10 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 	log.Printf("[INFO] Updating Aviatrix gateway: %#v", gateway)
 
 	d.Partial(true)
+	bgpCommunities, err := validateSpokeBgpCommunities(d)
+	if err != nil {
+		return err
+	}
 	commSendCurr, commAcceptCurr, err := client.GetGatewayBgpCommunities(gateway.GwName)
 	if err != nil {
 		return fmt.Errorf("failed to get BGP communities for gateway %s: %w", gateway.GwName, err)
@@ -2073,6 +4148,11 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 			}
 		}
 	}
+	if d.HasChange("bgp_communities") {
+		if err := client.SetSpokeBgpPrefixCommunities(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, bgpCommunities); err != nil {
+			return fmt.Errorf("failed to set BGP communities of advertised CIDRs for gateway %s: %w", gateway.GwName, err)
+		}
+	}
 
 	if d.HasChange("private_route_table_config") && goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AzureArmRelatedCloudTypes) {
 		routeTables := getStringSet(d, "private_route_table_config")
@@ -2082,12 +4162,25 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if d.HasChange("gcp_private_routes") {
+		if err := validateSpokeGcpPrivateRoutes(gateway.CloudType, getStringSet(d, "gcp_private_routes")); err != nil {
+			return err
+		}
+		err := client.EditGcpPrivateRoutes(gateway, getStringSet(d, "gcp_private_routes"))
+		if err != nil {
+			return fmt.Errorf("could not edit GCP private routes: %w", err)
+		}
+	}
+
 	if getBool(d, "enable_private_vpc_default_route") && !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
 		return fmt.Errorf("enable_private_vpc_default_route is only valid for AWS (1), AWSGov (256), AWSChina (1024), AWS Top Secret (16384) and AWS Secret (32768)")
 	}
 	if getBool(d, "enable_skip_public_route_table_update") && !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
 		return fmt.Errorf("enable_skip_public_route_update is only valid for AWS (1), AWSGov (256), AWSChina (1024), AWS Top Secret (16384) and AWS Secret (32768)")
 	}
+	if err := validateSpokeCloudRouteTablePropagation(gateway.CloudType, getBool(d, "propagate_to_cloud_route_tables")); err != nil {
+		return err
+	}
 
 	if d.HasChange("ha_zone") {
 		haZone := getString(d, "ha_zone")
@@ -2132,6 +4225,13 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 	if !learnedCidrsApproval && len(approvedLearnedCidrs) != 0 {
 		return fmt.Errorf("'approved_learned_cidrs' must be empty if 'enable_learned_cidrs_approval' is false")
 	}
+	if err := validateSpokeLearnedCidrsApprovalMode(d); err != nil {
+		return err
+	}
+	connectionApprovedCidrs, err := validateSpokeConnectionApprovedCidrs(d)
+	if err != nil {
+		return err
+	}
 
 	if d.HasChange("enable_private_oob") {
 		return fmt.Errorf("updating enable_private_oob is not allowed")
@@ -2157,6 +4257,45 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("global vpc can only be enabled for GCP")
 	}
 
+	// Summary CIDRs are updated before Preserve AS Path so that a newly enabled Preserve AS Path
+	// applies to the summary routes being advertised.
+	if d.HasChange("bgp_summary_cidrs") {
+		bgpSummaryCidrs := getStringSet(d, "bgp_summary_cidrs")
+		if len(bgpSummaryCidrs) != 0 && !getBool(d, "enable_bgp") {
+			return fmt.Errorf("bgp_summary_cidrs is not supported for Non-BGP Spoke Gateways")
+		}
+		err := client.SetSpokeBgpSummaryCidrs(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, bgpSummaryCidrs)
+		if err != nil {
+			return fmt.Errorf("could not update BGP summary CIDRs during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if d.HasChange("bgp_community_outbound_filter") {
+		bgpCommunityOutboundFilter := getStringSet(d, "bgp_community_outbound_filter")
+		if len(bgpCommunityOutboundFilter) != 0 && !getBool(d, "enable_bgp") {
+			return fmt.Errorf("bgp_community_outbound_filter is not supported for Non-BGP Spoke Gateways")
+		}
+		err := client.SetSpokeBgpCommunityOutboundFilter(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, bgpCommunityOutboundFilter)
+		if err != nil {
+			return fmt.Errorf("could not update BGP community outbound filter during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if d.HasChanges("bgp_import_route_map", "bgp_export_route_map") {
+		importRouteMap, exportRouteMap := getString(d, "bgp_import_route_map"), getString(d, "bgp_export_route_map")
+		if err := validateSpokeBgpRouteMaps(client, getBool(d, "enable_bgp"), importRouteMap, exportRouteMap); err != nil {
+			return err
+		}
+		err := client.SetSpokeBgpRouteMaps(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, importRouteMap, exportRouteMap)
+		if err != nil {
+			return fmt.Errorf("could not update BGP route maps during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if err := updateSpokeBgpNextHopSelf(d, client); err != nil {
+		return err
+	}
+
 	if d.HasChange("enable_preserve_as_path") {
 		enableBgp := getBool(d, "enable_bgp")
 		enableSpokePreserveAsPath := getBool(d, "enable_preserve_as_path")
@@ -2424,6 +4563,18 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	// HA gateways created by this update are encrypted with ha_customer_managed_keys. When enable_encrypt_volume
+	// changed, every HA gateway is encrypted below together with the spoke gateway.
+	if getString(d, "ha_customer_managed_keys") != "" && getBool(d, "enable_encrypt_volume") && !d.HasChange("enable_encrypt_volume") {
+		haGwNames := newSpokeHaGwNames
+		if newHaGwEnabled {
+			haGwNames = append([]string{getString(d, "gw_name") + "-hagw"}, haGwNames...)
+		}
+		if err := encryptSpokeHaGatewayVolumes(d, client, haGwNames); err != nil {
+			return err
+		}
+	}
+
 	haSubnet := getString(d, "ha_subnet")
 	haZone := getString(d, "ha_zone")
 	haEnabled := haSubnet != "" || haZone != ""
@@ -2497,32 +4648,31 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	if d.HasChange("single_ip_snat") {
-		enableSNat := getBool(d, "single_ip_snat")
-		gw := &goaviatrix.Gateway{
-			CloudType:   getInt(d, "cloud_type"),
-			GatewayName: getString(d, "gw_name"),
+	if d.HasChanges("single_ip_snat", "snat_mode", "snat_policy") {
+		oldSingleIP, newSingleIP := d.GetChange("single_ip_snat")
+		oldMode, newMode := d.GetChange("snat_mode")
+		policies := getList(d, "snat_policy")
+		if err := validateSpokeSnat(mustString(newMode), policies); err != nil {
+			return err
 		}
-		if enableSNat {
-			err := client.EnableSNat(gw)
-			if err != nil {
-				return fmt.Errorf("failed to enable single_ip' mode SNAT: %w", err)
-			}
-		} else {
-			err := client.DisableSNat(gw)
-			if err != nil {
-				return fmt.Errorf("failed to enable 'single_ip' mode SNAT: %w", err)
-			}
+		err := updateSpokeGatewaySnat(client, getString(d, "gw_name"), getInt(d, "cloud_type"),
+			spokeSnatMode(mustBool(oldSingleIP), mustString(oldMode)), spokeSnatMode(mustBool(newSingleIP), mustString(newMode)),
+			expandSNatPolicy(policies), d.HasChange("snat_policy"))
+		if err != nil {
+			return err
 		}
 	}
 
-	if d.HasChange("enable_vpc_dns_server") && goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes|goaviatrix.AliCloudRelatedCloudTypes) {
+	if d.HasChange("enable_vpc_dns_server") {
+		enableVpcDnsServer := getBool(d, "enable_vpc_dns_server")
+		if err := validateSpokeVpcDnsServer(gateway.CloudType, enableVpcDnsServer); err != nil {
+			return err
+		}
 		gw := &goaviatrix.Gateway{
 			CloudType: getInt(d, "cloud_type"),
 			GwName:    getString(d, "gw_name"),
 		}
 
-		enableVpcDnsServer := getBool(d, "enable_vpc_dns_server")
 		if enableVpcDnsServer {
 			err := client.EnableVpcDNSServer(gw)
 			if err != nil {
@@ -2534,9 +4684,19 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 				return fmt.Errorf("failed to disable VPC DNS Server: %w", err)
 			}
 		}
+	}
 
-	} else if d.HasChange("enable_vpc_dns_server") {
-		return fmt.Errorf("'enable_vpc_dns_server' only supported by AWS (1), Azure (8), AzureGov (32), AWSGov (256), AWSChina (1024), AzureChina (2048), Alibaba Cloud (8192), AWS Top Secret (16384) and AWS Secret (32768)")
+	// Gateway based approval can only be enabled once the gateway is in gateway mode, and the gateway can only
+	// switch to connection mode once gateway based approval is disabled.
+	approvalMode := getString(d, "learned_cidrs_approval_mode")
+	if d.HasChange("learned_cidrs_approval_mode") && approvalMode == "gateway" {
+		gw := &goaviatrix.SpokeVpc{
+			GwName: getString(d, "gw_name"),
+		}
+		err := client.SetSpokeLearnedCIDRsApprovalMode(gw, approvalMode)
+		if err != nil {
+			return fmt.Errorf("could not set learned CIDRs approval mode to %q: %w", approvalMode, err)
+		}
 	}
 
 	if d.HasChange("enable_learned_cidrs_approval") {
@@ -2558,6 +4718,16 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if d.HasChange("learned_cidrs_approval_mode") && approvalMode == "connection" {
+		gw := &goaviatrix.SpokeVpc{
+			GwName: getString(d, "gw_name"),
+		}
+		err := client.SetSpokeLearnedCIDRsApprovalMode(gw, approvalMode)
+		if err != nil {
+			return fmt.Errorf("could not set learned CIDRs approval mode to %q: %w", approvalMode, err)
+		}
+	}
+
 	if learnedCidrsApproval && d.HasChange("approved_learned_cidrs") {
 		gw := &goaviatrix.SpokeVpc{
 			GwName:               getString(d, "gw_name"),
@@ -2570,6 +4740,33 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if d.HasChange("connection_approved_cidrs") {
+		o, _ := d.GetChange("connection_approved_cidrs")
+		oldApprovedCidrs, err := expandSpokeConnectionApprovedCidrs(mustSchemaSet(o).List())
+		if err != nil {
+			return err
+		}
+		gwName := getString(d, "gw_name")
+		for connName := range oldApprovedCidrs {
+			if _, ok := connectionApprovedCidrs[connName]; ok {
+				continue
+			}
+			err := client.UpdateSpokeConnectionPendingApprovedCidrs(gwName, connName, nil)
+			if err != nil {
+				return fmt.Errorf("could not clear approved CIDRs of connection %s: %w", connName, err)
+			}
+		}
+		for connName, cidrs := range connectionApprovedCidrs {
+			if slices.Equal(oldApprovedCidrs[connName], cidrs) {
+				continue
+			}
+			err := client.UpdateSpokeConnectionPendingApprovedCidrs(gwName, connName, cidrs)
+			if err != nil {
+				return fmt.Errorf("could not update approved CIDRs of connection %s: %w", connName, err)
+			}
+		}
+	}
+
 	if d.HasChange("enable_encrypt_volume") {
 		if getBool(d, "enable_encrypt_volume") {
 			if !goaviatrix.IsCloudType(gateway.CloudType, goaviatrix.AWSRelatedCloudTypes) {
@@ -2587,28 +4784,25 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 			haSubnet := getString(d, "ha_subnet")
 			haZone := getString(d, "ha_zone")
 			haEnabled := haSubnet != "" || haZone != ""
+			haGwNames := spokeHaGatewayNames(getList(d, "ha_gateways"))
 			if haEnabled && manageHaGw {
-				gwHAEncVolume := &goaviatrix.Gateway{
-					GwName:              getString(d, "gw_name") + "-hagw",
-					CustomerManagedKeys: getString(d, "customer_managed_keys"),
-				}
-				err := client.EnableEncryptVolume(gwHAEncVolume)
-				if err != nil {
-					return fmt.Errorf("failed to enable encrypt gateway volume for %s due to %w", gwHAEncVolume.GwName, err)
-				}
+				haGwNames = append([]string{getString(d, "gw_name") + "-hagw"}, haGwNames...)
+			}
+			if err := encryptSpokeHaGatewayVolumes(d, client, haGwNames); err != nil {
+				return err
 			}
 		} else {
 			return fmt.Errorf("can't disable Encrypt Volume for gateway: %s", gateway.GwName)
 		}
 	} else if d.HasChange("customer_managed_keys") {
 		return fmt.Errorf("updating customer_managed_keys only is not allowed")
+	} else if d.HasChange("ha_customer_managed_keys") && !newHaGwEnabled && len(newSpokeHaGwNames) == 0 {
+		return fmt.Errorf("updating ha_customer_managed_keys only is not allowed")
 	}
 
-	if d.HasChange("customized_spoke_vpc_routes") {
-		o, n := d.GetChange("customized_spoke_vpc_routes")
-		oldRouteList := strings.Split(mustString(o), ",")
-		newRouteList := strings.Split(mustString(n), ",")
-		if len(goaviatrix.Difference(oldRouteList, newRouteList)) != 0 || len(goaviatrix.Difference(newRouteList, oldRouteList)) != 0 {
+	if d.HasChanges("customized_spoke_vpc_routes", "customized_spoke_vpc_routes_list") {
+		oldRouteList, newRouteList := getSpokeRoutesChange(d, "customized_spoke_vpc_routes")
+		if !goaviatrix.Equivalent(oldRouteList, newRouteList) {
 			transitGateway := &goaviatrix.Gateway{
 				GwName:                   getString(d, "gw_name"),
 				CustomizedSpokeVpcRoutes: newRouteList,
@@ -2621,11 +4815,28 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	if d.HasChange("filtered_spoke_vpc_routes") {
-		o, n := d.GetChange("filtered_spoke_vpc_routes")
-		oldRouteList := strings.Split(mustString(o), ",")
-		newRouteList := strings.Split(mustString(n), ",")
-		if len(goaviatrix.Difference(oldRouteList, newRouteList)) != 0 || len(goaviatrix.Difference(newRouteList, oldRouteList)) != 0 {
+	if d.HasChange("customized_routes") {
+		customizedRoutes, err := expandSpokeCustomizedRoutes(d)
+		if err != nil {
+			return err
+		}
+		// Removing the block while switching to customized_spoke_vpc_routes must not clear the routes set above.
+		if len(customizedRoutes) != 0 || len(getSpokeRoutes(d, "customized_spoke_vpc_routes")) == 0 {
+			transitGateway := &goaviatrix.Gateway{
+				GwName:           getString(d, "gw_name"),
+				CustomizedRoutes: customizedRoutes,
+			}
+			log.Printf("[INFO] Customizing routes with next hops of spoke gateway: %s ", transitGateway.GwName)
+			err = client.EditGatewayCustomRoutes(transitGateway)
+			if err != nil {
+				return fmt.Errorf("failed to customize spoke vpc routes of spoke gateway: %s due to: %w", transitGateway.GwName, err)
+			}
+		}
+	}
+
+	if d.HasChanges("filtered_spoke_vpc_routes", "filtered_spoke_vpc_routes_list") {
+		oldRouteList, newRouteList := getSpokeRoutesChange(d, "filtered_spoke_vpc_routes")
+		if !goaviatrix.Equivalent(oldRouteList, newRouteList) {
 			transitGateway := &goaviatrix.Gateway{
 				GwName:                 getString(d, "gw_name"),
 				FilteredSpokeVpcRoutes: newRouteList,
@@ -2638,11 +4849,9 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	if d.HasChange("included_advertised_spoke_routes") {
-		o, n := d.GetChange("included_advertised_spoke_routes")
-		oldRouteList := strings.Split(mustString(o), ",")
-		newRouteList := strings.Split(mustString(n), ",")
-		if len(goaviatrix.Difference(oldRouteList, newRouteList)) != 0 || len(goaviatrix.Difference(newRouteList, oldRouteList)) != 0 {
+	if d.HasChanges("included_advertised_spoke_routes", "included_advertised_spoke_routes_list") {
+		oldRouteList, newRouteList := getSpokeRoutesChange(d, "included_advertised_spoke_routes")
+		if !goaviatrix.Equivalent(oldRouteList, newRouteList) {
 			transitGateway := &goaviatrix.Gateway{
 				GwName:                getString(d, "gw_name"),
 				AdvertisedSpokeRoutes: newRouteList,
@@ -2686,17 +4895,33 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	if d.HasChange("enable_jumbo_frame") {
-		if getBool(d, "enable_jumbo_frame") {
-			err := client.EnableJumboFrame(gateway)
-			if err != nil {
-				return fmt.Errorf("could not enable jumbo frame for spoke gateway when updating: %w", err)
-			}
-		} else {
-			err := client.DisableJumboFrame(gateway)
-			if err != nil {
-				return fmt.Errorf("could not disable jumbo frame for spoke gateway when updating: %w", err)
-			}
+	enableJumboFrame := getBool(d, "enable_jumbo_frame")
+	if d.HasChange("enable_jumbo_frame") || (newHaGwEnabled && !enableJumboFrame) {
+		// A newly created HA gateway comes up with jumbo frames enabled, only it needs updating if the setting did not change
+		var gwName, haGwName string
+		if d.HasChange("enable_jumbo_frame") {
+			gwName = gateway.GwName
+		}
+		if haEnabled && manageHaGw {
+			haGwName = gateway.GwName + "-hagw"
+		}
+		err := setSpokeGatewayJumboFrame(client, enableJumboFrame, gwName, haGwName)
+		if err != nil {
+			return fmt.Errorf("could not update jumbo frame for spoke gateway when updating: %w", err)
+		}
+	}
+
+	if d.HasChange("maintenance_window") {
+		err := client.SetGatewayMaintenanceWindow(gateway.GwName, expandSpokeMaintenanceWindow(d))
+		if err != nil {
+			return fmt.Errorf("could not update maintenance window for spoke gateway when updating: %w", err)
+		}
+	}
+
+	if d.HasChange("snmp") {
+		err := client.SetGatewaySnmpConfig(gateway.GwName, expandSpokeSnmp(d))
+		if err != nil {
+			return fmt.Errorf("could not update SNMP for spoke gateway when updating: %w", err)
 		}
 	}
 
@@ -2714,6 +4939,91 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if d.HasChange("description") {
+		err := client.SetGatewayDescription(context.Background(), gateway.GwName, getString(d, "description"))
+		if err != nil {
+			return fmt.Errorf("could not update description during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if d.HasChange("tcp_mss_clamp") {
+		err := client.SetSpokeTcpMssClamp(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, getInt(d, "tcp_mss_clamp"))
+		if err != nil {
+			return fmt.Errorf("could not update TCP MSS clamp during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if d.HasChange("peering_keepalive_interval") {
+		err := client.SetSpokePeeringKeepaliveInterval(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, getInt(d, "peering_keepalive_interval"))
+		if err != nil {
+			return fmt.Errorf("could not update peering keepalive interval during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if d.HasChange("timezone") {
+		err := client.SetSpokeTimezone(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, getString(d, "timezone"))
+		if err != nil {
+			return fmt.Errorf("could not update time zone during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if d.HasChanges("ike_proposals", "esp_proposals") {
+		err := client.SetSpokeIpsecProposals(&goaviatrix.SpokeVpc{GwName: gateway.GwName},
+			getStringList(d, "ike_proposals"), getStringList(d, "esp_proposals"))
+		if err != nil {
+			return fmt.Errorf("could not update IPsec proposals during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if d.HasChanges("enable_dns_forwarding", "dns_forwarding_targets") {
+		enableDnsForwarding := getBool(d, "enable_dns_forwarding")
+		dnsForwardingTargets := getStringList(d, "dns_forwarding_targets")
+		if err := validateSpokeDnsForwarding(enableDnsForwarding, dnsForwardingTargets); err != nil {
+			return err
+		}
+		err := client.SetSpokeDnsForwarding(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, enableDnsForwarding, dnsForwardingTargets)
+		if err != nil {
+			return fmt.Errorf("could not update DNS forwarding during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if d.HasChange("default_egress_action") {
+		defaultEgressAction := getString(d, "default_egress_action")
+		if err := validateSpokeDefaultEgressAction(gateway.CloudType, defaultEgressAction); err != nil {
+			return err
+		}
+		err := client.SetSpokeDefaultEgressAction(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, defaultEgressAction)
+		if err != nil {
+			return fmt.Errorf("could not update default egress action during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if d.HasChange("fqdn_gateway_name") {
+		fqdnGwName := getString(d, "fqdn_gateway_name")
+		if fqdnGwName != "" {
+			if err := validateFqdnGatewayExists(client, fqdnGwName); err != nil {
+				return err
+			}
+		}
+		err := client.SetSpokeEgressFqdnGateway(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, fqdnGwName)
+		if err != nil {
+			return fmt.Errorf("could not update egress FQDN gateway during Spoke Gateway update: %w", err)
+		}
+	}
+
+	if d.HasChange("egress_via_connection") {
+		connName := getString(d, "egress_via_connection")
+		if connName != "" {
+			if err := validateSpokeEgressConnectionExists(client, getString(d, "vpc_id"), connName); err != nil {
+				return err
+			}
+		}
+		err := client.SetSpokeEgressViaConnection(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, connName)
+		if err != nil {
+			return fmt.Errorf("could not update egress connection during Spoke Gateway update: %w", err)
+		}
+	}
+
 	if d.HasChange("enable_private_vpc_default_route") {
 		if getBool(d, "enable_private_vpc_default_route") {
 			err := client.EnablePrivateVpcDefaultRoute(gateway)
@@ -2742,6 +5052,14 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if err := updateSpokeCloudRouteTablePropagation(d, client, gateway); err != nil {
+		return err
+	}
+
+	if err := updateSpokeGatewayAdminSSH(d, client, newHaGwEnabled); err != nil {
+		return err
+	}
+
 	if d.HasChange("enable_auto_advertise_s2c_cidrs") {
 		if getBool(d, "enable_auto_advertise_s2c_cidrs") {
 			err := client.EnableAutoAdvertiseS2CCidrs(gateway)
@@ -2824,6 +5142,10 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if err := updateSpokeActiveStandbyFailoverDelay(d, client); err != nil {
+		return err
+	}
+
 	if d.HasChanges("local_as_number", "prepend_as_path") {
 		var prependASPath []string
 		for _, v := range getList(d, "prepend_as_path") {
@@ -2887,6 +5209,21 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if err := updateSpokeHaMode(d, client); err != nil {
+		return err
+	}
+
+	if d.HasChange("bgp_neighbor_passive") {
+		passive := getBool(d, "bgp_neighbor_passive")
+		if passive && !getBool(d, "enable_bgp") {
+			return fmt.Errorf("bgp_neighbor_passive is not supported for Non-BGP Spoke Gateways")
+		}
+		err := client.SetSpokeBgpNeighborPassive(&goaviatrix.SpokeVpc{GwName: gateway.GwName}, passive)
+		if err != nil {
+			return fmt.Errorf("could not update BGP neighbor passive mode during Spoke Gateway update: %w", err)
+		}
+	}
+
 	if d.HasChange("disable_route_propagation") {
 		disableRoutePropagation := getBool(d, "disable_route_propagation")
 		enableBgp := getBool(d, "enable_bgp")
@@ -2921,15 +5258,16 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		if err != nil {
 			return fmt.Errorf("could not modify rx queue size for spoke: %s during gateway update: %w", gw.GatewayName, err)
 		}
-		if haSubnet != "" || haZone != "" {
-			haGwRxQueueSize := &goaviatrix.Gateway{
-				GwName:      getString(d, "gw_name") + "-hagw",
-				RxQueueSize: getString(d, "rx_queue_size"),
-			}
-			err := client.SetRxQueueSize(haGwRxQueueSize)
-			if err != nil {
-				return fmt.Errorf("could not modify rx queue size for spoke ha: %s during gateway update: %w", haGwRxQueueSize.GwName, err)
-			}
+		err = setSpokeHaRxQueueSize(client, gateway.GwName, getString(d, "rx_queue_size"), getBool(d, "manage_ha_gateway"), haEnabled)
+		if err != nil {
+			return fmt.Errorf("could not modify rx queue size for spoke ha during gateway update: %w", err)
+		}
+	}
+
+	if d.HasChange("spot_price") {
+		err := client.UpdateSpotPrice(gateway.GwName, getString(d, "spot_price"))
+		if err != nil {
+			return fmt.Errorf("could not update spot price for spoke: %s during gateway update: %w", gateway.GwName, err)
 		}
 	}
 
@@ -2972,8 +5310,45 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if d.HasChanges("attach_to_transit_gws", "transit_route_table") {
+		oldTransitGws, newTransitGws := d.GetChange("attach_to_transit_gws")
+		oldTransitGwNames := goaviatrix.ExpandStringList(mustSlice(oldTransitGws))
+		newTransitGwNames := goaviatrix.ExpandStringList(mustSlice(newTransitGws))
+		if err := validateSpokeTransitGws(newTransitGwNames); err != nil {
+			return err
+		}
+		// A changed route table applies to every attachment, otherwise only new attachments need the association
+		routeTable := getString(d, "transit_route_table")
+		routeTableTransitGwNames := newTransitGwNames
+		if !d.HasChange("transit_route_table") {
+			routeTableTransitGwNames = goaviatrix.Difference(newTransitGwNames, oldTransitGwNames)
+		}
+		if err := validateSpokeTransitRouteTable(client, routeTableTransitGwNames, routeTable); err != nil {
+			return fmt.Errorf("failed to update 'transit_route_table' during Spoke Gateway update: %w", err)
+		}
+
+		err := reconcileSpokeTransitGws(client, gateway.GwName, oldTransitGwNames, newTransitGwNames)
+		if err != nil {
+			return fmt.Errorf("failed to update 'attach_to_transit_gws' during Spoke Gateway update: %w", err)
+		}
+
+		if d.HasChange("transit_route_table") || routeTable != "" {
+			err := applySpokeTransitRouteTable(client, gateway.GwName, routeTableTransitGwNames, routeTable)
+			if err != nil {
+				return fmt.Errorf("failed to update 'transit_route_table' during Spoke Gateway update: %w", err)
+			}
+		}
+	}
+
+	if d.HasChange("firenet_inspection_exclude_cidrs") {
+		if err := applySpokeFireNetInspectionExcludeCidrs(d, client); err != nil {
+			return err
+		}
+	}
+
 	d.Partial(false)
 	d.SetId(gateway.GwName)
+	client.ForgetCachedGateway(gateway.GwName)
 	return resourceAviatrixSpokeGatewayRead(d, meta)
 }
 
@@ -2987,25 +5362,140 @@ func resourceAviatrixSpokeGatewayDelete(d *schema.ResourceData, meta interface{}
 
 	log.Printf("[INFO] Deleting Aviatrix Spoke Gateway: %#v", gateway)
 
-	// If HA is enabled, delete HA GW first.
-	if getBool(d, "manage_ha_gateway") {
-		haSubnet := getString(d, "ha_subnet")
-		haZone := getString(d, "ha_zone")
-		if haSubnet != "" || haZone != "" {
-			// Delete HA Gw too
-			gateway.GwName += "-hagw"
-			err := client.DeleteGateway(gateway)
-			if err != nil {
-				return fmt.Errorf("failed to delete Aviatrix Spoke HA gateway: %w", err)
-			}
+	if len(getStringList(d, "attach_to_transit_gws")) != 0 {
+		err := client.SpokeLeaveAllTransit(&goaviatrix.SpokeVpc{GwName: gateway.GwName})
+		if err != nil {
+			return fmt.Errorf("failed to detach Aviatrix Spoke Gateway from transit gateways: %w", err)
 		}
 	}
-	gateway.GwName = getString(d, "gw_name")
 
-	err := client.DeleteGateway(gateway)
-	if err != nil {
+	if err := deleteSpokeHaGateways(d, client, getList(d, "ha_gateways")); err != nil {
+		return err
+	}
+
+	deleteGateway := func(gw *goaviatrix.Gateway) error {
+		return client.RetryableDo(goaviatrix.IsGatewayDownError, func() error {
+			return client.DeleteGateway(gw)
+		})
+	}
+
+	haEnabled := getBool(d, "manage_ha_gateway") && (getString(d, "ha_subnet") != "" || getString(d, "ha_zone") != "")
+	haGateway := &goaviatrix.Gateway{
+		CloudType: gateway.CloudType,
+		GwName:    gateway.GwName + "-hagw",
+	}
+	deletePrimaryFirst := getBool(d, "delete_primary_first")
+
+	// If HA is enabled, delete HA GW first unless delete_primary_first is set.
+	if haEnabled && !deletePrimaryFirst {
+		if err := deleteGateway(haGateway); err != nil {
+			return fmt.Errorf("failed to delete Aviatrix Spoke HA gateway: %w", err)
+		}
+	}
+
+	if err := deleteGateway(gateway); err != nil {
 		return fmt.Errorf("failed to delete Aviatrix Spoke Gateway: %w", err)
 	}
 
+	if haEnabled && deletePrimaryFirst {
+		if err := deleteGateway(haGateway); err != nil {
+			return fmt.Errorf("failed to delete Aviatrix Spoke HA gateway: %w", err)
+		}
+	}
+
 	return nil
 }
+
+// getSpokeRoutes returns the CIDRs of the given comma separated route attribute, or of the set attribute
+// replacing it, e.g. customized_spoke_vpc_routes and customized_spoke_vpc_routes_list.
+func getSpokeRoutes(d *schema.ResourceData, attr string) []string {
+	return spokeRoutes(getString(d, attr), getSet(d, attr+"_list"))
+}
+
+// getSpokeRoutesChange returns the old and new CIDRs of the given comma separated route attribute, or of
+// the set attribute replacing it.
+func getSpokeRoutesChange(d *schema.ResourceData, attr string) ([]string, []string) {
+	o, n := d.GetChange(attr)
+	oSet, nSet := d.GetChange(attr + "_list")
+	return spokeRoutes(mustString(o), mustSchemaSet(oSet)), spokeRoutes(mustString(n), mustSchemaSet(nSet))
+}
+
+func spokeRoutes(routes string, routeSet *schema.Set) []string {
+	if routes != "" {
+		return splitSpokeRoutes(routes)
+	}
+	if routeSet.Len() == 0 {
+		return nil
+	}
+	return expandStringSet(routeSet)
+}
+
+// splitSpokeRoutes splits a comma separated route attribute into its CIDRs, trimming the spaces around
+// each of them so that "10.0.0.0/16, 10.1.0.0/16" is sent to the controller the same way as without spaces.
+func splitSpokeRoutes(routes string) []string {
+	cidrs := strings.Split(routes, ",")
+	for i := range cidrs {
+		cidrs[i] = strings.TrimSpace(cidrs[i])
+	}
+	return cidrs
+}
+
+// setSpokeRoutes sets the CIDRs read from the controller into the given comma separated route attribute if
+// it is in use, keeping its order when it holds the same CIDRs. Otherwise they are set into the set attribute
+// replacing it, which is also the one populated on import.
+func setSpokeRoutes(d *schema.ResourceData, attr string, routes []string) {
+	if current := getString(d, attr); current != "" {
+		if len(routes) != 0 && goaviatrix.Equivalent(splitSpokeRoutes(current), routes) {
+			mustSet(d, attr, current)
+		} else {
+			mustSet(d, attr, strings.Join(routes, ","))
+		}
+		mustSet(d, attr+"_list", nil)
+		return
+	}
+	mustSet(d, attr, "")
+	mustSet(d, attr+"_list", routes)
+}
+
+// expandSpokeCustomizedRoutes returns the customized_routes of the spoke gateway sorted by CIDR.
+// Each CIDR may only be customized once.
+func expandSpokeCustomizedRoutes(d *schema.ResourceData) ([]goaviatrix.CustomizedRoute, error) {
+	var routes []goaviatrix.CustomizedRoute
+	cidrs := make(map[string]bool)
+	for _, v := range getSet(d, "customized_routes").List() {
+		route := mustMap(v)
+		cidr := mustString(route["cidr"])
+		if cidrs[cidr] {
+			return nil, fmt.Errorf("CIDR %s is listed more than once in 'customized_routes'", cidr)
+		}
+		cidrs[cidr] = true
+		routes = append(routes, goaviatrix.CustomizedRoute{
+			Cidr:    cidr,
+			NextHop: mustString(route["next_hop"]),
+		})
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Cidr < routes[j].Cidr
+	})
+	return routes, nil
+}
+
+func flattenSpokeCustomizedRoutes(routes []goaviatrix.CustomizedRoute) []interface{} {
+	var customizedRoutes []interface{}
+	for _, route := range routes {
+		customizedRoutes = append(customizedRoutes, map[string]interface{}{
+			"cidr":     route.Cidr,
+			"next_hop": route.NextHop,
+		})
+	}
+	return customizedRoutes
+}
+
+func hasSpokeCustomizedRouteNextHops(routes []goaviatrix.CustomizedRoute) bool {
+	for _, route := range routes {
+		if route.NextHop != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,336 @@
+package aviatrix
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+// spokeHaGatewayReplaceKeys are the ha_gateways attributes that cannot be changed on an existing HA gateway.
+// Changing any of them replaces that HA gateway.
+var spokeHaGatewayReplaceKeys = []string{"subnet", "zone", "insane_mode_az", "eip", "availability_domain", "fault_domain"}
+
+// validateSpokeHaGateways checks that every ha_gateways block has a unique gw_name, that the blocks are
+// sorted by gw_name, which is the order they are read back in, and that each block is valid for the cloud
+// type of the spoke gateway.
+func validateSpokeHaGateways(d *schema.ResourceData, haGateways []interface{}) error {
+	if len(haGateways) == 0 {
+		return nil
+	}
+	if getBool(d, "manage_ha_gateway") {
+		return fmt.Errorf("'ha_gateways' can only be used when 'manage_ha_gateway' is set to false")
+	}
+	var prevName string
+	for i, v := range haGateways {
+		name := mustString(mustMap(v)["gw_name"])
+		if i > 0 {
+			if name == prevName {
+				return fmt.Errorf("HA gateway %q is listed more than once in 'ha_gateways'", name)
+			}
+			if name < prevName {
+				return fmt.Errorf("'ha_gateways' must be sorted by gw_name: %q is listed after %q", name, prevName)
+			}
+		}
+		prevName = name
+		if err := validateSpokeHaGateway(d, mustMap(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSpokeHaGateway checks an ha_gateways block against the cloud type and insane_mode of the spoke
+// gateway in d.
+func validateSpokeHaGateway(d *schema.ResourceData, ha map[string]interface{}) error {
+	cloudType := getInt(d, "cloud_type")
+	name := mustString(ha["gw_name"])
+	zone := mustString(ha["zone"])
+	availabilityDomain := mustString(ha["availability_domain"])
+	faultDomain := mustString(ha["fault_domain"])
+	insaneModeAz := mustString(ha["insane_mode_az"])
+
+	if goaviatrix.IsCloudType(cloudType, goaviatrix.AWSRelatedCloudTypes) {
+		if zone != "" || availabilityDomain != "" || faultDomain != "" {
+			return fmt.Errorf("HA gateway %s: 'zone', 'availability_domain' and 'fault_domain' must be empty for AWS related cloud types", name)
+		}
+	} else if goaviatrix.IsCloudType(cloudType, goaviatrix.AzureArmRelatedCloudTypes) {
+		if availabilityDomain != "" || faultDomain != "" {
+			return fmt.Errorf("HA gateway %s: 'availability_domain' and 'fault_domain' must be empty for Azure related cloud types", name)
+		}
+		if mustString(ha["eip"]) != "" {
+			return fmt.Errorf("HA gateway %s: 'eip' is not supported in 'ha_gateways' for Azure related cloud types, use 'aviatrix_spoke_ha_gateway' instead", name)
+		}
+		if zone != "" {
+			if _, errs := validateAzureAZ(zone, "zone"); len(errs) != 0 {
+				return fmt.Errorf("HA gateway %s: %w", name, errs[0])
+			}
+		}
+	} else if goaviatrix.IsCloudType(cloudType, goaviatrix.GCPRelatedCloudTypes) {
+		if zone == "" {
+			return fmt.Errorf("HA gateway %s: 'zone' is required for GCP related cloud types", name)
+		}
+		if availabilityDomain != "" || faultDomain != "" {
+			return fmt.Errorf("HA gateway %s: 'availability_domain' and 'fault_domain' must be empty for GCP related cloud types", name)
+		}
+	} else if goaviatrix.IsCloudType(cloudType, goaviatrix.OCIRelatedCloudTypes) {
+		if availabilityDomain == "" || faultDomain == "" {
+			return fmt.Errorf("HA gateway %s: 'availability_domain' and 'fault_domain' are required for OCI related cloud types", name)
+		}
+		if zone != "" {
+			return fmt.Errorf("HA gateway %s: 'zone' must be empty for OCI related cloud types", name)
+		}
+	}
+
+	insaneModeAws := getBool(d, "insane_mode") && goaviatrix.IsCloudType(cloudType, goaviatrix.AWSRelatedCloudTypes)
+	if insaneModeAws && insaneModeAz == "" {
+		return fmt.Errorf("HA gateway %s: 'insane_mode_az' is required if insane_mode is enabled for AWS (1), AWSGov (256), AWS China (1024), AWS Top Secret (16384) or AWS Secret (32768)", name)
+	}
+	if !insaneModeAws && insaneModeAz != "" {
+		return fmt.Errorf("HA gateway %s: 'insane_mode_az' is only valid if insane_mode is enabled for AWS related cloud types", name)
+	}
+	return nil
+}
+
+// newSpokeHaGateway builds the request creating the HA gateway described by an ha_gateways block of the spoke
+// gateway in d. The block must have passed validateSpokeHaGateway.
+func newSpokeHaGateway(d *schema.ResourceData, ha map[string]interface{}) *goaviatrix.SpokeHaGateway {
+	cloudType := getInt(d, "cloud_type")
+	haGw := &goaviatrix.SpokeHaGateway{
+		PrimaryGwName:      getString(d, "gw_name"),
+		GwName:             mustString(ha["gw_name"]),
+		GwSize:             mustString(ha["gw_size"]),
+		Subnet:             mustString(ha["subnet"]),
+		Zone:               mustString(ha["zone"]),
+		AvailabilityDomain: mustString(ha["availability_domain"]),
+		FaultDomain:        mustString(ha["fault_domain"]),
+		Eip:                mustString(ha["eip"]),
+		InsaneMode:         "no",
+	}
+
+	if goaviatrix.IsCloudType(cloudType, goaviatrix.AzureArmRelatedCloudTypes) && haGw.Zone != "" {
+		haGw.Subnet = fmt.Sprintf("%s~~%s~~", haGw.Subnet, haGw.Zone)
+	}
+	if getBool(d, "insane_mode") {
+		haGw.InsaneMode = "yes"
+		if goaviatrix.IsCloudType(cloudType, goaviatrix.AWSRelatedCloudTypes) {
+			haGw.Subnet = haGw.Subnet + subnetSeparator + mustString(ha["insane_mode_az"])
+		}
+	}
+
+	return haGw
+}
+
+// spokeHaGatewayNames returns the gw_name of each of the given ha_gateways blocks.
+func spokeHaGatewayNames(haGateways []interface{}) []string {
+	var names []string
+	for _, v := range haGateways {
+		names = append(names, mustString(mustMap(v)["gw_name"]))
+	}
+	return names
+}
+
+// createSpokeHaGateways creates the HA gateways described by the given ha_gateways blocks.
+func createSpokeHaGateways(d *schema.ResourceData, client *goaviatrix.Client, haGateways []interface{}) error {
+	for _, v := range haGateways {
+		haGw := newSpokeHaGateway(d, mustMap(v))
+		log.Printf("[INFO] Creating Spoke HA Gateway %s for %s", haGw.GwName, haGw.PrimaryGwName)
+		if _, err := client.CreateSpokeHaGw(haGw); err != nil {
+			return fmt.Errorf("failed to create Spoke HA Gateway %s: %w", haGw.GwName, err)
+		}
+	}
+	return nil
+}
+
+// deleteSpokeHaGateways deletes the HA gateways described by the given ha_gateways blocks.
+func deleteSpokeHaGateways(d *schema.ResourceData, client *goaviatrix.Client, haGateways []interface{}) error {
+	for _, v := range haGateways {
+		haGw := &goaviatrix.Gateway{
+			CloudType: getInt(d, "cloud_type"),
+			GwName:    mustString(mustMap(v)["gw_name"]),
+		}
+		log.Printf("[INFO] Deleting Spoke HA Gateway %s", haGw.GwName)
+		if err := client.DeleteGateway(haGw); err != nil {
+			return fmt.Errorf("failed to delete Spoke HA Gateway %s: %w", haGw.GwName, err)
+		}
+	}
+	return nil
+}
+
+// reconcileSpokeHaGateways brings the HA gateways of the spoke gateway from oldHaGateways to newHaGateways.
+// HA gateways are matched by gw_name: removed ones are deleted, added ones are created, ones with a changed
+// replace key are recreated and ones with a changed gw_size are resized. HA gateways that did not change are
+// left untouched. It returns the names of the HA gateways it created.
+func reconcileSpokeHaGateways(d *schema.ResourceData, client *goaviatrix.Client, oldHaGateways, newHaGateways []interface{}) ([]string, error) {
+	oldByName := make(map[string]map[string]interface{}, len(oldHaGateways))
+	for _, v := range oldHaGateways {
+		ha := mustMap(v)
+		oldByName[mustString(ha["gw_name"])] = ha
+	}
+	newNames := make(map[string]bool, len(newHaGateways))
+	for _, v := range newHaGateways {
+		newNames[mustString(mustMap(v)["gw_name"])] = true
+	}
+
+	var toDelete, toCreate []interface{}
+	var toResize []map[string]interface{}
+	for _, v := range oldHaGateways {
+		if !newNames[mustString(mustMap(v)["gw_name"])] {
+			toDelete = append(toDelete, v)
+		}
+	}
+	for _, v := range newHaGateways {
+		ha := mustMap(v)
+		old, ok := oldByName[mustString(ha["gw_name"])]
+		if !ok {
+			toCreate = append(toCreate, v)
+			continue
+		}
+		replace := false
+		for _, k := range spokeHaGatewayReplaceKeys {
+			if mustString(old[k]) != mustString(ha[k]) {
+				replace = true
+				break
+			}
+		}
+		if replace {
+			toDelete = append(toDelete, old)
+			toCreate = append(toCreate, v)
+		} else if mustString(old["gw_size"]) != mustString(ha["gw_size"]) {
+			toResize = append(toResize, ha)
+		}
+	}
+
+	if err := deleteSpokeHaGateways(d, client, toDelete); err != nil {
+		return nil, err
+	}
+	if err := createSpokeHaGateways(d, client, toCreate); err != nil {
+		return nil, err
+	}
+	for _, ha := range toResize {
+		haGw := &goaviatrix.Gateway{
+			CloudType: getInt(d, "cloud_type"),
+			GwName:    mustString(ha["gw_name"]),
+			VpcSize:   mustString(ha["gw_size"]),
+		}
+		log.Printf("[INFO] Resizing Spoke HA Gateway %s to: %s", haGw.GwName, haGw.VpcSize)
+		if err := client.UpdateGateway(haGw); err != nil {
+			return nil, fmt.Errorf("failed to update Spoke HA Gateway %s size: %w", haGw.GwName, err)
+		}
+	}
+	return spokeHaGatewayNames(toCreate), nil
+}
+
+// flattenSpokeHaGateway returns the ha_gateways block of the HA gateway gw. Optional attributes the controller
+// does not report back reliably keep the value from prior.
+func flattenSpokeHaGateway(gw *goaviatrix.Gateway, prior map[string]interface{}) map[string]interface{} {
+	ha := map[string]interface{}{
+		"gw_name":             gw.GwName,
+		"subnet":              gw.VpcNet,
+		"gw_size":             gw.GwSize,
+		"zone":                "",
+		"insane_mode_az":      "",
+		"eip":                 "",
+		"availability_domain": "",
+		"fault_domain":        "",
+		"cloud_instance_id":   gw.CloudnGatewayInstID,
+		"private_ip":          gw.PrivateIP,
+		"public_ip":           gw.PublicIP,
+	}
+	if mustString(prior["eip"]) != "" {
+		ha["eip"] = gw.PublicIP
+	}
+
+	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes) {
+		if gw.InsaneMode == "yes" {
+			ha["insane_mode_az"] = gw.GatewayZone
+		}
+	} else if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.GCPRelatedCloudTypes) {
+		ha["zone"] = gw.GatewayZone
+	} else if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AzureArmRelatedCloudTypes) {
+		if mustString(prior["zone"]) != "" && gw.GatewayZone != "AvailabilitySet" {
+			ha["zone"] = "az-" + gw.GatewayZone
+		}
+	} else if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.OCIRelatedCloudTypes) {
+		if gw.GatewayZone != "" {
+			ha["availability_domain"] = gw.GatewayZone
+		} else {
+			ha["availability_domain"] = prior["availability_domain"]
+		}
+		ha["fault_domain"] = gw.FaultDomain
+	}
+	return ha
+}
+
+// readSpokeHaGateways refreshes the HA gateways in ha_gateways. HA gateways that no longer exist are dropped
+// so that they are recreated on the next apply. On import the HA gateways are discovered from the
+// controller instead. The blocks are sorted by gw_name.
+func readSpokeHaGateways(d *schema.ResourceData, client *goaviatrix.Client, isImport bool) error {
+	if isImport {
+		return importSpokeHaGateways(d, client)
+	}
+	var haGateways []map[string]interface{}
+	for _, v := range getList(d, "ha_gateways") {
+		prior := mustMap(v)
+		name := mustString(prior["gw_name"])
+		gw, err := client.GetGateway(&goaviatrix.Gateway{GwName: name})
+		if errors.Is(err, goaviatrix.ErrNotFound) {
+			log.Printf("[WARN] Spoke HA Gateway %s not found, removing it from 'ha_gateways'", name)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't get Spoke HA Gateway %s: %w", name, err)
+		}
+		haGateways = append(haGateways, flattenSpokeHaGateway(gw, prior))
+	}
+	sort.Slice(haGateways, func(i, j int) bool {
+		return mustString(haGateways[i]["gw_name"]) < mustString(haGateways[j]["gw_name"])
+	})
+	mustSet(d, "ha_gateways", haGateways)
+	return nil
+}
+
+// importSpokeHaGateways fills ha_gateways with the HA gateways of the imported spoke gateway. A spoke gateway
+// whose only HA gateway is the legacy "-hagw" one keeps manage_ha_gateway enabled and an empty ha_gateways,
+// otherwise manage_ha_gateway is turned off so that the HA gateways are managed through ha_gateways.
+func importSpokeHaGateways(d *schema.ResourceData, client *goaviatrix.Client) error {
+	primaryGwName := getString(d, "gw_name")
+	gwList, err := client.ListGateways()
+	if err != nil {
+		return fmt.Errorf("couldn't list gateways to import the HA gateways of Spoke Gateway %s: %w", primaryGwName, err)
+	}
+
+	var haGateways []map[string]interface{}
+	legacyHaGw := false
+	for i := range gwList {
+		gw := &gwList[i]
+		if gw.PrimaryGwName != primaryGwName || gw.GwName == primaryGwName {
+			continue
+		}
+		if gw.GwName == primaryGwName+"-hagw" {
+			legacyHaGw = true
+			continue
+		}
+		// The eip of an HA gateway cannot be told apart from an allocated one, so it is left unset. The
+		// zone of an Azure HA gateway is read back as for the HA gateway of the spoke gateway itself.
+		prior := map[string]interface{}{"eip": "", "zone": "import", "availability_domain": ""}
+		haGateways = append(haGateways, flattenSpokeHaGateway(gw, prior))
+	}
+	if len(haGateways) == 0 {
+		return nil
+	}
+	if legacyHaGw {
+		return fmt.Errorf("Spoke Gateway %s has both a '-hagw' HA gateway and additional HA gateways, which cannot be imported together", primaryGwName)
+	}
+
+	sort.Slice(haGateways, func(i, j int) bool {
+		return mustString(haGateways[i]["gw_name"]) < mustString(haGateways[j]["gw_name"])
+	})
+	mustSet(d, "manage_ha_gateway", false)
+	mustSet(d, "ha_gateways", haGateways)
+	return nil
+}
@@ -0,0 +1,425 @@
+package aviatrix
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func testSpokeHaGatewayBlock(name, subnet, gwSize string) map[string]interface{} {
+	return map[string]interface{}{
+		"gw_name":             name,
+		"subnet":              subnet,
+		"gw_size":             gwSize,
+		"zone":                "",
+		"insane_mode_az":      "",
+		"eip":                 "",
+		"availability_domain": "",
+		"fault_domain":        "",
+	}
+}
+
+func TestValidateSpokeHaGateways(t *testing.T) {
+	tests := []struct {
+		name            string
+		manageHaGateway bool
+		haGateways      []interface{}
+		expectError     string
+	}{
+		{
+			name: "sorted unique names",
+			haGateways: []interface{}{
+				testSpokeHaGatewayBlock("spoke-gw-ha1", "10.0.1.0/24", "t3.small"),
+				testSpokeHaGatewayBlock("spoke-gw-ha2", "10.0.2.0/24", "t3.small"),
+			},
+		},
+		{
+			name:            "no HA gateways with manage_ha_gateway",
+			manageHaGateway: true,
+		},
+		{
+			name:            "manage_ha_gateway enabled",
+			manageHaGateway: true,
+			haGateways: []interface{}{
+				testSpokeHaGatewayBlock("spoke-gw-ha1", "10.0.1.0/24", "t3.small"),
+			},
+			expectError: "'manage_ha_gateway' is set to false",
+		},
+		{
+			name: "duplicate names",
+			haGateways: []interface{}{
+				testSpokeHaGatewayBlock("spoke-gw-ha1", "10.0.1.0/24", "t3.small"),
+				testSpokeHaGatewayBlock("spoke-gw-ha1", "10.0.2.0/24", "t3.small"),
+			},
+			expectError: "listed more than once",
+		},
+		{
+			name: "unsorted names",
+			haGateways: []interface{}{
+				testSpokeHaGatewayBlock("spoke-gw-ha2", "10.0.2.0/24", "t3.small"),
+				testSpokeHaGatewayBlock("spoke-gw-ha1", "10.0.1.0/24", "t3.small"),
+			},
+			expectError: "must be sorted by gw_name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":           "spoke-gw",
+				"manage_ha_gateway": tt.manageHaGateway,
+			})
+
+			err := validateSpokeHaGateways(d, tt.haGateways)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSpokeHaGateway(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		ha          map[string]string
+		expectError string
+	}{
+		{
+			name:   "AWS",
+			config: map[string]interface{}{"cloud_type": goaviatrix.AWS},
+		},
+		{
+			name:   "AWS insane mode",
+			config: map[string]interface{}{"cloud_type": goaviatrix.AWS, "insane_mode": true},
+			ha:     map[string]string{"insane_mode_az": "us-west-2b"},
+		},
+		{
+			name:        "AWS with zone",
+			config:      map[string]interface{}{"cloud_type": goaviatrix.AWS},
+			ha:          map[string]string{"zone": "us-west-2b"},
+			expectError: "must be empty for AWS related cloud types",
+		},
+		{
+			name:        "AWS insane mode without AZ",
+			config:      map[string]interface{}{"cloud_type": goaviatrix.AWS, "insane_mode": true},
+			expectError: "'insane_mode_az' is required",
+		},
+		{
+			name:        "insane_mode_az without insane mode",
+			config:      map[string]interface{}{"cloud_type": goaviatrix.AWS},
+			ha:          map[string]string{"insane_mode_az": "us-west-2b"},
+			expectError: "'insane_mode_az' is only valid",
+		},
+		{
+			name:        "Azure zone in GCP form",
+			config:      map[string]interface{}{"cloud_type": goaviatrix.Azure},
+			ha:          map[string]string{"zone": "us-west1-c"},
+			expectError: "HA gateway spoke-gw-ha1: expected zone to be of the form 'az-n', got 'us-west1-c'",
+		},
+		{
+			name:        "Azure eip",
+			config:      map[string]interface{}{"cloud_type": goaviatrix.Azure},
+			ha:          map[string]string{"eip": "198.51.100.10"},
+			expectError: "'eip' is not supported",
+		},
+		{
+			name:        "GCP without zone",
+			config:      map[string]interface{}{"cloud_type": goaviatrix.GCP},
+			expectError: "'zone' is required",
+		},
+		{
+			name:        "OCI without fault domain",
+			config:      map[string]interface{}{"cloud_type": goaviatrix.OCI},
+			ha:          map[string]string{"availability_domain": "ad-1"},
+			expectError: "'availability_domain' and 'fault_domain' are required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{"gw_name": "spoke-gw", "manage_ha_gateway": false}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, config)
+			ha := testSpokeHaGatewayBlock("spoke-gw-ha1", "10.0.1.0/24", "t3.small")
+			for k, v := range tt.ha {
+				ha[k] = v
+			}
+
+			err := validateSpokeHaGateways(d, []interface{}{ha})
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewSpokeHaGateway(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         map[string]interface{}
+		ha             map[string]string
+		expectedSubnet string
+		expectedInsane string
+	}{
+		{
+			name:           "AWS",
+			config:         map[string]interface{}{"cloud_type": goaviatrix.AWS},
+			expectedSubnet: "10.0.1.0/24",
+			expectedInsane: "no",
+		},
+		{
+			name:           "AWS insane mode",
+			config:         map[string]interface{}{"cloud_type": goaviatrix.AWS, "insane_mode": true},
+			ha:             map[string]string{"insane_mode_az": "us-west-2b"},
+			expectedSubnet: "10.0.1.0/24~~us-west-2b",
+			expectedInsane: "yes",
+		},
+		{
+			name:           "Azure zone",
+			config:         map[string]interface{}{"cloud_type": goaviatrix.Azure},
+			ha:             map[string]string{"zone": "az-2"},
+			expectedSubnet: "10.0.1.0/24~~az-2~~",
+			expectedInsane: "no",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{"gw_name": "spoke-gw"}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, config)
+			ha := testSpokeHaGatewayBlock("spoke-gw-ha1", "10.0.1.0/24", "t3.small")
+			for k, v := range tt.ha {
+				ha[k] = v
+			}
+
+			haGw := newSpokeHaGateway(d, ha)
+			assert.Equal(t, "spoke-gw", haGw.PrimaryGwName)
+			assert.Equal(t, "spoke-gw-ha1", haGw.GwName)
+			assert.Equal(t, "t3.small", haGw.GwSize)
+			assert.Equal(t, tt.expectedSubnet, haGw.Subnet)
+			assert.Equal(t, tt.expectedInsane, haGw.InsaneMode)
+		})
+	}
+}
+
+func TestNewSpokeHaGatewayAzureZones(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name":    "spoke-gw",
+		"cloud_type": goaviatrix.Azure,
+		"zone":       "az-1",
+	})
+
+	tests := []struct {
+		name           string
+		subnet         string
+		zone           string
+		expectedSubnet string
+	}{
+		{name: "spoke-gw-ha1", subnet: "10.0.1.0/24", zone: "az-3", expectedSubnet: "10.0.1.0/24~~az-3~~"},
+		{name: "spoke-gw-ha2", subnet: "10.0.2.0/24", zone: "az-2", expectedSubnet: "10.0.2.0/24~~az-2~~"},
+	}
+	for _, tt := range tests {
+		ha := testSpokeHaGatewayBlock(tt.name, tt.subnet, "Standard_B1ms")
+		ha["zone"] = tt.zone
+
+		haGw := newSpokeHaGateway(d, ha)
+		assert.Equal(t, tt.expectedSubnet, haGw.Subnet)
+	}
+}
+
+func TestReconcileSpokeHaGateways(t *testing.T) {
+	ha1 := testSpokeHaGatewayBlock("spoke-gw-ha1", "10.0.1.0/24", "t3.small")
+	ha2 := testSpokeHaGatewayBlock("spoke-gw-ha2", "10.0.2.0/24", "t3.small")
+	ha3 := testSpokeHaGatewayBlock("spoke-gw-ha3", "10.0.3.0/24", "t3.small")
+	ha2Resized := testSpokeHaGatewayBlock("spoke-gw-ha2", "10.0.2.0/24", "t3.medium")
+	ha2Moved := testSpokeHaGatewayBlock("spoke-gw-ha2", "10.0.4.0/24", "t3.small")
+
+	tests := []struct {
+		name          string
+		oldHaGateways []interface{}
+		newHaGateways []interface{}
+		expectedCalls []string
+		expectedNew   []string
+	}{
+		{
+			name:          "delete one HA gateway",
+			oldHaGateways: []interface{}{ha1, ha2, ha3},
+			newHaGateways: []interface{}{ha1, ha3},
+			expectedCalls: []string{"delete_container spoke-gw-ha2"},
+		},
+		{
+			name:          "add one HA gateway",
+			oldHaGateways: []interface{}{ha1},
+			newHaGateways: []interface{}{ha1, ha2},
+			expectedCalls: []string{"create_multicloud_ha_gateway spoke-gw-ha2"},
+			expectedNew:   []string{"spoke-gw-ha2"},
+		},
+		{
+			name:          "resize one HA gateway",
+			oldHaGateways: []interface{}{ha1, ha2},
+			newHaGateways: []interface{}{ha1, ha2Resized},
+			expectedCalls: []string{"edit_gw_config spoke-gw-ha2"},
+		},
+		{
+			name:          "move one HA gateway to another subnet",
+			oldHaGateways: []interface{}{ha1, ha2},
+			newHaGateways: []interface{}{ha1, ha2Moved},
+			expectedCalls: []string{"delete_container spoke-gw-ha2", "create_multicloud_ha_gateway spoke-gw-ha2"},
+			expectedNew:   []string{"spoke-gw-ha2"},
+		},
+		{
+			name:          "no change",
+			oldHaGateways: []interface{}{ha1, ha2},
+			newHaGateways: []interface{}{ha1, ha2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					switch form.Get("action") {
+					case "check_task_status":
+						return `{"return": true, "results": "done", "reason": ""}`
+					case "delete_container":
+						calls = append(calls, "delete_container "+form.Get("gw_name"))
+					case "create_multicloud_ha_gateway":
+						calls = append(calls, "create_multicloud_ha_gateway "+form.Get("ha_gw_name"))
+					default:
+						calls = append(calls, form.Get("action")+" "+form.Get("gw_name"))
+					}
+					return `{"return": true, "results": "request-1", "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":    "spoke-gw",
+				"cloud_type": goaviatrix.AWS,
+			})
+
+			created, err := reconcileSpokeHaGateways(d, client, tt.oldHaGateways, tt.newHaGateways)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedNew, created)
+		})
+	}
+}
+
+func TestReadSpokeHaGateways(t *testing.T) {
+	gateways := map[string]string{
+		"spoke-gw-ha1": "10.0.1.0/24",
+		"spoke-gw-ha2": "10.0.2.0/24",
+	}
+	transport := &fakeControllerTransport{
+		respond: func(form url.Values) string {
+			name := form.Get("gateway_name")
+			subnet, ok := gateways[name]
+			if !ok {
+				return `{"return": true, "results": [], "reason": ""}`
+			}
+			return fmt.Sprintf(`{"return": true, "results": [{"vpc_name": %q, "cloud_type": 1, "public_subnet": %q,
+				"vpc_size": "t3.small", "public_ip": "198.51.100.10", "private_ip": "10.0.0.10",
+				"cloudn_gateway_inst_id": "i-0a1b2c3d"}], "reason": ""}`, name, subnet)
+		},
+	}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name":           "spoke-gw",
+		"manage_ha_gateway": false,
+		"ha_gateways": []interface{}{
+			testSpokeHaGatewayBlock("spoke-gw-ha2", "10.0.2.0/24", "t3.small"),
+			testSpokeHaGatewayBlock("spoke-gw-ha3", "10.0.3.0/24", "t3.small"),
+			testSpokeHaGatewayBlock("spoke-gw-ha1", "10.0.1.0/24", "t3.small"),
+		},
+	})
+
+	err := readSpokeHaGateways(d, client, false)
+	assert.NoError(t, err)
+
+	haGateways := getList(d, "ha_gateways")
+	assert.Len(t, haGateways, 2)
+	for i, name := range []string{"spoke-gw-ha1", "spoke-gw-ha2"} {
+		ha := mustMap(haGateways[i])
+		assert.Equal(t, name, ha["gw_name"])
+		assert.Equal(t, gateways[name], ha["subnet"])
+		assert.Equal(t, "t3.small", ha["gw_size"])
+		assert.Equal(t, "", ha["eip"])
+		assert.Equal(t, "198.51.100.10", ha["public_ip"])
+		assert.Equal(t, "i-0a1b2c3d", ha["cloud_instance_id"])
+	}
+}
+
+func TestReadSpokeHaGatewaysImport(t *testing.T) {
+	tests := []struct {
+		name             string
+		gateways         string
+		expectedManageHa bool
+		expectedNames    []string
+		expectError      string
+	}{
+		{
+			name: "additional HA gateways",
+			gateways: `{"vpc_name": "spoke-gw", "cloud_type": 1},
+				{"vpc_name": "spoke-gw-ha2", "cloud_type": 1, "primary_gw_name": "spoke-gw", "public_subnet": "10.0.2.0/24"},
+				{"vpc_name": "spoke-gw-ha1", "cloud_type": 1, "primary_gw_name": "spoke-gw", "public_subnet": "10.0.1.0/24"},
+				{"vpc_name": "other-gw-ha1", "cloud_type": 1, "primary_gw_name": "other-gw"}`,
+			expectedNames: []string{"spoke-gw-ha1", "spoke-gw-ha2"},
+		},
+		{
+			name: "legacy HA gateway",
+			gateways: `{"vpc_name": "spoke-gw", "cloud_type": 1},
+				{"vpc_name": "spoke-gw-hagw", "cloud_type": 1, "primary_gw_name": "spoke-gw"}`,
+			expectedManageHa: true,
+		},
+		{
+			name: "legacy and additional HA gateways",
+			gateways: `{"vpc_name": "spoke-gw", "cloud_type": 1},
+				{"vpc_name": "spoke-gw-hagw", "cloud_type": 1, "primary_gw_name": "spoke-gw"},
+				{"vpc_name": "spoke-gw-ha1", "cloud_type": 1, "primary_gw_name": "spoke-gw"}`,
+			expectError: "cannot be imported together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: `{"return": true, "results": [` + tt.gateways + `], "reason": ""}`}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":           "spoke-gw",
+				"manage_ha_gateway": true,
+			})
+
+			err := readSpokeHaGateways(d, client, true)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedManageHa, getBool(d, "manage_ha_gateway"))
+			var names []string
+			for _, v := range getList(d, "ha_gateways") {
+				ha := mustMap(v)
+				names = append(names, mustString(ha["gw_name"]))
+				assert.Equal(t, "", ha["eip"])
+			}
+			assert.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
@@ -1,13 +1,21 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 
 	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
 )
@@ -115,6 +123,7 @@ func TestAccAviatrixSpokeGateway_basic(t *testing.T) {
 						resource.TestCheckResourceAttr(resourceName, "single_ip_snat", "false"),
 						resource.TestCheckResourceAttr(resourceName, "bgp_polling_time", "50"),
 						resource.TestCheckResourceAttr(resourceName, "bgp_neighbor_status_polling_time", "5"),
+						resource.TestMatchResourceAttr(resourceName, "eip_allocation_id", regexp.MustCompile(`^eipalloc-`)),
 					),
 				},
 				{
@@ -943,3 +952,3487 @@ resource "aviatrix_spoke_gateway" "test_spoke_gateway_ipv6_gcp" {
 	`, rName, os.Getenv("GCP_PROJECT_ID"), os.Getenv("GOOGLE_CREDENTIALS_FILEPATH"),
 		os.Getenv("GCP_VPC_ID"), os.Getenv("GCP_ZONE"), os.Getenv("GCP_SUBNET"))
 }
+
+func TestAccAviatrixSpokeGateway_description(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_spoke_gateway.test_spoke_gateway_description"
+
+	msgCommon := ". Set SKIP_SPOKE_GATEWAY_AWS to yes to skip Spoke Gateway description tests"
+
+	if os.Getenv("SKIP_SPOKE_GATEWAY") == "yes" || os.Getenv("SKIP_SPOKE_GATEWAY_AWS") == "yes" {
+		t.Skip("Skipping Spoke Gateway description test as SKIP_SPOKE_GATEWAY or SKIP_SPOKE_GATEWAY_AWS is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preAwsSpokeGatewayCheck(t, msgCommon)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSpokeGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpokeGatewayConfigAWSDescription(rName, "inventory: team-a"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "description", "inventory: team-a"),
+				),
+			},
+			{
+				Config: testAccSpokeGatewayConfigAWSDescription(rName, "inventory: team-b"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "description", "inventory: team-b"),
+				),
+			},
+			{
+				Config: testAccSpokeGatewayConfigAWSDescription(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccSpokeGatewayConfigAWSDescription(rName, description string) string {
+	awsGwSize := os.Getenv("AWS_GW_SIZE")
+	if awsGwSize == "" {
+		awsGwSize = "t2.micro"
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_spoke_gateway" "test_spoke_gateway_description" {
+	cloud_type   = 1
+	account_name = aviatrix_account.test_acc_aws.account_name
+	gw_name      = "tfg-aws-desc-%[1]s"
+	vpc_id       = "%[5]s"
+	vpc_reg      = "%[6]s"
+	gw_size      = "%[7]s"
+	subnet       = "%[8]s"
+	description  = "%[9]s"
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID4"), os.Getenv("AWS_REGION"), awsGwSize, os.Getenv("AWS_SUBNET4"), description)
+}
+
+func TestAccAviatrixSpokeGateway_tcpMssClamp(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_spoke_gateway.test_spoke_gateway_mss"
+
+	msgCommon := ". Set SKIP_SPOKE_GATEWAY_AWS to yes to skip Spoke Gateway TCP MSS clamp tests"
+
+	if os.Getenv("SKIP_SPOKE_GATEWAY") == "yes" || os.Getenv("SKIP_SPOKE_GATEWAY_AWS") == "yes" {
+		t.Skip("Skipping Spoke Gateway TCP MSS clamp test as SKIP_SPOKE_GATEWAY or SKIP_SPOKE_GATEWAY_AWS is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preAwsSpokeGatewayCheck(t, msgCommon)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSpokeGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpokeGatewayConfigAWSTcpMssClamp(rName, "1350"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "tcp_mss_clamp", "1350"),
+				),
+			},
+			{
+				Config: testAccSpokeGatewayConfigAWSTcpMssClamp(rName, "null"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "tcp_mss_clamp", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSpokeGatewayConfigAWSTcpMssClamp(rName, tcpMss string) string {
+	awsGwSize := os.Getenv("AWS_GW_SIZE")
+	if awsGwSize == "" {
+		awsGwSize = "t2.micro"
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_spoke_gateway" "test_spoke_gateway_mss" {
+	cloud_type    = 1
+	account_name  = aviatrix_account.test_acc_aws.account_name
+	gw_name       = "tfg-aws-mss-%[1]s"
+	vpc_id        = "%[5]s"
+	vpc_reg       = "%[6]s"
+	gw_size       = "%[7]s"
+	subnet        = "%[8]s"
+	tcp_mss_clamp = %[9]s
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID4"), os.Getenv("AWS_REGION"), awsGwSize, os.Getenv("AWS_SUBNET4"), tcpMss)
+}
+
+func TestAccAviatrixSpokeGateway_peeringKeepaliveInterval(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_spoke_gateway.test_spoke_gateway_keepalive"
+
+	msgCommon := ". Set SKIP_SPOKE_GATEWAY_AWS to yes to skip Spoke Gateway peering keepalive interval tests"
+
+	if os.Getenv("SKIP_SPOKE_GATEWAY") == "yes" || os.Getenv("SKIP_SPOKE_GATEWAY_AWS") == "yes" {
+		t.Skip("Skipping Spoke Gateway peering keepalive interval test as SKIP_SPOKE_GATEWAY or SKIP_SPOKE_GATEWAY_AWS is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preAwsSpokeGatewayCheck(t, msgCommon)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSpokeGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpokeGatewayConfigAWSPeeringKeepaliveInterval(rName, "5"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "peering_keepalive_interval", "5"),
+				),
+			},
+			{
+				Config: testAccSpokeGatewayConfigAWSPeeringKeepaliveInterval(rName, "null"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "peering_keepalive_interval", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSpokeGatewayConfigAWSPeeringKeepaliveInterval(rName, interval string) string {
+	awsGwSize := os.Getenv("AWS_GW_SIZE")
+	if awsGwSize == "" {
+		awsGwSize = "t2.micro"
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_spoke_gateway" "test_spoke_gateway_keepalive" {
+	cloud_type                 = 1
+	account_name               = aviatrix_account.test_acc_aws.account_name
+	gw_name                    = "tfg-aws-keepalive-%[1]s"
+	vpc_id                     = "%[5]s"
+	vpc_reg                    = "%[6]s"
+	gw_size                    = "%[7]s"
+	subnet                     = "%[8]s"
+	peering_keepalive_interval = %[9]s
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID4"), os.Getenv("AWS_REGION"), awsGwSize, os.Getenv("AWS_SUBNET4"), interval)
+}
+
+func TestAccAviatrixSpokeGateway_additionalFaultDomains(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_spoke_gateway.test_spoke_gateway_fault_domains"
+
+	msgCommon := ". Set SKIP_SPOKE_GATEWAY_OCI to yes to skip Spoke Gateway additional fault domains tests"
+
+	if os.Getenv("SKIP_SPOKE_GATEWAY") == "yes" || os.Getenv("SKIP_SPOKE_GATEWAY_OCI") == "yes" {
+		t.Skip("Skipping Spoke Gateway additional fault domains test as SKIP_SPOKE_GATEWAY or SKIP_SPOKE_GATEWAY_OCI is set")
+	}
+	additionalFaultDomain := os.Getenv("OCI_ADDITIONAL_FAULT_DOMAIN")
+	if additionalFaultDomain == "" {
+		t.Skip("Skipping Spoke Gateway additional fault domains test as OCI_ADDITIONAL_FAULT_DOMAIN is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preGatewayCheckOCI(t, msgCommon)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSpokeGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpokeGatewayConfigOCIAdditionalFaultDomains(rName, additionalFaultDomain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "fault_domain", os.Getenv("OCI_FAULT_DOMAIN")),
+					resource.TestCheckResourceAttr(resourceName, "additional_fault_domains.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "additional_fault_domains.*", additionalFaultDomain),
+				),
+			},
+		},
+	})
+}
+
+func testAccSpokeGatewayConfigOCIAdditionalFaultDomains(rName, additionalFaultDomain string) string {
+	ociGwSize := os.Getenv("OCI_GW_SIZE")
+	if ociGwSize == "" {
+		ociGwSize = "VM.Standard2.2"
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_oci" {
+	account_name                 = "tfa-oci-%s"
+	cloud_type                   = 16
+	oci_tenancy_id               = "%s"
+	oci_user_id                  = "%s"
+	oci_compartment_id           = "%s"
+	oci_api_private_key_filepath = "%s"
+}
+resource "aviatrix_spoke_gateway" "test_spoke_gateway_fault_domains" {
+	cloud_type               = 16
+	account_name             = aviatrix_account.test_acc_oci.account_name
+	gw_name                  = "tfg-oci-fd-%[1]s"
+	vpc_id                   = "%[6]s"
+	vpc_reg                  = "%[7]s"
+	gw_size                  = "%[8]s"
+	subnet                   = "%[9]s"
+	availability_domain      = "%[10]s"
+	fault_domain             = "%[11]s"
+	additional_fault_domains = ["%[12]s"]
+}
+	`, rName, os.Getenv("OCI_TENANCY_ID"), os.Getenv("OCI_USER_ID"), os.Getenv("OCI_COMPARTMENT_ID"),
+		os.Getenv("OCI_API_KEY_FILEPATH"), os.Getenv("OCI_VPC_ID"), os.Getenv("OCI_REGION"),
+		ociGwSize, os.Getenv("OCI_SUBNET"), os.Getenv("OCI_AVAILABILITY_DOMAIN"), os.Getenv("OCI_FAULT_DOMAIN"),
+		additionalFaultDomain)
+}
+
+func TestAccAviatrixSpokeGateway_bgpNeighborPassive(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_spoke_gateway.test_spoke_gateway_passive"
+
+	msgCommon := ". Set SKIP_SPOKE_GATEWAY_AWS to yes to skip Spoke Gateway BGP neighbor passive tests"
+
+	if os.Getenv("SKIP_SPOKE_GATEWAY") == "yes" || os.Getenv("SKIP_SPOKE_GATEWAY_AWS") == "yes" {
+		t.Skip("Skipping Spoke Gateway BGP neighbor passive test as SKIP_SPOKE_GATEWAY or SKIP_SPOKE_GATEWAY_AWS is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preAwsSpokeGatewayCheck(t, msgCommon)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSpokeGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpokeGatewayConfigAWSBgpNeighborPassive(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "enable_bgp", "true"),
+					resource.TestCheckResourceAttr(resourceName, "bgp_neighbor_passive", "true"),
+				),
+			},
+			{
+				Config: testAccSpokeGatewayConfigAWSBgpNeighborPassive(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "bgp_neighbor_passive", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSpokeGatewayConfigAWSBgpNeighborPassive(rName string, passive bool) string {
+	awsGwSize := os.Getenv("AWS_GW_SIZE")
+	if awsGwSize == "" {
+		awsGwSize = "t2.micro"
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_spoke_gateway" "test_spoke_gateway_passive" {
+	cloud_type           = 1
+	account_name         = aviatrix_account.test_acc_aws.account_name
+	gw_name              = "tfg-aws-passive-%[1]s"
+	vpc_id               = "%[5]s"
+	vpc_reg              = "%[6]s"
+	gw_size              = "%[7]s"
+	subnet               = "%[8]s"
+	enable_bgp           = true
+	local_as_number      = "65001"
+	bgp_neighbor_passive = %[9]t
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID4"), os.Getenv("AWS_REGION"), awsGwSize, os.Getenv("AWS_SUBNET4"), passive)
+}
+
+func TestAccAviatrixSpokeGateway_bgpSummaryCidrs(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_spoke_gateway.test_spoke_gateway_summary"
+
+	msgCommon := ". Set SKIP_SPOKE_GATEWAY_AWS to yes to skip Spoke Gateway BGP summary CIDRs tests"
+
+	if os.Getenv("SKIP_SPOKE_GATEWAY") == "yes" || os.Getenv("SKIP_SPOKE_GATEWAY_AWS") == "yes" {
+		t.Skip("Skipping Spoke Gateway BGP summary CIDRs test as SKIP_SPOKE_GATEWAY or SKIP_SPOKE_GATEWAY_AWS is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preAwsSpokeGatewayCheck(t, msgCommon)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSpokeGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpokeGatewayConfigAWSBgpSummaryCidrs(rName, `["10.0.0.0/8", "172.16.0.0/12"]`, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "bgp_summary_cidrs.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "bgp_summary_cidrs.*", "10.0.0.0/8"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "bgp_summary_cidrs.*", "172.16.0.0/12"),
+					resource.TestCheckResourceAttr(resourceName, "enable_preserve_as_path", "true"),
+				),
+			},
+			{
+				Config: testAccSpokeGatewayConfigAWSBgpSummaryCidrs(rName, "[]", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "bgp_summary_cidrs.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "enable_preserve_as_path", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSpokeGatewayConfigAWSBgpSummaryCidrs(rName, summaryCidrs string, preserveAsPath bool) string {
+	awsGwSize := os.Getenv("AWS_GW_SIZE")
+	if awsGwSize == "" {
+		awsGwSize = "t2.micro"
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_spoke_gateway" "test_spoke_gateway_summary" {
+	cloud_type              = 1
+	account_name            = aviatrix_account.test_acc_aws.account_name
+	gw_name                 = "tfg-aws-summary-%[1]s"
+	vpc_id                  = "%[5]s"
+	vpc_reg                 = "%[6]s"
+	gw_size                 = "%[7]s"
+	subnet                  = "%[8]s"
+	enable_bgp              = true
+	local_as_number         = "65001"
+	bgp_summary_cidrs       = %[9]s
+	enable_preserve_as_path = %[10]t
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID4"), os.Getenv("AWS_REGION"), awsGwSize, os.Getenv("AWS_SUBNET4"), summaryCidrs, preserveAsPath)
+}
+
+func TestAccAviatrixSpokeGateway_fqdnGateway(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_spoke_gateway.test_spoke_gateway_fqdn"
+
+	msgCommon := ". Set SKIP_SPOKE_GATEWAY_AWS to yes to skip Spoke Gateway FQDN gateway tests"
+
+	if os.Getenv("SKIP_SPOKE_GATEWAY") == "yes" || os.Getenv("SKIP_SPOKE_GATEWAY_AWS") == "yes" {
+		t.Skip("Skipping Spoke Gateway FQDN gateway test as SKIP_SPOKE_GATEWAY or SKIP_SPOKE_GATEWAY_AWS is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preAwsSpokeGatewayCheck(t, msgCommon)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSpokeGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpokeGatewayConfigAWSFqdnGateway(rName, "aviatrix_gateway.test_fqdn_gateway.gw_name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "fqdn_gateway_name", fmt.Sprintf("tfg-aws-fqdn-%s", rName)),
+				),
+			},
+			{
+				Config: testAccSpokeGatewayConfigAWSFqdnGateway(rName, "null"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "fqdn_gateway_name", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccSpokeGatewayConfigAWSFqdnGateway(rName, fqdnGwName string) string {
+	awsGwSize := os.Getenv("AWS_GW_SIZE")
+	if awsGwSize == "" {
+		awsGwSize = "t2.micro"
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_gateway" "test_fqdn_gateway" {
+	cloud_type     = 1
+	account_name   = aviatrix_account.test_acc_aws.account_name
+	gw_name        = "tfg-aws-fqdn-%[1]s"
+	vpc_id         = "%[5]s"
+	vpc_reg        = "%[6]s"
+	gw_size        = "%[7]s"
+	subnet         = "%[8]s"
+	single_ip_snat = true
+}
+resource "aviatrix_spoke_gateway" "test_spoke_gateway_fqdn" {
+	cloud_type        = 1
+	account_name      = aviatrix_account.test_acc_aws.account_name
+	gw_name           = "tfg-aws-spoke-fqdn-%[1]s"
+	vpc_id            = "%[5]s"
+	vpc_reg           = "%[6]s"
+	gw_size           = "%[7]s"
+	subnet            = "%[8]s"
+	fqdn_gateway_name = %[9]s
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID4"), os.Getenv("AWS_REGION"), awsGwSize, os.Getenv("AWS_SUBNET4"), fqdnGwName)
+}
+
+func TestAccAviatrixSpokeGateway_iamInstanceProfile(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_spoke_gateway.test_spoke_gateway_iam"
+
+	msgCommon := ". Set SKIP_SPOKE_GATEWAY_AWS to yes to skip Spoke Gateway IAM instance profile tests"
+
+	if os.Getenv("SKIP_SPOKE_GATEWAY") == "yes" || os.Getenv("SKIP_SPOKE_GATEWAY_AWS") == "yes" {
+		t.Skip("Skipping Spoke Gateway IAM instance profile test as SKIP_SPOKE_GATEWAY or SKIP_SPOKE_GATEWAY_AWS is set")
+	}
+	iamInstanceProfile := os.Getenv("AWS_IAM_INSTANCE_PROFILE")
+	if iamInstanceProfile == "" {
+		t.Skip("Skipping Spoke Gateway IAM instance profile test as AWS_IAM_INSTANCE_PROFILE is not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preAwsSpokeGatewayCheck(t, msgCommon)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSpokeGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpokeGatewayConfigAWSIamInstanceProfile(rName, iamInstanceProfile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpokeGatewayExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "aws_iam_instance_profile", iamInstanceProfile),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSpokeGatewayConfigAWSIamInstanceProfile(rName, iamInstanceProfile string) string {
+	awsGwSize := os.Getenv("AWS_GW_SIZE")
+	if awsGwSize == "" {
+		awsGwSize = "t2.micro"
+	}
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_spoke_gateway" "test_spoke_gateway_iam" {
+	cloud_type               = 1
+	account_name             = aviatrix_account.test_acc_aws.account_name
+	gw_name                  = "tfg-aws-iam-%[1]s"
+	vpc_id                   = "%[5]s"
+	vpc_reg                  = "%[6]s"
+	gw_size                  = "%[7]s"
+	subnet                   = "%[8]s"
+	aws_iam_instance_profile = "%[9]s"
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID4"), os.Getenv("AWS_REGION"), awsGwSize, os.Getenv("AWS_SUBNET4"), iamInstanceProfile)
+}
+
+func TestResourceAviatrixSpokeGatewayCustomizeDiffTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name: "GCP spoke with tags",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.GCP, "vpc_reg": "us-west1-a", "tags": map[string]interface{}{"team": "network"},
+			},
+			expectError: "'tags' can't be read back for cloud type 4",
+		},
+		{
+			name: "GCP spoke without tags",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.GCP, "vpc_reg": "us-west1-a",
+			},
+		},
+		{
+			name: "AWS spoke with tags",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.AWS, "tags": map[string]interface{}{"Team": "network"},
+			},
+		},
+		{
+			name: "Azure spoke with tags",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.Azure, "vpc_reg": "West US", "tags": map[string]interface{}{"Team": "network"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"account_name": "test-account",
+				"gw_name":      "test-spoke",
+				"gw_size":      "t3.small",
+				"vpc_id":       "vpc-1234",
+				"vpc_reg":      "us-east-1",
+				"subnet":       "10.0.0.0/24",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			_, err := resourceAviatrixSpokeGateway().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestResourceAviatrixSpokeGatewayCustomizeDiffIPv6Subnets(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name: "dual stack",
+			config: map[string]interface{}{
+				"enable_ipv6": true, "subnet_ipv6_cidr": "2600:1f18:1234:5600::/64",
+				"ha_subnet": "10.0.1.0/24", "ha_subnet_ipv6_cidr": "2600:1f18:1234:5601::/64",
+			},
+		},
+		{
+			name: "IPv6 subnet",
+			config: map[string]interface{}{
+				"enable_ipv6": true, "subnet": "2600:1f18:1234:5600::/64", "subnet_ipv6_cidr": "2600:1f18:1234:5600::/64",
+			},
+			expectError: "invalid subnet: expected an IPv4 CIDR when enable_ipv6 is true",
+		},
+		{
+			name: "IPv4 subnet_ipv6_cidr",
+			config: map[string]interface{}{
+				"enable_ipv6": true, "subnet_ipv6_cidr": "10.0.0.0/24",
+			},
+			expectError: "invalid subnet_ipv6_cidr: expected an IPv6 CIDR when enable_ipv6 is true",
+		},
+		{
+			name: "IPv4 ha_subnet_ipv6_cidr",
+			config: map[string]interface{}{
+				"enable_ipv6": true, "subnet_ipv6_cidr": "2600:1f18:1234:5600::/64",
+				"ha_subnet": "10.0.1.0/24", "ha_subnet_ipv6_cidr": "10.0.1.0/24",
+			},
+			expectError: "invalid ha_subnet_ipv6_cidr: expected an IPv6 CIDR when enable_ipv6 is true",
+		},
+		{
+			name: "IPv6 disabled",
+			config: map[string]interface{}{
+				"subnet": "2600:1f18:1234:5600::/64",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"cloud_type":   goaviatrix.AWS,
+				"account_name": "test-account",
+				"gw_name":      "test-spoke",
+				"gw_size":      "t3.small",
+				"vpc_id":       "vpc-1234",
+				"vpc_reg":      "us-east-1",
+				"subnet":       "10.0.0.0/24",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			_, err := resourceAviatrixSpokeGateway().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestResourceAviatrixSpokeGatewayCustomizeDiffHaCustomerManagedKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name: "with ha_subnet",
+			config: map[string]interface{}{
+				"enable_encrypt_volume": true, "ha_customer_managed_keys": "2222bbbb-12ab-34cd-56ef-1234567890ab",
+				"ha_subnet": "10.0.1.0/24", "ha_gw_size": "t3.small",
+			},
+		},
+		{
+			name: "with ha_gateways",
+			config: map[string]interface{}{
+				"enable_encrypt_volume": true, "ha_customer_managed_keys": "2222bbbb-12ab-34cd-56ef-1234567890ab",
+				"manage_ha_gateway": false,
+				"ha_gateways": []interface{}{
+					map[string]interface{}{"gw_name": "test-spoke-ha1", "subnet": "10.0.1.0/24", "gw_size": "t3.small"},
+				},
+			},
+		},
+		{
+			name: "without HA",
+			config: map[string]interface{}{
+				"enable_encrypt_volume": true, "ha_customer_managed_keys": "2222bbbb-12ab-34cd-56ef-1234567890ab",
+			},
+			expectError: "'ha_customer_managed_keys' requires an HA gateway",
+		},
+		{
+			name: "without encrypt volume",
+			config: map[string]interface{}{
+				"ha_customer_managed_keys": "2222bbbb-12ab-34cd-56ef-1234567890ab",
+				"ha_subnet":                "10.0.1.0/24", "ha_gw_size": "t3.small",
+			},
+			expectError: "'ha_customer_managed_keys' should be empty since Encrypt Volume is not enabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"cloud_type":   goaviatrix.AWS,
+				"account_name": "test-account",
+				"gw_name":      "test-spoke",
+				"gw_size":      "t3.small",
+				"vpc_id":       "vpc-1234",
+				"vpc_reg":      "us-east-1",
+				"subnet":       "10.0.0.0/24",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			_, err := resourceAviatrixSpokeGateway().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEncryptSpokeHaGatewayVolumes(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		expectedKeys  []string
+		expectedCalls []string
+	}{
+		{
+			name: "falls back to customer_managed_keys",
+			config: map[string]interface{}{
+				"customer_managed_keys": "1111aaaa-12ab-34cd-56ef-1234567890ab",
+			},
+			expectedKeys:  []string{"1111aaaa-12ab-34cd-56ef-1234567890ab", "1111aaaa-12ab-34cd-56ef-1234567890ab"},
+			expectedCalls: []string{"encrypt_gateway_volume", "encrypt_gateway_volume"},
+		},
+		{
+			name: "HA key alias ARN",
+			config: map[string]interface{}{
+				"customer_managed_keys":    "1111aaaa-12ab-34cd-56ef-1234567890ab",
+				"ha_customer_managed_keys": "arn:aws:kms:us-west-2:111122223333:alias/ha-gateway-volumes",
+			},
+			expectedKeys:  []string{"3333cccc-12ab-34cd-56ef-1234567890ab", "3333cccc-12ab-34cd-56ef-1234567890ab"},
+			expectedCalls: []string{"get_kms_key_id_by_alias", "encrypt_gateway_volume", "encrypt_gateway_volume"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					if form.Get("action") == "get_kms_key_id_by_alias" {
+						return `{"return": true, "results": {"key_id": "3333cccc-12ab-34cd-56ef-1234567890ab"}, "reason": ""}`
+					}
+					keys = append(keys, form.Get("customer_managed_keys"))
+					return `{"return": true, "results": "ok", "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			config := map[string]interface{}{"gw_name": "spoke-gw", "account_name": "aws-account"}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, config)
+
+			err := encryptSpokeHaGatewayVolumes(d, client, []string{"spoke-gw-hagw", "spoke-gw-ha1"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, transport.actions)
+			assert.Equal(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+func TestResourceAviatrixSpokeGatewayCustomizeDiffGwSizes(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		existing    bool
+		response    string
+		expectList  bool
+		expectError string
+	}{
+		{
+			name:       "available sizes",
+			config:     map[string]interface{}{"ha_subnet": "10.0.1.0/24", "ha_gw_size": "C5N.xlarge"},
+			expectList: true,
+		},
+		{
+			name:        "unavailable gw_size",
+			config:      map[string]interface{}{"gw_size": "t2.nano"},
+			expectList:  true,
+			expectError: `invalid gw_size: instance size "t2.nano" is not available for cloud type 1 in us-east-1`,
+		},
+		{
+			name:        "unavailable ha_gw_size",
+			config:      map[string]interface{}{"ha_subnet": "10.0.1.0/24", "ha_gw_size": "t2.nano"},
+			expectList:  true,
+			expectError: `invalid ha_gw_size: instance size "t2.nano" is not available`,
+		},
+		{
+			name:     "unchanged gw_size",
+			config:   map[string]interface{}{"gw_size": "t2.nano"},
+			existing: true,
+		},
+		{
+			name:       "sizes can't be listed",
+			config:     map[string]interface{}{"gw_size": "t2.nano"},
+			response:   `{"return": false, "reason": "Invalid action: list_gateway_instance_sizes"}`,
+			expectList: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"cloud_type":   goaviatrix.AWS,
+				"account_name": "test-account",
+				"gw_name":      "test-spoke",
+				"gw_size":      "t3.small",
+				"vpc_id":       "vpc-1234",
+				"vpc_reg":      "us-east-1",
+				"subnet":       "10.0.0.0/24",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			var state *terraform.InstanceState
+			if tt.existing {
+				// the state of the gateway as created from the same configuration
+				created, err := resourceAviatrixSpokeGateway().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+				assert.NoError(t, err)
+				state = &terraform.InstanceState{ID: "test-spoke", Attributes: map[string]string{}}
+				for k, attr := range created.Attributes {
+					if !attr.NewComputed {
+						state.Attributes[k] = attr.New
+					}
+				}
+			}
+			response := tt.response
+			if response == "" {
+				response = `{"return": true, "results": ["t3.small", "c5n.xlarge"], "reason": ""}`
+			}
+			transport := &fakeControllerTransport{body: response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			_, err := resourceAviatrixSpokeGateway().Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectList {
+				assert.Contains(t, transport.actions, "list_gateway_instance_sizes")
+			} else {
+				assert.Empty(t, transport.actions)
+			}
+		})
+	}
+}
+
+func TestValidateSpokeConnectionApprovedCidrs(t *testing.T) {
+	connectionApprovedCidrs := []interface{}{
+		map[string]interface{}{
+			"connection_name": "conn-a",
+			"approved_cidrs":  []interface{}{"10.1.0.0/16", "10.0.0.0/16"},
+		},
+		map[string]interface{}{
+			"connection_name": "conn-b",
+			"approved_cidrs":  []interface{}{"192.168.0.0/24"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expected    map[string][]string
+		expectError string
+	}{
+		{
+			name: "BGP spoke",
+			config: map[string]interface{}{
+				"enable_bgp":                  true,
+				"learned_cidrs_approval_mode": "connection",
+				"connection_approved_cidrs":   connectionApprovedCidrs,
+			},
+			expected: map[string][]string{
+				"conn-a": {"10.0.0.0/16", "10.1.0.0/16"},
+				"conn-b": {"192.168.0.0/24"},
+			},
+		},
+		{
+			name:     "not configured",
+			config:   map[string]interface{}{},
+			expected: map[string][]string{},
+		},
+		{
+			name: "non-BGP spoke",
+			config: map[string]interface{}{
+				"connection_approved_cidrs": connectionApprovedCidrs,
+			},
+			expectError: "only supported for BGP enabled Spoke Gateways",
+		},
+		{
+			name: "gateway based approval",
+			config: map[string]interface{}{
+				"enable_bgp":                    true,
+				"enable_learned_cidrs_approval": true,
+				"connection_approved_cidrs":     connectionApprovedCidrs,
+			},
+			expectError: "must be empty if 'enable_learned_cidrs_approval' is true",
+		},
+		{
+			name: "gateway mode",
+			config: map[string]interface{}{
+				"enable_bgp":                  true,
+				"learned_cidrs_approval_mode": "gateway",
+				"connection_approved_cidrs":   connectionApprovedCidrs,
+			},
+			expectError: "requires 'learned_cidrs_approval_mode' to be set to 'connection'",
+		},
+		{
+			name: "duplicate connection",
+			config: map[string]interface{}{
+				"enable_bgp": true,
+				"connection_approved_cidrs": []interface{}{
+					map[string]interface{}{
+						"connection_name": "conn-a",
+						"approved_cidrs":  []interface{}{"10.0.0.0/16"},
+					},
+					map[string]interface{}{
+						"connection_name": "conn-a",
+						"approved_cidrs":  []interface{}{"10.1.0.0/16"},
+					},
+				},
+			},
+			expectError: `connection "conn-a" is listed more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, tt.config)
+
+			approvedCidrs, err := validateSpokeConnectionApprovedCidrs(d)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, approvedCidrs)
+		})
+	}
+}
+
+func TestValidateSpokeAdditionalFaultDomains(t *testing.T) {
+	tests := []struct {
+		name            string
+		cloudType       int
+		faultDomains    []string
+		expectedActions []string
+		expectError     string
+	}{
+		{
+			name:            "available fault domains",
+			cloudType:       goaviatrix.OCI,
+			faultDomains:    []string{"FAULT-DOMAIN-2", "FAULT-DOMAIN-3"},
+			expectedActions: []string{"list_oci_vpc_fault_domains"},
+		},
+		{
+			name:         "not OCI",
+			cloudType:    goaviatrix.AWS,
+			faultDomains: []string{"FAULT-DOMAIN-2"},
+			expectError:  "'additional_fault_domains' is only valid for OCI",
+		},
+		{
+			name:            "repeats fault_domain",
+			cloudType:       goaviatrix.OCI,
+			faultDomains:    []string{"FAULT-DOMAIN-1"},
+			expectedActions: []string{"list_oci_vpc_fault_domains"},
+			expectError:     "must not contain 'fault_domain' \"FAULT-DOMAIN-1\"",
+		},
+		{
+			name:            "unavailable fault domain",
+			cloudType:       goaviatrix.OCI,
+			faultDomains:    []string{"FAULT-DOMAIN-4"},
+			expectedActions: []string{"list_oci_vpc_fault_domains"},
+			expectError:     "fault domain \"FAULT-DOMAIN-4\" in 'additional_fault_domains' is not available in VPC vcn-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				body: `{"return": true, "results": ["FAULT-DOMAIN-1", "FAULT-DOMAIN-2", "FAULT-DOMAIN-3"], "reason": ""}`,
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			gateway := &goaviatrix.SpokeVpc{
+				CloudType:   tt.cloudType,
+				AccountName: "oci-account",
+				VpcID:       "vcn-1",
+				VpcRegion:   "us-ashburn-1",
+				FaultDomain: "FAULT-DOMAIN-1",
+			}
+
+			err := validateSpokeAdditionalFaultDomains(client, gateway, tt.faultDomains)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateFqdnGatewayExists(t *testing.T) {
+	tests := []struct {
+		name        string
+		gateway     string
+		expectError string
+	}{
+		{
+			name:    "gateway with SNAT",
+			gateway: `{"vpc_name": "fqdn-gw", "enable_nat": "yes"}`,
+		},
+		{
+			name:    "FireNet FQDN gateway",
+			gateway: `{"vpc_name": "fqdn-gw", "fqdn_interfaces": {"fqdn-gw": ["eth1"]}}`,
+		},
+		{
+			name:        "gateway without SNAT",
+			gateway:     `{"vpc_name": "fqdn-gw"}`,
+			expectError: "invalid fqdn_gateway_name: gateway \"fqdn-gw\" is not an FQDN gateway",
+		},
+		{
+			name:        "transit gateway",
+			gateway:     `{"vpc_name": "fqdn-gw", "enable_nat": "yes", "transit_vpc": "yes"}`,
+			expectError: "invalid fqdn_gateway_name: gateway \"fqdn-gw\" is not an FQDN gateway",
+		},
+		{
+			name:        "missing gateway",
+			gateway:     `{"vpc_name": "other-gw", "enable_nat": "yes"}`,
+			expectError: "invalid fqdn_gateway_name: FQDN gateway \"fqdn-gw\" does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: `{"return": true, "results": [` + tt.gateway + `], "reason": ""}`}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := validateFqdnGatewayExists(client, "fqdn-gw")
+			assert.Equal(t, []string{"list_vpcs_summary"}, transport.actions)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSpokeEgressConnectionExists(t *testing.T) {
+	tests := []struct {
+		name        string
+		connName    string
+		expectError string
+	}{
+		{
+			name:     "connection exists",
+			connName: "s2c-conn",
+		},
+		{
+			name:        "connection in another VPC",
+			connName:    "other-conn",
+			expectError: "invalid egress_via_connection: connection \"other-conn\" does not exist in VPC vpc-1",
+		},
+		{
+			name:        "missing connection",
+			connName:    "missing-conn",
+			expectError: "invalid egress_via_connection: connection \"missing-conn\" does not exist in VPC vpc-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				body: `{"return": true, "results": {"connections": [` +
+					`{"vpc_id": "vpc-1", "name": "s2c-conn"}, {"vpc_id": "vpc-2", "name": "other-conn"}]}, "reason": ""}`,
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := validateSpokeEgressConnectionExists(client, "vpc-1", tt.connName)
+			assert.Equal(t, []string{"list_site2cloud_conn"}, transport.actions)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSpokeLearnedCidrsApprovalMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name: "gateway mode",
+			config: map[string]interface{}{
+				"enable_bgp":                    true,
+				"enable_learned_cidrs_approval": true,
+				"learned_cidrs_approval_mode":   "gateway",
+			},
+		},
+		{
+			name: "connection mode",
+			config: map[string]interface{}{
+				"enable_bgp":                  true,
+				"learned_cidrs_approval_mode": "connection",
+			},
+		},
+		{
+			name: "connection mode on non-BGP spoke",
+			config: map[string]interface{}{
+				"learned_cidrs_approval_mode": "connection",
+			},
+			expectError: "only be set to 'connection' for BGP enabled Spoke Gateways",
+		},
+		{
+			name: "connection mode with gateway based approval",
+			config: map[string]interface{}{
+				"enable_bgp":                    true,
+				"enable_learned_cidrs_approval": true,
+				"learned_cidrs_approval_mode":   "connection",
+			},
+			expectError: "'enable_learned_cidrs_approval' must be false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, tt.config)
+
+			err := validateSpokeLearnedCidrsApprovalMode(d)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFlattenSpokeConnectionApprovedCidrs(t *testing.T) {
+	approvalInfo := []goaviatrix.LearnedCIDRApprovalInfo{
+		{ConnName: "conn-a", EnabledApproval: "yes", ApprovedLearnedCidrs: []string{"10.0.0.0/16"}},
+		{ConnName: "conn-b", EnabledApproval: "yes", ApprovedLearnedCidrs: []string{"192.168.0.0/24"}},
+		{ConnName: "conn-c", EnabledApproval: "yes"},
+	}
+	managed := map[string][]string{
+		"conn-a": {"10.0.0.0/16"},
+		"conn-c": {"172.16.0.0/12"},
+	}
+
+	assert.Equal(t, []map[string]interface{}{
+		{"connection_name": "conn-a", "approved_cidrs": []string{"10.0.0.0/16"}},
+		{"connection_name": "conn-c", "approved_cidrs": []string(nil)},
+	}, flattenSpokeConnectionApprovedCidrs(managed, approvalInfo))
+}
+
+func TestValidateSpokeBgpCommunities(t *testing.T) {
+	bgpCommunities := []interface{}{
+		map[string]interface{}{
+			"cidr":        "10.0.0.0/16",
+			"communities": []interface{}{"65000:200", "65000:100"},
+		},
+		map[string]interface{}{
+			"cidr":        "10.1.0.0/16",
+			"communities": []interface{}{"65000:300"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expected    map[string][]string
+		expectError string
+	}{
+		{
+			name: "send communities enabled",
+			config: map[string]interface{}{
+				"bgp_send_communities": true,
+				"bgp_communities":      bgpCommunities,
+			},
+			expected: map[string][]string{
+				"10.0.0.0/16": {"65000:100", "65000:200"},
+				"10.1.0.0/16": {"65000:300"},
+			},
+		},
+		{
+			name:     "not configured",
+			config:   map[string]interface{}{},
+			expected: map[string][]string{},
+		},
+		{
+			name: "send communities disabled",
+			config: map[string]interface{}{
+				"bgp_accept_communities": true,
+				"bgp_communities":        bgpCommunities,
+			},
+			expectError: "'bgp_communities' must be empty if 'bgp_send_communities' is false",
+		},
+		{
+			name: "duplicate CIDR",
+			config: map[string]interface{}{
+				"bgp_send_communities": true,
+				"bgp_communities": []interface{}{
+					map[string]interface{}{"cidr": "10.0.0.0/16", "communities": []interface{}{"65000:100"}},
+					map[string]interface{}{"cidr": "10.0.0.0/16", "communities": []interface{}{"65000:200"}},
+				},
+			},
+			expectError: "listed more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{"gw_name": "spoke-gw"}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, config)
+
+			bgpCommunities, err := validateSpokeBgpCommunities(d)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, bgpCommunities)
+		})
+	}
+}
+
+func TestFlattenSpokeBgpCommunities(t *testing.T) {
+	assert.Equal(t, []map[string]interface{}{
+		{"cidr": "10.0.0.0/16", "communities": []string{"65000:100"}},
+		{"cidr": "10.1.0.0/16", "communities": []string{"65000:200", "65000:300"}},
+	}, flattenSpokeBgpCommunities(map[string][]string{
+		"10.1.0.0/16": {"65000:200", "65000:300"},
+		"10.0.0.0/16": {"65000:100"},
+	}))
+	assert.Nil(t, flattenSpokeBgpCommunities(nil))
+}
+
+func TestSetSpokeGatewayJumboFrame(t *testing.T) {
+	tests := []struct {
+		name          string
+		enable        bool
+		gwNames       []string
+		expectedCalls []string
+	}{
+		{
+			name:          "disable with HA",
+			enable:        false,
+			gwNames:       []string{"spoke-gw", "spoke-gw-hagw"},
+			expectedCalls: []string{"disable_jumbo_frame spoke-gw", "disable_jumbo_frame spoke-gw-hagw"},
+		},
+		{
+			name:          "enable with HA",
+			enable:        true,
+			gwNames:       []string{"spoke-gw", "spoke-gw-hagw"},
+			expectedCalls: []string{"enable_jumbo_frame spoke-gw", "enable_jumbo_frame spoke-gw-hagw"},
+		},
+		{
+			name:          "without HA",
+			enable:        false,
+			gwNames:       []string{"spoke-gw", ""},
+			expectedCalls: []string{"disable_jumbo_frame spoke-gw"},
+		},
+		{
+			name:          "new HA gateway only",
+			enable:        false,
+			gwNames:       []string{"", "spoke-gw-hagw"},
+			expectedCalls: []string{"disable_jumbo_frame spoke-gw-hagw"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					calls = append(calls, form.Get("action")+" "+form.Get("gateway_name"))
+					return `{"return": true, "results": "jumbo frame updated", "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := setSpokeGatewayJumboFrame(client, tt.enable, tt.gwNames...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestReadSpokeGatewayJumboFrame(t *testing.T) {
+	tests := []struct {
+		name       string
+		gw         *goaviatrix.Gateway
+		expected   bool
+		expectedHa bool
+	}{
+		{
+			name: "HA present",
+			gw: &goaviatrix.Gateway{
+				JumboFrame: true,
+				HaGw:       goaviatrix.HaGateway{GwName: "spoke-gw-hagw", GwSize: "t3.small", JumboFrame: true},
+			},
+			expected:   true,
+			expectedHa: true,
+		},
+		{
+			name: "HA present with jumbo frame disabled",
+			gw: &goaviatrix.Gateway{
+				HaGw: goaviatrix.HaGateway{GwName: "spoke-gw-hagw", GwSize: "t3.small"},
+			},
+		},
+		{
+			name:     "no HA",
+			gw:       &goaviatrix.Gateway{JumboFrame: true},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name": "spoke-gw",
+			})
+
+			readSpokeGatewayJumboFrame(d, tt.gw)
+			assert.Equal(t, tt.expected, getBool(d, "enable_jumbo_frame"))
+			assert.Equal(t, tt.expectedHa, getBool(d, "ha_enable_jumbo_frame"))
+		})
+	}
+}
+
+// fakeControllerTransport answers every controller request with a fixed JSON body, or the body
+// returned by respond when it is set, and records the actions it was asked for, taken from the
+// query string or the form body.
+type fakeControllerTransport struct {
+	body    string
+	respond func(form url.Values) string
+	actions []string
+}
+
+func (f *fakeControllerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	form := req.URL.Query()
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if form, err = url.ParseQuery(string(body)); err != nil {
+			return nil, err
+		}
+	}
+	f.actions = append(f.actions, form.Get("action"))
+	body := f.body
+	if f.respond != nil {
+		body = f.respond(form)
+	}
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}
+	resp.Header.Set("Content-Type", "application/json")
+	return resp, nil
+}
+
+func TestReadSpokeGatewayUtilization(t *testing.T) {
+	tests := []struct {
+		name        string
+		readMetrics bool
+		response    string
+		expected    []interface{}
+		expectCalls []string
+		expectError string
+	}{
+		{
+			name:     "metrics not requested",
+			response: `{"return": false, "reason": "unexpected request"}`,
+			expected: []interface{}{},
+		},
+		{
+			name:        "metrics requested",
+			readMetrics: true,
+			response:    `{"return": true, "results": {"cpu_percent": 42.5, "memory_percent": 61.25}, "reason": ""}`,
+			expected: []interface{}{
+				map[string]interface{}{"cpu_percent": 42.5, "memory_percent": 61.25},
+			},
+			expectCalls: []string{"get_gateway_resource_utilization"},
+		},
+		{
+			name:        "gateway unreachable",
+			readMetrics: true,
+			response:    `{"return": false, "reason": "Gateway spoke-gw is not reachable"}`,
+			expectError: "could not get utilization of spoke gateway spoke-gw",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: tt.response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":      "spoke-gw",
+				"read_metrics": tt.readMetrics,
+			})
+
+			err := readSpokeGatewayUtilization(d, client, "spoke-gw")
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, d.Get("utilization"))
+			assert.Equal(t, tt.expectCalls, transport.actions)
+		})
+	}
+}
+
+func TestSpokeHaPublicIPv6(t *testing.T) {
+	tests := []struct {
+		name     string
+		gw       *goaviatrix.Gateway
+		expected string
+	}{
+		{
+			name: "IPv6 enabled",
+			gw: &goaviatrix.Gateway{
+				EnableIPv6: true,
+				HaGw:       goaviatrix.HaGateway{GwName: "spoke-gw-hagw", PublicIPv6: "2600:1f14:abc:de00::10"},
+			},
+			expected: "2600:1f14:abc:de00::10",
+		},
+		{
+			name: "IPv6 disabled",
+			gw: &goaviatrix.Gateway{
+				HaGw: goaviatrix.HaGateway{GwName: "spoke-gw-hagw", PublicIPv6: "2600:1f14:abc:de00::10"},
+			},
+			expected: "",
+		},
+		{
+			name: "no address reported",
+			gw: &goaviatrix.Gateway{
+				EnableIPv6: true,
+				HaGw:       goaviatrix.HaGateway{GwName: "spoke-gw-hagw"},
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, spokeHaPublicIPv6(tt.gw))
+		})
+	}
+}
+
+func TestValidateSpokeDnsForwarding(t *testing.T) {
+	tests := []struct {
+		name        string
+		enable      bool
+		targets     []string
+		expectError string
+	}{
+		{
+			name:    "enabled with targets",
+			enable:  true,
+			targets: []string{"10.10.0.53", "10.20.0.53"},
+		},
+		{
+			name: "disabled without targets",
+		},
+		{
+			name:        "enabled without targets",
+			enable:      true,
+			expectError: "'dns_forwarding_targets' is required",
+		},
+		{
+			name:        "disabled with targets",
+			targets:     []string{"10.10.0.53"},
+			expectError: "'dns_forwarding_targets' must be empty",
+		},
+		{
+			name:        "duplicate target",
+			enable:      true,
+			targets:     []string{"10.10.0.53", "10.10.0.53"},
+			expectError: `"10.10.0.53" is listed more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSpokeDnsForwarding(tt.enable, tt.targets)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSpokeSnat(t *testing.T) {
+	policy := map[string]interface{}{"src_cidr": "10.0.0.0/16", "snat_ips": "10.0.1.10"}
+	tests := []struct {
+		name        string
+		mode        string
+		policies    []interface{}
+		expectError string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "single_ip",
+			mode: "single_ip",
+		},
+		{
+			name:     "customized",
+			mode:     "customized",
+			policies: []interface{}{policy},
+		},
+		{
+			name:        "customized without policies",
+			mode:        "customized",
+			expectError: "'snat_policy' is required",
+		},
+		{
+			name:        "policies with single_ip",
+			mode:        "single_ip",
+			policies:    []interface{}{policy},
+			expectError: "'snat_policy' is only valid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSpokeSnat(tt.mode, tt.policies)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateSpokeGatewaySnat(t *testing.T) {
+	tests := []struct {
+		name          string
+		oldMode       string
+		newMode       string
+		policyChanged bool
+		expectedCalls []string
+	}{
+		{
+			name:          "enable single_ip",
+			newMode:       "single_ip",
+			expectedCalls: []string{"enable_snat"},
+		},
+		{
+			name:          "enable customized",
+			newMode:       "customized",
+			policyChanged: true,
+			expectedCalls: []string{"edit_gw_customized_snat_config"},
+		},
+		{
+			name:          "single_ip to customized",
+			oldMode:       "single_ip",
+			newMode:       "customized",
+			expectedCalls: []string{"disable_snat", "edit_gw_customized_snat_config"},
+		},
+		{
+			name:          "customized to single_ip",
+			oldMode:       "customized",
+			newMode:       "single_ip",
+			expectedCalls: []string{"edit_gw_customized_snat_config", "enable_snat"},
+		},
+		{
+			name:          "update customized policies",
+			oldMode:       "customized",
+			newMode:       "customized",
+			policyChanged: true,
+			expectedCalls: []string{"edit_gw_customized_snat_config"},
+		},
+		{
+			name:          "disable customized",
+			oldMode:       "customized",
+			expectedCalls: []string{"edit_gw_customized_snat_config"},
+		},
+		{
+			name:    "no change",
+			oldMode: "single_ip",
+			newMode: "single_ip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: `{"return": true, "results": "", "reason": ""}`}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			policies := []goaviatrix.PolicyRule{{SrcIP: "10.0.0.0/16", NewSrcIP: "10.0.1.10", Protocol: "all"}}
+
+			err := updateSpokeGatewaySnat(client, "spoke-gw", goaviatrix.AWS, tt.oldMode, tt.newMode, policies, tt.policyChanged)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, transport.actions)
+		})
+	}
+}
+
+func TestReadSpokeGatewaySnat(t *testing.T) {
+	tests := []struct {
+		name             string
+		snatMode         string
+		gw               *goaviatrix.Gateway
+		expectedSingleIP bool
+		expectedMode     string
+		expectedPolicies int
+	}{
+		{
+			name:             "single_ip_snat",
+			gw:               &goaviatrix.Gateway{EnableNat: "yes", NatEnabled: true, SnatMode: "primary"},
+			expectedSingleIP: true,
+		},
+		{
+			name:     "customized SNAT not managed by snat_mode",
+			gw:       &goaviatrix.Gateway{NatEnabled: true, SnatMode: "customized"},
+			snatMode: "",
+		},
+		{
+			name:         "single_ip mode",
+			snatMode:     "single_ip",
+			gw:           &goaviatrix.Gateway{EnableNat: "yes", NatEnabled: true, SnatMode: "primary"},
+			expectedMode: "single_ip",
+		},
+		{
+			name:             "customized mode",
+			snatMode:         "customized",
+			gw:               &goaviatrix.Gateway{NatEnabled: true, SnatMode: "customized"},
+			expectedMode:     "customized",
+			expectedPolicies: 2,
+		},
+		{
+			name:     "SNAT disabled outside of Terraform",
+			snatMode: "customized",
+			gw:       &goaviatrix.Gateway{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				body: `{"return": true, "results": {"vpc_name": "spoke-gw", "snat_ip_port_list": [
+					{"src_ip": "10.0.0.0/16", "new_src_ip": "10.0.1.10", "protocol": "all", "connection": "None"},
+					{"src_ip": "10.0.0.0/16", "new_src_ip": "10.0.1.10", "protocol": "all", "connection": "None"},
+					{"src_ip": "10.1.0.0/16", "new_src_ip": "10.0.1.11", "protocol": "tcp", "connection": "None"}]}, "reason": ""}`,
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":   "spoke-gw",
+				"snat_mode": tt.snatMode,
+			})
+			tt.gw.GwName = "spoke-gw"
+
+			err := readSpokeGatewaySnat(d, client, tt.gw)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSingleIP, getBool(d, "single_ip_snat"))
+			assert.Equal(t, tt.expectedMode, getString(d, "snat_mode"))
+			assert.Len(t, getList(d, "snat_policy"), tt.expectedPolicies)
+		})
+	}
+}
+
+func TestValidateSpokeDefaultEgressAction(t *testing.T) {
+	tests := []struct {
+		name        string
+		cloudType   int
+		action      string
+		expectError bool
+	}{
+		{
+			name:      "deny on AWS",
+			cloudType: goaviatrix.AWS,
+			action:    "deny",
+		},
+		{
+			name:      "deny on Azure",
+			cloudType: goaviatrix.AzureGov,
+			action:    "deny",
+		},
+		{
+			name:      "deny on GCP",
+			cloudType: goaviatrix.GCP,
+			action:    "deny",
+		},
+		{
+			name:        "deny on OCI",
+			cloudType:   goaviatrix.OCI,
+			action:      "deny",
+			expectError: true,
+		},
+		{
+			name:      "allow on OCI",
+			cloudType: goaviatrix.OCI,
+			action:    "allow",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSpokeDefaultEgressAction(tt.cloudType, tt.action)
+			if tt.expectError {
+				assert.ErrorContains(t, err, "is only supported for")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSpokeBgpRouteMaps(t *testing.T) {
+	tests := []struct {
+		name           string
+		enableBgp      bool
+		importRouteMap string
+		exportRouteMap string
+		expectCalls    []string
+		expectErr      string
+	}{
+		{
+			name:        "no route maps",
+			expectCalls: nil,
+		},
+		{
+			name:           "existing route maps",
+			enableBgp:      true,
+			importRouteMap: "import-rm",
+			exportRouteMap: "export-rm",
+			expectCalls:    []string{"list_bgp_route_maps"},
+		},
+		{
+			name:           "missing route map",
+			enableBgp:      true,
+			exportRouteMap: "unknown-rm",
+			expectCalls:    []string{"list_bgp_route_maps"},
+			expectErr:      `BGP route map "unknown-rm" does not exist`,
+		},
+		{
+			name:           "BGP disabled",
+			importRouteMap: "import-rm",
+			expectErr:      "not supported for Non-BGP Spoke Gateways",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				body: `{"return": true, "results": [{"name": "import-rm"}, {"name": "export-rm"}], "reason": ""}`,
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := validateSpokeBgpRouteMaps(client, tt.enableBgp, tt.importRouteMap, tt.exportRouteMap)
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectCalls, transport.actions)
+		})
+	}
+}
+
+func TestReadSpokeGatewaySnatPublicIP(t *testing.T) {
+	tests := []struct {
+		name        string
+		gw          goaviatrix.Gateway
+		expected    string
+		expectCalls []string
+	}{
+		{
+			name:        "single IP SNAT",
+			gw:          goaviatrix.Gateway{EnableNat: "yes", SnatMode: "primary"},
+			expected:    "203.0.113.10",
+			expectCalls: []string{"get_gateway_snat_public_ip"},
+		},
+		{
+			name:        "customized SNAT",
+			gw:          goaviatrix.Gateway{NatEnabled: true, SnatMode: "custom"},
+			expected:    "203.0.113.10",
+			expectCalls: []string{"get_gateway_snat_public_ip"},
+		},
+		{
+			name:     "SNAT disabled",
+			gw:       goaviatrix.Gateway{EnableNat: "no"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					assert.Equal(t, "spoke-gw", form.Get("gateway_name"))
+					return `{"return": true, "results": {"public_ip": "203.0.113.10"}, "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name": "spoke-gw",
+			})
+			gw := tt.gw
+			gw.GwName = "spoke-gw"
+
+			err := readSpokeGatewaySnatPublicIP(d, client, &gw)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, getString(d, "snat_public_ip"))
+			assert.Equal(t, tt.expectCalls, transport.actions)
+		})
+	}
+}
+
+func TestSetSpokeHaRxQueueSize(t *testing.T) {
+	tests := []struct {
+		name            string
+		manageHaGw      bool
+		haConfigured    bool
+		haExists        bool
+		expectedActions []string
+		expectError     string
+	}{
+		{
+			name:            "managed HA gateway",
+			manageHaGw:      true,
+			haConfigured:    true,
+			haExists:        true,
+			expectedActions: []string{"list_vpcs_summary", "set_rx_queue_size"},
+		},
+		{
+			name:            "managed HA gateway missing",
+			manageHaGw:      true,
+			haConfigured:    true,
+			expectedActions: []string{"list_vpcs_summary"},
+			expectError:     "spoke ha gateway spoke-gw-hagw is configured with 'ha_subnet' or 'ha_zone' but does not exist",
+		},
+		{
+			name:       "no HA configured",
+			manageHaGw: true,
+		},
+		{
+			name:     "HA managed by aviatrix_spoke_ha_gateway",
+			haExists: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					assert.Equal(t, "spoke-gw-hagw", form.Get("gateway_name"))
+					if form.Get("action") == "list_vpcs_summary" && tt.haExists {
+						return `{"return": true, "results": [{"vpc_name": "spoke-gw-hagw"}], "reason": ""}`
+					}
+					if form.Get("action") == "set_rx_queue_size" {
+						assert.Equal(t, "4K", form.Get("rx_queue_size"))
+					}
+					return `{"return": true, "results": [], "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := setSpokeHaRxQueueSize(client, "spoke-gw", "4K", tt.manageHaGw, tt.haConfigured)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReadSpokeGatewayAdminSSH(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled %t", enabled), func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					assert.Equal(t, "spoke-gw", form.Get("gateway_name"))
+					return fmt.Sprintf(`{"return": true, "results": {"enabled": %t}, "reason": ""}`, enabled)
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":          "spoke-gw",
+				"enable_admin_ssh": !enabled,
+			})
+
+			readSpokeGatewayAdminSSH(d, client, "spoke-gw")
+			assert.Equal(t, enabled, getBool(d, "enable_admin_ssh"))
+			assert.Equal(t, []string{"show_gateway_admin_ssh"}, transport.actions)
+		})
+	}
+}
+
+func TestReadSpokeGatewayAdminSSHError(t *testing.T) {
+	transport := &fakeControllerTransport{body: `{"return": false, "reason": "gateway not found"}`}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name":          "spoke-gw",
+		"enable_admin_ssh": false,
+	})
+
+	readSpokeGatewayAdminSSH(d, client, "spoke-gw")
+	assert.Equal(t, []string{"show_gateway_admin_ssh"}, transport.actions)
+	assert.False(t, getBool(d, "enable_admin_ssh"))
+}
+
+func TestReadSpokeGatewaySubnetIsPublic(t *testing.T) {
+	tests := []struct {
+		name        string
+		cloudType   int
+		response    string
+		expected    bool
+		expectCalls []string
+	}{
+		{
+			name:      "public subnet",
+			cloudType: goaviatrix.AWS,
+			response: `{"return": true, "results": {"route_table_id": "rtb-0a1b2c3d", "routes": [
+				{"destination": "10.0.0.0/16", "target": "local"},
+				{"destination": "0.0.0.0/0", "target": "igw-0a1b2c3d"}]}, "reason": ""}`,
+			expected:    true,
+			expectCalls: []string{"get_subnet_route_table"},
+		},
+		{
+			name:      "private subnet",
+			cloudType: goaviatrix.AWS,
+			response: `{"return": true, "results": {"route_table_id": "rtb-0a1b2c3e", "routes": [
+				{"destination": "10.0.0.0/16", "target": "local"},
+				{"destination": "0.0.0.0/0", "target": "nat-0a1b2c3d"}]}, "reason": ""}`,
+			expected:    false,
+			expectCalls: []string{"get_subnet_route_table"},
+		},
+		{
+			name:        "lookup failure keeps last known value",
+			cloudType:   goaviatrix.AWS,
+			response:    `{"return": false, "reason": "subnet not found"}`,
+			expected:    true,
+			expectCalls: []string{"get_subnet_route_table"},
+		},
+		{
+			name:      "non AWS gateway",
+			cloudType: goaviatrix.Azure,
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					assert.Equal(t, "vpc-0a1b2c3d", form.Get("vpc_id"))
+					assert.Equal(t, "10.0.1.0/24", form.Get("subnet_cidr"))
+					return tt.response
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name": "spoke-gw",
+			})
+			mustSet(d, "subnet_is_public", true)
+			gw := &goaviatrix.Gateway{
+				GwName:      "spoke-gw",
+				CloudType:   tt.cloudType,
+				AccountName: "aws-account",
+				VpcRegion:   "us-west-2",
+				VpcID:       "vpc-0a1b2c3d~~spoke-vpc",
+				VpcNet:      "10.0.1.0/24",
+			}
+
+			readSpokeGatewaySubnetIsPublic(d, client, gw)
+			assert.Equal(t, tt.expected, d.Get("subnet_is_public"))
+			assert.Equal(t, tt.expectCalls, transport.actions)
+		})
+	}
+}
+
+func TestReadSpokeGatewayAttachedRouteTables(t *testing.T) {
+	tests := []struct {
+		name        string
+		cloudType   int
+		response    string
+		expected    []interface{}
+		expectCalls []string
+	}{
+		{
+			name:        "AWS gateway",
+			cloudType:   goaviatrix.AWS,
+			response:    `{"return": true, "results": {"route_table_ids": ["rtb-0a1b2c3f", "rtb-0a1b2c3d", "rtb-0a1b2c3e"]}, "reason": ""}`,
+			expected:    []interface{}{"rtb-0a1b2c3d", "rtb-0a1b2c3e", "rtb-0a1b2c3f"},
+			expectCalls: []string{"list_gateway_attached_route_tables"},
+		},
+		{
+			name:        "lookup failure keeps last known value",
+			cloudType:   goaviatrix.AWS,
+			response:    `{"return": false, "reason": "gateway is down"}`,
+			expected:    []interface{}{"rtb-00000000"},
+			expectCalls: []string{"list_gateway_attached_route_tables"},
+		},
+		{
+			name:      "non AWS gateway",
+			cloudType: goaviatrix.Azure,
+			expected:  []interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					assert.Equal(t, "spoke-gw", form.Get("gateway_name"))
+					return tt.response
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name": "spoke-gw",
+			})
+			mustSet(d, "attached_route_table_ids", []string{"rtb-00000000"})
+			gw := &goaviatrix.Gateway{GwName: "spoke-gw", CloudType: tt.cloudType}
+
+			readSpokeGatewayAttachedRouteTables(d, client, gw)
+			assert.Equal(t, tt.expected, d.Get("attached_route_table_ids"))
+			assert.Equal(t, tt.expectCalls, transport.actions)
+		})
+	}
+}
+
+func TestExpandSpokeCustomizedRoutes(t *testing.T) {
+	tests := []struct {
+		name        string
+		routes      []interface{}
+		expected    []goaviatrix.CustomizedRoute
+		expectError string
+	}{
+		{
+			name: "sorted by CIDR",
+			routes: []interface{}{
+				map[string]interface{}{"cidr": "10.1.0.0/16", "next_hop": "10.10.0.6"},
+				map[string]interface{}{"cidr": "10.0.0.0/16", "next_hop": "10.10.0.5"},
+			},
+			expected: []goaviatrix.CustomizedRoute{
+				{Cidr: "10.0.0.0/16", NextHop: "10.10.0.5"},
+				{Cidr: "10.1.0.0/16", NextHop: "10.10.0.6"},
+			},
+		},
+		{
+			name: "duplicate CIDR",
+			routes: []interface{}{
+				map[string]interface{}{"cidr": "10.0.0.0/16", "next_hop": "10.10.0.5"},
+				map[string]interface{}{"cidr": "10.0.0.0/16", "next_hop": "10.10.0.6"},
+			},
+			expectError: "listed more than once",
+		},
+		{
+			name: "no routes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":           "spoke-gw",
+				"customized_routes": tt.routes,
+			})
+
+			routes, err := expandSpokeCustomizedRoutes(d)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, routes)
+		})
+	}
+}
+
+func TestSpokeCustomizedRoutesConflict(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cloud_type":                  goaviatrix.AWS,
+		"account_name":                "aws-account",
+		"gw_name":                     "spoke-gw",
+		"vpc_id":                      "vpc-0a1b2c3d",
+		"vpc_reg":                     "us-west-2",
+		"gw_size":                     "t3.small",
+		"subnet":                      "10.0.1.0/24",
+		"customized_spoke_vpc_routes": "10.0.0.0/16",
+		"customized_routes": []interface{}{
+			map[string]interface{}{"cidr": "10.1.0.0/16", "next_hop": "10.10.0.6"},
+		},
+	})
+
+	diags := resourceAviatrixSpokeGateway().Validate(config)
+	assert.Len(t, diags, 2)
+	for _, d := range diags {
+		assert.Equal(t, "Conflicting configuration arguments", d.Summary)
+	}
+}
+
+func TestSpokeCustomizedRoutesValidation(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cloud_type":   goaviatrix.AWS,
+		"account_name": "aws-account",
+		"gw_name":      "spoke-gw",
+		"vpc_id":       "vpc-0a1b2c3d",
+		"vpc_reg":      "us-west-2",
+		"gw_size":      "t3.small",
+		"subnet":       "10.0.1.0/24",
+		"customized_routes": []interface{}{
+			map[string]interface{}{"cidr": "10.1.0.0", "next_hop": "next-hop"},
+		},
+	})
+
+	diags := resourceAviatrixSpokeGateway().Validate(config)
+	assert.Len(t, diags, 2)
+}
+
+func TestSpokeMaintenanceWindowValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		window      map[string]interface{}
+		expectError string
+	}{
+		{
+			name:   "valid window",
+			window: map[string]interface{}{"day_of_week": "Sunday", "hour": 3},
+		},
+		{
+			name:        "invalid day",
+			window:      map[string]interface{}{"day_of_week": "sunday", "hour": 3},
+			expectError: "expected maintenance_window.0.day_of_week to be one of",
+		},
+		{
+			name:        "invalid hour",
+			window:      map[string]interface{}{"day_of_week": "Sunday", "hour": 24},
+			expectError: "expected maintenance_window.0.hour to be in the range (0 - 23)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"cloud_type":         goaviatrix.AWS,
+				"account_name":       "aws-account",
+				"gw_name":            "spoke-gw",
+				"vpc_id":             "vpc-0a1b2c3d",
+				"vpc_reg":            "us-west-2",
+				"gw_size":            "t3.small",
+				"subnet":             "10.0.1.0/24",
+				"maintenance_window": []interface{}{tt.window},
+			})
+
+			diags := resourceAviatrixSpokeGateway().Validate(config)
+			if tt.expectError != "" {
+				assert.True(t, diags.HasError())
+				assert.Contains(t, fmt.Sprint(diags), tt.expectError)
+				return
+			}
+			assert.False(t, diags.HasError(), "%v", diags)
+		})
+	}
+}
+
+func TestExpandSpokeMaintenanceWindow(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name":            "spoke-gw",
+		"maintenance_window": []interface{}{map[string]interface{}{"day_of_week": "Sunday", "hour": 3}},
+	})
+	assert.Equal(t, &goaviatrix.GatewayMaintenanceWindow{DayOfWeek: "Sunday", Hour: 3}, expandSpokeMaintenanceWindow(d))
+
+	d = schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+	})
+	assert.Nil(t, expandSpokeMaintenanceWindow(d))
+}
+
+func TestReadSpokeGatewayMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected []interface{}
+	}{
+		{
+			name:     "window registered",
+			response: `{"return": true, "results": {"day_of_week": "Sunday", "hour": 3}, "reason": ""}`,
+			expected: []interface{}{map[string]interface{}{"day_of_week": "Sunday", "hour": 3}},
+		},
+		{
+			name:     "window removed on the controller",
+			response: `{"return": true, "results": {}, "reason": ""}`,
+			expected: []interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: tt.response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":            "spoke-gw",
+				"maintenance_window": []interface{}{map[string]interface{}{"day_of_week": "Monday", "hour": 1}},
+			})
+
+			err := readSpokeGatewayMaintenanceWindow(d, client, "spoke-gw")
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"get_gateway_maintenance_window"}, transport.actions)
+			assert.Equal(t, tt.expected, getList(d, "maintenance_window"))
+		})
+	}
+}
+
+func TestValidateSpokeBgp(t *testing.T) {
+	tests := []struct {
+		name        string
+		cloudType   int
+		enable      bool
+		expectError bool
+	}{
+		{name: "AWS enabled", cloudType: goaviatrix.AWS, enable: true},
+		{name: "GCP enabled", cloudType: goaviatrix.GCP, enable: true},
+		{name: "Azure enabled", cloudType: goaviatrix.Azure, enable: true},
+		{name: "OCI enabled", cloudType: goaviatrix.OCI, enable: true},
+		{name: "AWSGov enabled", cloudType: goaviatrix.AWSGov, enable: true, expectError: true},
+		{name: "Alibaba Cloud enabled", cloudType: goaviatrix.AliCloud, enable: true, expectError: true},
+		{name: "Alibaba Cloud disabled", cloudType: goaviatrix.AliCloud},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSpokeBgp(tt.cloudType, tt.enable)
+			if tt.expectError {
+				assert.ErrorContains(t, err, "only supported for AWS (1), GCP (4), Azure (8) and OCI (16)")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSpokeVpcDnsServer(t *testing.T) {
+	tests := []struct {
+		name        string
+		cloudType   int
+		enable      bool
+		expectError bool
+	}{
+		{name: "AWS enabled", cloudType: goaviatrix.AWS, enable: true},
+		{name: "Azure enabled", cloudType: goaviatrix.Azure, enable: true},
+		{name: "GCP enabled", cloudType: goaviatrix.GCP, enable: true},
+		{name: "Alibaba Cloud enabled", cloudType: goaviatrix.AliCloud, enable: true},
+		{name: "OCI enabled", cloudType: goaviatrix.OCI, enable: true, expectError: true},
+		{name: "OCI disabled", cloudType: goaviatrix.OCI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSpokeVpcDnsServer(tt.cloudType, tt.enable)
+			if tt.expectError {
+				assert.ErrorContains(t, err, "only supported by AWS (1), GCP (4), Azure (8)")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSpokeHaMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name:   "not set",
+			config: map[string]interface{}{"cloud_type": goaviatrix.GCP},
+		},
+		{
+			name: "AWS active_active",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.AWS,
+				"enable_bgp": true,
+				"ha_subnet":  "10.0.1.0/24",
+				"ha_mode":    "active_active",
+			},
+		},
+		{
+			name: "Azure standby with active-standby",
+			config: map[string]interface{}{
+				"cloud_type":            goaviatrix.Azure,
+				"enable_bgp":            true,
+				"ha_subnet":             "10.0.1.0/24",
+				"enable_active_standby": true,
+				"ha_mode":               "standby",
+			},
+		},
+		{
+			name: "GCP",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.GCP,
+				"enable_bgp": true,
+				"ha_zone":    "us-west1-b",
+				"ha_mode":    "active_active",
+			},
+			expectError: "'ha_mode' is only supported for AWS (1), Azure (8)",
+		},
+		{
+			name: "non-BGP spoke",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.AWS,
+				"ha_subnet":  "10.0.1.0/24",
+				"ha_mode":    "standby",
+			},
+			expectError: "only supported for BGP enabled Spoke Gateways",
+		},
+		{
+			name: "HA disabled",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.AWS,
+				"enable_bgp": true,
+				"ha_mode":    "standby",
+			},
+			expectError: "requires HA to be enabled",
+		},
+		{
+			name: "active_active with active-standby",
+			config: map[string]interface{}{
+				"cloud_type":            goaviatrix.AWS,
+				"enable_bgp":            true,
+				"ha_subnet":             "10.0.1.0/24",
+				"enable_active_standby": true,
+				"ha_mode":               "active_active",
+			},
+			expectError: "can't be 'active_active' when 'enable_active_standby' is true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, tt.config)
+
+			err := validateSpokeHaMode(d)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateSpokeHaMode(t *testing.T) {
+	bgpHa := map[string]interface{}{"enable_bgp": true, "ha_subnet": "10.0.1.0/24", "ha_gw_size": "t3.small"}
+	withHaMode := func(config map[string]interface{}, haMode string) map[string]interface{} {
+		c := map[string]interface{}{"ha_mode": haMode}
+		for k, v := range config {
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := []struct {
+		name           string
+		oldConfig      map[string]interface{}
+		newConfig      map[string]interface{}
+		expectedHaMode string
+	}{
+		{
+			name:           "set active_active",
+			oldConfig:      bgpHa,
+			newConfig:      withHaMode(bgpHa, "active_active"),
+			expectedHaMode: "active_active",
+		},
+		{
+			name:           "removed restores standby",
+			oldConfig:      withHaMode(bgpHa, "active_active"),
+			newConfig:      bgpHa,
+			expectedHaMode: "standby",
+		},
+		{
+			name:      "removed together with HA",
+			oldConfig: withHaMode(bgpHa, "active_active"),
+			newConfig: map[string]interface{}{"enable_bgp": true},
+		},
+		{
+			name:      "unchanged",
+			oldConfig: withHaMode(bgpHa, "active_active"),
+			newConfig: withHaMode(bgpHa, "active_active"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testSpokeGatewayUpdateData(t, tt.oldConfig, tt.newConfig, nil)
+			var haModes []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					haModes = append(haModes, form.Get("ha_mode"))
+					return `{"return": true, "results": "ok", "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := updateSpokeHaMode(d, client)
+			assert.NoError(t, err)
+			if tt.expectedHaMode == "" {
+				assert.Empty(t, transport.actions)
+				return
+			}
+			assert.Equal(t, []string{"set_spoke_gateway_ha_mode"}, transport.actions)
+			assert.Equal(t, []string{tt.expectedHaMode}, haModes)
+		})
+	}
+}
+
+func TestUpdateSpokeGatewayAdminSSH(t *testing.T) {
+	ha := map[string]interface{}{"ha_subnet": "10.0.1.0/24", "ha_gw_size": "t3.small"}
+	withAdminSSH := func(config map[string]interface{}, enable bool) map[string]interface{} {
+		c := map[string]interface{}{"enable_admin_ssh": enable}
+		for k, v := range config {
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := []struct {
+		name            string
+		oldConfig       map[string]interface{}
+		newConfig       map[string]interface{}
+		newHaGwEnabled  bool
+		expectedActions []string
+	}{
+		{
+			name:            "disabled",
+			oldConfig:       withAdminSSH(ha, true),
+			newConfig:       withAdminSSH(ha, false),
+			expectedActions: []string{"disable_gateway_admin_ssh test-spoke", "disable_gateway_admin_ssh test-spoke-hagw"},
+		},
+		{
+			name:            "enabled again",
+			oldConfig:       withAdminSSH(ha, false),
+			newConfig:       withAdminSSH(ha, true),
+			expectedActions: []string{"enable_gateway_admin_ssh test-spoke", "enable_gateway_admin_ssh test-spoke-hagw"},
+		},
+		{
+			name:            "disabled without HA",
+			oldConfig:       withAdminSSH(nil, true),
+			newConfig:       withAdminSSH(nil, false),
+			expectedActions: []string{"disable_gateway_admin_ssh test-spoke"},
+		},
+		{
+			name:            "HA gateway created while disabled",
+			oldConfig:       withAdminSSH(nil, false),
+			newConfig:       withAdminSSH(ha, false),
+			newHaGwEnabled:  true,
+			expectedActions: []string{"disable_gateway_admin_ssh test-spoke-hagw"},
+		},
+		{
+			name:           "HA gateway created while enabled",
+			oldConfig:      withAdminSSH(nil, true),
+			newConfig:      withAdminSSH(ha, true),
+			newHaGwEnabled: true,
+		},
+		{
+			name:      "unchanged",
+			oldConfig: withAdminSSH(ha, false),
+			newConfig: withAdminSSH(ha, false),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testSpokeGatewayUpdateData(t, tt.oldConfig, tt.newConfig, nil)
+			var actions []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					actions = append(actions, form.Get("action")+" "+form.Get("gateway_name"))
+					return `{"return": true, "results": "ok", "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := updateSpokeGatewayAdminSSH(d, client, tt.newHaGwEnabled)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedActions, actions)
+		})
+	}
+}
+
+func TestUpdateSpokeBgpNextHopSelf(t *testing.T) {
+	tests := []struct {
+		name            string
+		oldConfig       map[string]interface{}
+		newConfig       map[string]interface{}
+		expectedActions []string
+		expectError     bool
+	}{
+		{
+			name:            "enabled",
+			oldConfig:       map[string]interface{}{"enable_bgp": true},
+			newConfig:       map[string]interface{}{"enable_bgp": true, "bgp_next_hop_self": true},
+			expectedActions: []string{"enable_gateway_bgp_next_hop_self"},
+		},
+		{
+			name:            "disabled",
+			oldConfig:       map[string]interface{}{"enable_bgp": true, "bgp_next_hop_self": true},
+			newConfig:       map[string]interface{}{"enable_bgp": true},
+			expectedActions: []string{"disable_gateway_bgp_next_hop_self"},
+		},
+		{
+			name:        "non-BGP spoke",
+			oldConfig:   map[string]interface{}{},
+			newConfig:   map[string]interface{}{"bgp_next_hop_self": true},
+			expectError: true,
+		},
+		{
+			name:      "unchanged",
+			oldConfig: map[string]interface{}{"enable_bgp": true, "bgp_next_hop_self": true},
+			newConfig: map[string]interface{}{"enable_bgp": true, "bgp_next_hop_self": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testSpokeGatewayUpdateData(t, tt.oldConfig, tt.newConfig, nil)
+			transport := &fakeControllerTransport{body: `{"return": true, "results": "ok", "reason": ""}`}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := updateSpokeBgpNextHopSelf(d, client)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedActions, transport.actions)
+		})
+	}
+}
+
+func TestValidateSpokeCloudRouteTablePropagation(t *testing.T) {
+	tests := []struct {
+		name        string
+		cloudType   int
+		propagate   bool
+		expectError bool
+	}{
+		{name: "AWS enabled", cloudType: goaviatrix.AWS, propagate: true},
+		{name: "AWS disabled", cloudType: goaviatrix.AWS},
+		{name: "Azure disabled", cloudType: goaviatrix.Azure},
+		{name: "AWSGov disabled", cloudType: goaviatrix.AWSGov},
+		{name: "GCP enabled", cloudType: goaviatrix.GCP, propagate: true},
+		{name: "GCP disabled", cloudType: goaviatrix.GCP, expectError: true},
+		{name: "OCI disabled", cloudType: goaviatrix.OCI, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSpokeCloudRouteTablePropagation(tt.cloudType, tt.propagate)
+			if tt.expectError {
+				assert.ErrorContains(t, err, "'propagate_to_cloud_route_tables' can only be disabled")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestResourceAviatrixSpokeGatewayUpdateCloudRouteTablePropagation(t *testing.T) {
+	tests := []struct {
+		name           string
+		oldPropagate   bool
+		newPropagate   bool
+		expectedAction string
+	}{
+		{name: "disable", oldPropagate: true, newPropagate: false, expectedAction: "disable_cloud_route_table_propagation"},
+		{name: "enable", oldPropagate: false, newPropagate: true, expectedAction: "enable_cloud_route_table_propagation"},
+		{name: "unchanged", oldPropagate: true, newPropagate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testSpokeGatewayUpdateData(t,
+				map[string]interface{}{"propagate_to_cloud_route_tables": tt.oldPropagate},
+				map[string]interface{}{"propagate_to_cloud_route_tables": tt.newPropagate},
+				nil)
+			transport := &fakeControllerTransport{body: `{"return": true, "results": "ok", "reason": ""}`}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := updateSpokeCloudRouteTablePropagation(d, client, &goaviatrix.Gateway{GwName: "test-spoke"})
+			assert.NoError(t, err)
+			if tt.expectedAction == "" {
+				assert.Empty(t, transport.actions)
+				return
+			}
+			assert.Equal(t, []string{tt.expectedAction}, transport.actions)
+		})
+	}
+}
+
+// testSpokeGatewayUpdateData returns the ResourceData the update of a spoke gateway sees when its configuration
+// changes from oldConfig to newConfig, both applied on top of a minimal AWS spoke gateway configuration.
+// readBack overrides attributes of the state created from oldConfig, such as values read from the controller.
+func testSpokeGatewayUpdateData(t *testing.T, oldConfig, newConfig map[string]interface{}, readBack map[string]string) *schema.ResourceData {
+	t.Helper()
+	config := func(extra map[string]interface{}) *terraform.ResourceConfig {
+		c := map[string]interface{}{
+			"cloud_type":   goaviatrix.AWS,
+			"account_name": "test-account",
+			"gw_name":      "test-spoke",
+			"gw_size":      "t3.small",
+			"vpc_id":       "vpc-1234",
+			"vpc_reg":      "us-east-1",
+			"subnet":       "10.0.0.0/24",
+		}
+		for k, v := range extra {
+			c[k] = v
+		}
+		return terraform.NewResourceConfigRaw(c)
+	}
+
+	created, err := resourceAviatrixSpokeGateway().Diff(context.Background(), nil, config(oldConfig), nil)
+	assert.NoError(t, err)
+	state := &terraform.InstanceState{ID: "test-spoke", Attributes: map[string]string{}}
+	for k, attr := range created.Attributes {
+		if !attr.NewComputed {
+			state.Attributes[k] = attr.New
+		}
+	}
+	for k, v := range readBack {
+		state.Attributes[k] = v
+	}
+
+	diff, err := resourceAviatrixSpokeGateway().Diff(context.Background(), state, config(newConfig), nil)
+	assert.NoError(t, err)
+	if diff == nil {
+		diff = &terraform.InstanceDiff{}
+	}
+	d, err := schema.InternalMap(resourceAviatrixSpokeGateway().Schema).Data(state, diff)
+	assert.NoError(t, err)
+	return d
+}
+
+func TestReadSpokeGatewayPeeringTunnelNames(t *testing.T) {
+	transport := &fakeControllerTransport{
+		body: `{"return": true, "results": [{"tunnel_name": "spoke-gw--transit-gw-2"},
+			{"tunnel_name": "spoke-gw--transit-gw-1"}], "reason": ""}`,
+	}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+	})
+
+	readSpokeGatewayPeeringTunnelNames(d, client, "spoke-gw")
+	assert.Equal(t, []string{"list_gateway_peering_tunnels"}, transport.actions)
+	assert.Equal(t, []string{"spoke-gw--transit-gw-1", "spoke-gw--transit-gw-2"}, getStringList(d, "peering_tunnel_names"))
+}
+
+func TestReadSpokeGatewayPeeringTunnelNamesError(t *testing.T) {
+	transport := &fakeControllerTransport{body: `{"return": false, "reason": "gateway not found"}`}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+	})
+	mustSet(d, "peering_tunnel_names", []string{"spoke-gw--transit-gw-1"})
+
+	readSpokeGatewayPeeringTunnelNames(d, client, "spoke-gw")
+	assert.Equal(t, []string{"spoke-gw--transit-gw-1"}, getStringList(d, "peering_tunnel_names"))
+}
+
+func TestValidateSpokeGcpPrivateRoutes(t *testing.T) {
+	assert.NoError(t, validateSpokeGcpPrivateRoutes(goaviatrix.GCP, []string{"onprem-route"}))
+	assert.NoError(t, validateSpokeGcpPrivateRoutes(goaviatrix.AWS, nil))
+	assert.ErrorContains(t, validateSpokeGcpPrivateRoutes(goaviatrix.Azure, []string{"onprem-route"}),
+		"'gcp_private_routes' is only supported for GCP (4)")
+}
+
+func TestSetSpokePrivateRoutes(t *testing.T) {
+	tests := []struct {
+		name                string
+		cloudType           int
+		expectedRouteTables []string
+		expectedGcpRoutes   []string
+	}{
+		{
+			name:                "Azure",
+			cloudType:           goaviatrix.Azure,
+			expectedRouteTables: []string{"rtb-1:rg-1"},
+		},
+		{
+			name:              "GCP",
+			cloudType:         goaviatrix.GCP,
+			expectedGcpRoutes: []string{"onprem-route"},
+		},
+		{
+			name:      "AWS",
+			cloudType: goaviatrix.AWS,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name": "spoke-gw",
+			})
+
+			setSpokePrivateRoutes(d, &goaviatrix.Gateway{
+				CloudType:               tt.cloudType,
+				PrivateRouteTableConfig: []string{"rtb-1:rg-1"},
+				GcpPrivateRoutes:        []string{"onprem-route"},
+			})
+			assert.ElementsMatch(t, tt.expectedRouteTables, getStringSet(d, "private_route_table_config"))
+			assert.ElementsMatch(t, tt.expectedGcpRoutes, getStringSet(d, "gcp_private_routes"))
+		})
+	}
+}
+
+func TestReadSpokeGatewayIPv6Operational(t *testing.T) {
+	tests := []struct {
+		name            string
+		enableIPv6      bool
+		response        string
+		expectedActions []string
+		expected        bool
+	}{
+		{
+			name:            "operational",
+			enableIPv6:      true,
+			response:        `{"return": true, "results": {"operational": true}, "reason": ""}`,
+			expectedActions: []string{"get_gateway_ipv6_status"},
+			expected:        true,
+		},
+		{
+			name:            "configured but not operational",
+			enableIPv6:      true,
+			response:        `{"return": true, "results": {"operational": false}, "reason": ""}`,
+			expectedActions: []string{"get_gateway_ipv6_status"},
+		},
+		{
+			name: "IPv6 disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: tt.response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name": "spoke-gw",
+			})
+
+			err := readSpokeGatewayIPv6Operational(d, client, &goaviatrix.Gateway{GwName: "spoke-gw", EnableIPv6: tt.enableIPv6})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			assert.Equal(t, tt.expected, getBool(d, "ipv6_operational"))
+		})
+	}
+}
+
+func TestReadSpokeGatewayEipAllocationID(t *testing.T) {
+	tests := []struct {
+		name            string
+		cloudType       int
+		stateEip        string
+		publicIP        string
+		response        string
+		expectedActions []string
+		expected        string
+	}{
+		{
+			name:            "EIP changed",
+			cloudType:       goaviatrix.AWS,
+			stateEip:        "1.1.1.1",
+			publicIP:        "2.2.2.2",
+			response:        `{"return": true, "results": {"allocation_id": "eipalloc-2"}, "reason": ""}`,
+			expectedActions: []string{"get_gateway_eip_allocation_id"},
+			expected:        "eipalloc-2",
+		},
+		{
+			name:      "EIP unchanged",
+			cloudType: goaviatrix.AWS,
+			stateEip:  "1.1.1.1",
+			publicIP:  "1.1.1.1",
+			expected:  "eipalloc-1",
+		},
+		{
+			name:            "lookup failure keeps last known value",
+			cloudType:       goaviatrix.AWSGov,
+			stateEip:        "1.1.1.1",
+			publicIP:        "2.2.2.2",
+			response:        `{"return": false, "reason": "not found"}`,
+			expectedActions: []string{"get_gateway_eip_allocation_id"},
+			expected:        "eipalloc-1",
+		},
+		{
+			name:      "non AWS gateway",
+			cloudType: goaviatrix.Azure,
+			stateEip:  "1.1.1.1",
+			publicIP:  "1.1.1.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: tt.response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name": "spoke-gw",
+			})
+			mustSet(d, "eip", tt.stateEip)
+			mustSet(d, "eip_allocation_id", "eipalloc-1")
+
+			readSpokeGatewayEipAllocationID(d, client, &goaviatrix.Gateway{GwName: "spoke-gw", CloudType: tt.cloudType, PublicIP: tt.publicIP})
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			assert.Equal(t, tt.expected, getString(d, "eip_allocation_id"))
+		})
+	}
+}
+
+func TestResourceAviatrixSpokeGatewayActiveStandbyFailoverDelay(t *testing.T) {
+	tests := []struct {
+		name           string
+		delay          interface{}
+		expectChange   bool
+		expectedDelays []string
+	}{
+		{
+			name:           "set delay",
+			delay:          30,
+			expectChange:   true,
+			expectedDelays: []string{"30"},
+		},
+		{
+			name: "clear delay keeps controller value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"cloud_type":            goaviatrix.AWS,
+				"account_name":          "test-account",
+				"gw_name":               "test-spoke",
+				"gw_size":               "t3.small",
+				"vpc_id":                "vpc-1234",
+				"vpc_reg":               "us-east-1",
+				"subnet":                "10.0.0.0/24",
+				"enable_bgp":            true,
+				"local_as_number":       "65001",
+				"enable_active_standby": true,
+			}
+			// the state of the gateway as created from the same configuration, with the delay the controller reports
+			created, err := resourceAviatrixSpokeGateway().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			assert.NoError(t, err)
+			state := &terraform.InstanceState{ID: "test-spoke", Attributes: map[string]string{}}
+			for k, attr := range created.Attributes {
+				if !attr.NewComputed {
+					state.Attributes[k] = attr.New
+				}
+			}
+			state.Attributes["active_standby_failover_delay"] = "60"
+			if tt.delay != nil {
+				config["active_standby_failover_delay"] = tt.delay
+			}
+			transport := &fakeControllerTransport{body: `{"return": true, "results": "ok", "reason": ""}`}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			diff, err := resourceAviatrixSpokeGateway().Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+			assert.NoError(t, err)
+			if diff == nil {
+				diff = &terraform.InstanceDiff{}
+			}
+			_, changed := diff.Attributes["active_standby_failover_delay"]
+			assert.Equal(t, tt.expectChange, changed)
+
+			var delays []string
+			transport.respond = func(form url.Values) string {
+				delays = append(delays, form.Get("failover_delay"))
+				return transport.body
+			}
+			d, err := schema.InternalMap(resourceAviatrixSpokeGateway().Schema).Data(state, diff)
+			assert.NoError(t, err)
+			err = updateSpokeActiveStandbyFailoverDelay(d, client)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDelays, delays)
+		})
+	}
+}
+
+func TestUpdateSpokeActiveStandbyFailoverDelayDisabled(t *testing.T) {
+	transport := &fakeControllerTransport{}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name":                       "spoke-gw",
+		"active_standby_failover_delay": 30,
+	})
+
+	err := updateSpokeActiveStandbyFailoverDelay(d, client)
+	assert.ErrorContains(t, err, "with Active-Standby disabled")
+	assert.Empty(t, transport.actions)
+}
+
+func TestReadSpokeGatewayActiveGatewayInstance(t *testing.T) {
+	tests := []struct {
+		name                string
+		enableActiveStandby bool
+		response            string
+		expectedActions     []string
+		expected            string
+	}{
+		{
+			name:                "ha active",
+			enableActiveStandby: true,
+			response:            `{"return": true, "results": {"active_gateway": "spoke-gw-hagw"}, "reason": ""}`,
+			expectedActions:     []string{"show_active_standby_status"},
+			expected:            "ha",
+		},
+		{
+			name:                "primary active",
+			enableActiveStandby: true,
+			response:            `{"return": true, "results": {"active_gateway": "spoke-gw"}, "reason": ""}`,
+			expectedActions:     []string{"show_active_standby_status"},
+			expected:            "primary",
+		},
+		{
+			name:                "lookup failure keeps last known value",
+			enableActiveStandby: true,
+			response:            `{"return": false, "reason": "status unavailable"}`,
+			expectedActions:     []string{"show_active_standby_status"},
+			expected:            "primary",
+		},
+		{
+			name: "active-standby disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: tt.response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name": "spoke-gw",
+			})
+			mustSet(d, "active_gateway_instance", "primary")
+
+			readSpokeGatewayActiveGatewayInstance(d, client, &goaviatrix.Gateway{GwName: "spoke-gw", EnableActiveStandby: tt.enableActiveStandby})
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			assert.Equal(t, tt.expected, getString(d, "active_gateway_instance"))
+		})
+	}
+}
+
+func TestReadSpokeGatewayPrivateIPAllocation(t *testing.T) {
+	transport := &fakeControllerTransport{
+		body: `{"return": true, "results": {"private_ip_cidr": "10.0.1.0/24",
+			"allocated_private_ips": ["10.0.1.11", "10.0.1.10"]}, "reason": ""}`,
+	}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+	})
+
+	readSpokeGatewayPrivateIPAllocation(d, client, "spoke-gw")
+	assert.Equal(t, []string{"get_gateway_private_ip_allocation"}, transport.actions)
+	assert.Equal(t, "10.0.1.0/24", getString(d, "private_ip_cidr"))
+	assert.Equal(t, []string{"10.0.1.10", "10.0.1.11"}, getStringList(d, "allocated_private_ips"))
+}
+
+func TestReadSpokeGatewayPrivateIPAllocationError(t *testing.T) {
+	transport := &fakeControllerTransport{body: `{"return": false, "reason": "gateway not found"}`}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+	})
+
+	mustSet(d, "private_ip_cidr", "10.0.1.0/24")
+	mustSet(d, "allocated_private_ips", []string{"10.0.1.10"})
+
+	readSpokeGatewayPrivateIPAllocation(d, client, "spoke-gw")
+	assert.Equal(t, []string{"get_gateway_private_ip_allocation"}, transport.actions)
+	assert.Equal(t, "10.0.1.0/24", getString(d, "private_ip_cidr"))
+	assert.Equal(t, []string{"10.0.1.10"}, getStringList(d, "allocated_private_ips"))
+}
+
+func TestReadSpokeGatewayExternalBgpPeers(t *testing.T) {
+	transport := &fakeControllerTransport{
+		body: `{"return": true, "results": [{"neighbor_ip": "169.254.0.2", "neighbor_as_num": "65002", "bfd_enabled": true},
+			{"neighbor_ip": "169.254.0.1", "neighbor_as_num": "65001"}], "reason": ""}`,
+	}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+	})
+
+	readSpokeGatewayExternalBgpPeers(d, client, "spoke-gw")
+	assert.Equal(t, []string{"list_gateway_external_bgp_neighbors"}, transport.actions)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"remote_ip": "169.254.0.1", "remote_as": "65001", "bfd_enabled": false},
+		map[string]interface{}{"remote_ip": "169.254.0.2", "remote_as": "65002", "bfd_enabled": true},
+	}, d.Get("external_bgp_peers"))
+}
+
+func TestReadSpokeGatewayExternalBgpPeersError(t *testing.T) {
+	transport := &fakeControllerTransport{body: `{"return": false, "reason": "gateway not found"}`}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+	})
+
+	peers := []map[string]interface{}{{"remote_ip": "169.254.0.1", "remote_as": "65001", "bfd_enabled": false}}
+	mustSet(d, "external_bgp_peers", peers)
+
+	readSpokeGatewayExternalBgpPeers(d, client, "spoke-gw")
+	assert.Equal(t, []string{"list_gateway_external_bgp_neighbors"}, transport.actions)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"remote_ip": "169.254.0.1", "remote_as": "65001", "bfd_enabled": false},
+	}, d.Get("external_bgp_peers"))
+}
+
+func TestReadSpokeGatewayAdvertisedRoutes(t *testing.T) {
+	transport := &fakeControllerTransport{
+		body: `{"return": true, "results": [{"cidr": "10.2.0.0/16"}, {"cidr": "10.1.0.0/16"}], "reason": ""}`,
+	}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+	})
+
+	readSpokeGatewayAdvertisedRoutes(d, client, "spoke-gw")
+	assert.Equal(t, []string{"list_gateway_bgp_advertised_routes"}, transport.actions)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"cidr": "10.1.0.0/16"},
+		map[string]interface{}{"cidr": "10.2.0.0/16"},
+	}, d.Get("advertised_routes"))
+}
+
+func TestReadSpokeGatewayAdvertisedRoutesError(t *testing.T) {
+	transport := &fakeControllerTransport{body: `{"return": false, "reason": "gateway not found"}`}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+	})
+
+	mustSet(d, "advertised_routes", []map[string]interface{}{{"cidr": "10.1.0.0/16"}})
+
+	readSpokeGatewayAdvertisedRoutes(d, client, "spoke-gw")
+	assert.Equal(t, []string{"list_gateway_bgp_advertised_routes"}, transport.actions)
+	assert.Equal(t, []interface{}{map[string]interface{}{"cidr": "10.1.0.0/16"}}, d.Get("advertised_routes"))
+}
+
+func TestResourceAviatrixSpokeGatewayDelete(t *testing.T) {
+	tests := []struct {
+		name               string
+		deletePrimaryFirst bool
+		expectedDeletes    []string
+	}{
+		{
+			name:            "HA gateway first",
+			expectedDeletes: []string{"spoke-gw-hagw", "spoke-gw-hagw", "spoke-gw"},
+		},
+		{
+			name:               "primary gateway first",
+			deletePrimaryFirst: true,
+			expectedDeletes:    []string{"spoke-gw", "spoke-gw", "spoke-gw-hagw"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deletes []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					switch form.Get("action") {
+					case "check_task_status":
+						return `{"return": true, "results": "done", "reason": ""}`
+					case "delete_container":
+						deletes = append(deletes, form.Get("gw_name"))
+						// the first gateway deleted is briefly reported down
+						if len(deletes) == 1 {
+							return `{"return": false, "reason": "Cannot delete gateway when it is down"}`
+						}
+					}
+					return `{"return": true, "results": "request-1", "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid", RetryCount: 1}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"cloud_type":           goaviatrix.AWS,
+				"gw_name":              "spoke-gw",
+				"ha_subnet":            "10.0.1.0/24",
+				"delete_primary_first": tt.deletePrimaryFirst,
+			})
+
+			err := resourceAviatrixSpokeGatewayDelete(d, client)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDeletes, deletes)
+		})
+	}
+}
+
+func testSpokeSnmpBlock(community string, allowedCidrs ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"version":       "v2c",
+		"community":     community,
+		"allowed_cidrs": allowedCidrs,
+	}
+}
+
+func TestSpokeSnmpValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		snmp        map[string]interface{}
+		expectError string
+	}{
+		{
+			name: "valid SNMP",
+			snmp: testSpokeSnmpBlock("monitoring", "10.10.0.0/24"),
+		},
+		{
+			name: "invalid version",
+			snmp: map[string]interface{}{
+				"version":       "v3",
+				"community":     "monitoring",
+				"allowed_cidrs": []interface{}{"10.10.0.0/24"},
+			},
+			expectError: "expected snmp.0.version to be one of",
+		},
+		{
+			name:        "blank community",
+			snmp:        testSpokeSnmpBlock(" ", "10.10.0.0/24"),
+			expectError: "expected \"snmp.0.community\" to not be an empty string or whitespace",
+		},
+		{
+			name:        "invalid CIDR",
+			snmp:        testSpokeSnmpBlock("monitoring", "10.10.0.300/24"),
+			expectError: "invalid CIDR \"10.10.0.300/24\"",
+		},
+		{
+			name:        "host bits set",
+			snmp:        testSpokeSnmpBlock("monitoring", "10.10.0.1/24"),
+			expectError: "has host bits set",
+		},
+		{
+			name: "IPv6 CIDR",
+			snmp: testSpokeSnmpBlock("monitoring", "10.10.0.0/24", "2001:db8::/64"),
+		},
+		{
+			name:        "no allowed CIDRs",
+			snmp:        testSpokeSnmpBlock("monitoring"),
+			expectError: "allowed_cidrs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"cloud_type":   goaviatrix.AWS,
+				"account_name": "aws-account",
+				"gw_name":      "spoke-gw",
+				"vpc_id":       "vpc-0a1b2c3d",
+				"vpc_reg":      "us-west-2",
+				"gw_size":      "t3.small",
+				"subnet":       "10.0.1.0/24",
+				"snmp":         []interface{}{tt.snmp},
+			})
+
+			diags := resourceAviatrixSpokeGateway().Validate(config)
+			if tt.expectError != "" {
+				assert.True(t, diags.HasError())
+				assert.Contains(t, fmt.Sprint(diags), tt.expectError)
+				return
+			}
+			assert.False(t, diags.HasError(), "%v", diags)
+		})
+	}
+}
+
+func TestSpokeCanonicalCIDRValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError string
+	}{
+		{
+			name: "canonical CIDRs",
+			config: map[string]interface{}{
+				"manage_ha_gateway":                false,
+				"ha_gateways":                      []interface{}{map[string]interface{}{"gw_name": "spoke-gw-ha2", "subnet": "10.0.2.0/24", "gw_size": "t3.small"}},
+				"customized_routes":                []interface{}{map[string]interface{}{"cidr": "10.20.0.0/16", "next_hop": "10.0.1.5"}},
+				"firenet_inspection_exclude_cidrs": []interface{}{"10.30.0.0/16"},
+			},
+		},
+		{
+			name: "HA gateway subnet with host bits",
+			config: map[string]interface{}{
+				"manage_ha_gateway": false,
+				"ha_gateways":       []interface{}{map[string]interface{}{"gw_name": "spoke-gw-ha2", "subnet": "10.0.2.1/24", "gw_size": "t3.small"}},
+			},
+			expectError: "invalid ha_gateways.0.subnet: CIDR \"10.0.2.1/24\" has host bits set",
+		},
+		{
+			name: "customized route with host bits",
+			config: map[string]interface{}{
+				"customized_routes": []interface{}{map[string]interface{}{"cidr": "10.20.0.1/16", "next_hop": "10.0.1.5"}},
+			},
+			expectError: "CIDR \"10.20.0.1/16\" has host bits set",
+		},
+		{
+			name: "FireNet exclude CIDR with host bits",
+			config: map[string]interface{}{
+				"firenet_inspection_exclude_cidrs": []interface{}{"10.30.0.1/16"},
+			},
+			expectError: "CIDR \"10.30.0.1/16\" has host bits set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"cloud_type":   goaviatrix.AWS,
+				"account_name": "aws-account",
+				"gw_name":      "spoke-gw",
+				"vpc_id":       "vpc-0a1b2c3d",
+				"vpc_reg":      "us-west-2",
+				"gw_size":      "t3.small",
+				"subnet":       "10.0.1.0/24",
+			}
+			for k, v := range tt.config {
+				raw[k] = v
+			}
+
+			diags := resourceAviatrixSpokeGateway().Validate(terraform.NewResourceConfigRaw(raw))
+			if tt.expectError != "" {
+				assert.True(t, diags.HasError())
+				assert.Contains(t, fmt.Sprint(diags), tt.expectError)
+				return
+			}
+			assert.False(t, diags.HasError(), "%v", diags)
+		})
+	}
+}
+
+func TestSpokeSnmpCommunityIsSensitive(t *testing.T) {
+	snmp := resourceAviatrixSpokeGateway().Schema["snmp"].Elem.(*schema.Resource)
+	assert.True(t, snmp.Schema["community"].Sensitive)
+}
+
+func TestExpandSpokeSnmp(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+		"snmp":    []interface{}{testSpokeSnmpBlock("monitoring", "10.20.0.0/24", "10.10.0.0/24")},
+	})
+	assert.Equal(t, &goaviatrix.GatewaySnmpConfig{
+		Version:      "v2c",
+		Community:    "monitoring",
+		AllowedCidrs: []string{"10.10.0.0/24", "10.20.0.0/24"},
+	}, expandSpokeSnmp(d))
+
+	d = schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "spoke-gw",
+	})
+	assert.Nil(t, expandSpokeSnmp(d))
+}
+
+func TestReadSpokeGatewaySnmp(t *testing.T) {
+	tests := []struct {
+		name              string
+		response          string
+		expectedCommunity string
+		expectedCidrs     []string
+		expectedEmpty     bool
+	}{
+		{
+			name: "community reported by the controller",
+			response: `{"return": true, "results": {"enabled": true, "version": "v2c", "community": "rotated",
+				"allowed_cidrs": ["10.30.0.0/24"]}, "reason": ""}`,
+			expectedCommunity: "rotated",
+			expectedCidrs:     []string{"10.30.0.0/24"},
+		},
+		{
+			name: "community withheld by the controller",
+			response: `{"return": true, "results": {"enabled": true, "version": "v2c",
+				"allowed_cidrs": ["10.10.0.0/24"]}, "reason": ""}`,
+			expectedCommunity: "monitoring",
+			expectedCidrs:     []string{"10.10.0.0/24"},
+		},
+		{
+			name:          "SNMP disabled on the controller",
+			response:      `{"return": true, "results": {"enabled": false}, "reason": ""}`,
+			expectedEmpty: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: tt.response}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name": "spoke-gw",
+				"snmp":    []interface{}{testSpokeSnmpBlock("monitoring", "10.10.0.0/24")},
+			})
+
+			err := readSpokeGatewaySnmp(d, client, "spoke-gw")
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"get_gateway_snmp_config"}, transport.actions)
+			if tt.expectedEmpty {
+				assert.Empty(t, getList(d, "snmp"))
+				return
+			}
+			snmp := expandSpokeSnmp(d)
+			assert.Equal(t, "v2c", snmp.Version)
+			assert.Equal(t, tt.expectedCommunity, snmp.Community)
+			assert.Equal(t, tt.expectedCidrs, snmp.AllowedCidrs)
+		})
+	}
+}
+
+func TestSpokeRouteListsConflict(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cloud_type":                            goaviatrix.AWS,
+		"account_name":                          "aws-account",
+		"gw_name":                               "spoke-gw",
+		"vpc_id":                                "vpc-0a1b2c3d",
+		"vpc_reg":                               "us-west-2",
+		"gw_size":                               "t3.small",
+		"subnet":                                "10.0.1.0/24",
+		"filtered_spoke_vpc_routes":             "10.0.0.0/16",
+		"filtered_spoke_vpc_routes_list":        []interface{}{"10.1.0.0/16"},
+		"included_advertised_spoke_routes_list": []interface{}{"10.2.0.0/16"},
+	})
+
+	diags := resourceAviatrixSpokeGateway().Validate(config)
+	var conflicts int
+	for _, d := range diags {
+		if d.Summary == "Conflicting configuration arguments" {
+			conflicts++
+		}
+	}
+	assert.Equal(t, 2, conflicts)
+}
+
+func TestGetSpokeRoutes(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected []string
+	}{
+		{
+			name:     "string attribute",
+			config:   map[string]interface{}{"filtered_spoke_vpc_routes": "10.1.0.0/16,10.0.0.0/16"},
+			expected: []string{"10.1.0.0/16", "10.0.0.0/16"},
+		},
+		{
+			name:     "string attribute with spaces",
+			config:   map[string]interface{}{"filtered_spoke_vpc_routes": " 10.1.0.0/16, 10.0.0.0/16 "},
+			expected: []string{"10.1.0.0/16", "10.0.0.0/16"},
+		},
+		{
+			name:     "set attribute",
+			config:   map[string]interface{}{"filtered_spoke_vpc_routes_list": []interface{}{"10.1.0.0/16", "10.0.0.0/16"}},
+			expected: []string{"10.0.0.0/16", "10.1.0.0/16"},
+		},
+		{
+			name:   "not configured",
+			config: map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, tt.config)
+			assert.Equal(t, tt.expected, getSpokeRoutes(d, "filtered_spoke_vpc_routes"))
+		})
+	}
+}
+
+func TestSetSpokeRoutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         map[string]interface{}
+		routes         []string
+		expectedString string
+		expectedList   []string
+	}{
+		{
+			name:           "string attribute with same routes keeps its order",
+			config:         map[string]interface{}{"filtered_spoke_vpc_routes": "10.1.0.0/16,10.0.0.0/16"},
+			routes:         []string{"10.0.0.0/16", "10.1.0.0/16"},
+			expectedString: "10.1.0.0/16,10.0.0.0/16",
+		},
+		{
+			name:           "string attribute with spaces and same routes is kept",
+			config:         map[string]interface{}{"filtered_spoke_vpc_routes": "10.1.0.0/16, 10.0.0.0/16"},
+			routes:         []string{"10.0.0.0/16", "10.1.0.0/16"},
+			expectedString: "10.1.0.0/16, 10.0.0.0/16",
+		},
+		{
+			name:           "string attribute with drifted routes",
+			config:         map[string]interface{}{"filtered_spoke_vpc_routes": "10.1.0.0/16"},
+			routes:         []string{"10.0.0.0/16", "10.2.0.0/16"},
+			expectedString: "10.0.0.0/16,10.2.0.0/16",
+		},
+		{
+			name:           "string attribute with routes removed",
+			config:         map[string]interface{}{"filtered_spoke_vpc_routes": "10.1.0.0/16"},
+			expectedString: "",
+		},
+		{
+			name:         "set attribute",
+			config:       map[string]interface{}{"filtered_spoke_vpc_routes_list": []interface{}{"10.1.0.0/16"}},
+			routes:       []string{"10.1.0.0/16", "10.0.0.0/16"},
+			expectedList: []string{"10.0.0.0/16", "10.1.0.0/16"},
+		},
+		{
+			name:         "import",
+			config:       map[string]interface{}{},
+			routes:       []string{"10.0.0.0/16"},
+			expectedList: []string{"10.0.0.0/16"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, tt.config)
+
+			setSpokeRoutes(d, "filtered_spoke_vpc_routes", tt.routes)
+			assert.Equal(t, tt.expectedString, getString(d, "filtered_spoke_vpc_routes"))
+			assert.ElementsMatch(t, tt.expectedList, getStringSet(d, "filtered_spoke_vpc_routes_list"))
+		})
+	}
+}
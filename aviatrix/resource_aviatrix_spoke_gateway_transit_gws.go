@@ -0,0 +1,177 @@
+package aviatrix
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+// validateSpokeTransitGws checks that every transit gateway is listed at most once in attach_to_transit_gws.
+func validateSpokeTransitGws(transitGwNames []string) error {
+	seen := make(map[string]bool, len(transitGwNames))
+	for _, name := range transitGwNames {
+		if seen[name] {
+			return fmt.Errorf("transit gateway %q is listed more than once in 'attach_to_transit_gws'", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// reconcileSpokeTransitGws detaches the spoke gateway from the transit gateways only in oldTransitGwNames,
+// attaches it to the transit gateways only in newTransitGwNames in list order, and then sets the failover
+// priority to the order of newTransitGwNames.
+func reconcileSpokeTransitGws(client *goaviatrix.Client, spokeGwName string, oldTransitGwNames, newTransitGwNames []string) error {
+	kept := make(map[string]bool, len(newTransitGwNames))
+	for _, name := range newTransitGwNames {
+		kept[name] = true
+	}
+	attached := make(map[string]bool, len(oldTransitGwNames))
+	for _, name := range oldTransitGwNames {
+		attached[name] = true
+		if kept[name] {
+			continue
+		}
+		err := client.SpokeLeaveTransit(&goaviatrix.SpokeVpc{GwName: spokeGwName, TransitGateway: name})
+		if err != nil {
+			return fmt.Errorf("failed to detach spoke gateway %s from transit gateway %s: %w", spokeGwName, name, err)
+		}
+	}
+	for _, name := range newTransitGwNames {
+		if attached[name] {
+			continue
+		}
+		err := client.SpokeJoinTransit(&goaviatrix.SpokeVpc{GwName: spokeGwName, TransitGateway: name})
+		if err != nil {
+			return fmt.Errorf("failed to attach spoke gateway %s to transit gateway %s: %w", spokeGwName, name, err)
+		}
+	}
+	if len(newTransitGwNames) == 0 {
+		return nil
+	}
+	if err := client.SetSpokeTransitFailoverPriority(spokeGwName, newTransitGwNames); err != nil {
+		return fmt.Errorf("failed to set transit failover priority of spoke gateway %s: %w", spokeGwName, err)
+	}
+	return nil
+}
+
+// validateSpokeTransitRouteTable checks that routeTable exists on all the given transit gateways. An empty
+// routeTable resets the association and needs no check.
+func validateSpokeTransitRouteTable(client *goaviatrix.Client, transitGwNames []string, routeTable string) error {
+	if routeTable == "" {
+		return nil
+	}
+	for _, name := range transitGwNames {
+		routeTables, err := client.ListTransitRouteTables(name)
+		if err != nil {
+			return fmt.Errorf("could not list route tables of transit gateway %s: %w", name, err)
+		}
+		if !goaviatrix.Contains(routeTables, routeTable) {
+			return fmt.Errorf("route table %q does not exist on transit gateway %s", routeTable, name)
+		}
+	}
+	return nil
+}
+
+// applySpokeTransitRouteTable associates the attachments of the spoke gateway to the given transit gateways with
+// routeTable. Check the route table with validateSpokeTransitRouteTable first.
+func applySpokeTransitRouteTable(client *goaviatrix.Client, spokeGwName string, transitGwNames []string, routeTable string) error {
+	for _, name := range transitGwNames {
+		if err := client.SetSpokeTransitRouteTable(spokeGwName, name, routeTable); err != nil {
+			return fmt.Errorf("failed to associate spoke gateway %s with route table %q of transit gateway %s: %w", spokeGwName, routeTable, name, err)
+		}
+	}
+	return nil
+}
+
+// readSpokeTransitGws refreshes attach_to_transit_gws. It is only read back when it is set, so that spoke
+// gateways attached with aviatrix_spoke_transit_attachment do not show a diff. transit_route_table is read
+// from the preferred transit gateway.
+func readSpokeTransitGws(d *schema.ResourceData, client *goaviatrix.Client) error {
+	if len(getStringList(d, "attach_to_transit_gws")) == 0 {
+		return nil
+	}
+	gwName := getString(d, "gw_name")
+	transitGwNames, err := client.GetSpokeTransitFailoverPriority(gwName)
+	if err != nil {
+		return fmt.Errorf("could not get transit failover priority of spoke gateway %s: %w", gwName, err)
+	}
+	mustSet(d, "attach_to_transit_gws", transitGwNames)
+
+	if getString(d, "transit_route_table") == "" || len(transitGwNames) == 0 {
+		return nil
+	}
+	routeTable, err := client.GetSpokeTransitRouteTable(gwName, transitGwNames[0])
+	if err != nil {
+		return fmt.Errorf("could not get transit route table of spoke gateway %s: %w", gwName, err)
+	}
+	mustSet(d, "transit_route_table", routeTable)
+	return nil
+}
+
+// spokeFireNetTransitAttached returns whether the spoke gateway is attached to at least one transit gateway
+// with FireNet enabled. The transit gateways are not read from the cached gateway list, since FireNet may have
+// been enabled on them earlier in the same apply.
+func spokeFireNetTransitAttached(client *goaviatrix.Client, gw *goaviatrix.Gateway) (bool, error) {
+	for _, name := range strings.Split(gw.TransitGwName, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		transitGw, err := client.GetGateway(&goaviatrix.Gateway{GwName: name})
+		if err != nil {
+			return false, fmt.Errorf("could not get transit gateway %s of spoke gateway %s: %w", name, gw.GwName, err)
+		}
+		if transitGw.EnableFirenet || transitGw.EnableTransitFirenet {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// applySpokeFireNetInspectionExcludeCidrs sets firenet_inspection_exclude_cidrs on the spoke gateway. Exclude
+// CIDRs can only be set while the spoke gateway is attached to a FireNet enabled transit gateway.
+func applySpokeFireNetInspectionExcludeCidrs(d *schema.ResourceData, client *goaviatrix.Client) error {
+	gwName := getString(d, "gw_name")
+	cidrs := expandStringSet(getSet(d, "firenet_inspection_exclude_cidrs"))
+	if len(cidrs) != 0 {
+		gw, err := client.GetGateway(&goaviatrix.Gateway{GwName: gwName})
+		if err != nil {
+			return fmt.Errorf("could not get spoke gateway %s: %w", gwName, err)
+		}
+		attached, err := spokeFireNetTransitAttached(client, gw)
+		if err != nil {
+			return err
+		}
+		if !attached {
+			return fmt.Errorf("'firenet_inspection_exclude_cidrs' requires the spoke gateway to be attached to a FireNet enabled transit gateway")
+		}
+	}
+	err := client.SetSpokeFireNetInspectionExcludeCidrs(&goaviatrix.SpokeVpc{GwName: gwName}, cidrs)
+	if err != nil {
+		return fmt.Errorf("could not set FireNet inspection exclude CIDRs of spoke gateway %s: %w", gwName, err)
+	}
+	return nil
+}
+
+// readSpokeFireNetInspectionExcludeCidrs sets firenet_inspection_exclude_cidrs, which is only read back while
+// the spoke gateway is attached to a FireNet enabled transit gateway.
+func readSpokeFireNetInspectionExcludeCidrs(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) error {
+	attached, err := spokeFireNetTransitAttached(client, gw)
+	if err != nil {
+		return err
+	}
+	if !attached {
+		mustSet(d, "firenet_inspection_exclude_cidrs", nil)
+		return nil
+	}
+	cidrs, err := client.GetSpokeFireNetInspectionExcludeCidrs(&goaviatrix.SpokeVpc{GwName: gw.GwName})
+	if err != nil {
+		return fmt.Errorf("could not get FireNet inspection exclude CIDRs of spoke gateway %s: %w", gw.GwName, err)
+	}
+	mustSet(d, "firenet_inspection_exclude_cidrs", cidrs)
+	return nil
+}
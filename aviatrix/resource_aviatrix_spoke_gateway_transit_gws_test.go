@@ -0,0 +1,339 @@
+package aviatrix
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
+)
+
+func TestValidateSpokeTransitGws(t *testing.T) {
+	assert.NoError(t, validateSpokeTransitGws(nil))
+	assert.NoError(t, validateSpokeTransitGws([]string{"transit-gw-2", "transit-gw-1"}))
+	assert.ErrorContains(t, validateSpokeTransitGws([]string{"transit-gw-1", "transit-gw-2", "transit-gw-1"}),
+		`transit gateway "transit-gw-1" is listed more than once`)
+}
+
+func TestReconcileSpokeTransitGws(t *testing.T) {
+	tests := []struct {
+		name          string
+		oldTransitGws []string
+		newTransitGws []string
+		expectedCalls []string
+	}{
+		{
+			name:          "attach in order",
+			newTransitGws: []string{"transit-gw-2", "transit-gw-1"},
+			expectedCalls: []string{
+				"attach_spoke_to_transit_gw transit-gw-2",
+				"attach_spoke_to_transit_gw transit-gw-1",
+				"set_spoke_transit_failover_priority transit-gw-2,transit-gw-1",
+			},
+		},
+		{
+			name:          "reorder",
+			oldTransitGws: []string{"transit-gw-1", "transit-gw-2"},
+			newTransitGws: []string{"transit-gw-2", "transit-gw-1"},
+			expectedCalls: []string{"set_spoke_transit_failover_priority transit-gw-2,transit-gw-1"},
+		},
+		{
+			name:          "replace one transit gateway",
+			oldTransitGws: []string{"transit-gw-1", "transit-gw-2"},
+			newTransitGws: []string{"transit-gw-1", "transit-gw-3"},
+			expectedCalls: []string{
+				"detach_spoke_from_transit_gw transit-gw-2",
+				"attach_spoke_to_transit_gw transit-gw-3",
+				"set_spoke_transit_failover_priority transit-gw-1,transit-gw-3",
+			},
+		},
+		{
+			name:          "detach all",
+			oldTransitGws: []string{"transit-gw-1"},
+			expectedCalls: []string{"detach_spoke_from_transit_gw transit-gw-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					if form.Get("spoke_gw") != "spoke-gw" {
+						t.Errorf("unexpected spoke_gw %q", form.Get("spoke_gw"))
+					}
+					if form.Get("action") == "set_spoke_transit_failover_priority" {
+						calls = append(calls, form.Get("action")+" "+form.Get("transit_gw_list"))
+					} else {
+						calls = append(calls, form.Get("action")+" "+form.Get("transit_gw"))
+					}
+					return `{"return": true, "results": "done", "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := reconcileSpokeTransitGws(client, "spoke-gw", tt.oldTransitGws, tt.newTransitGws)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestReadSpokeTransitGws(t *testing.T) {
+	tests := []struct {
+		name            string
+		transitGws      []interface{}
+		expectedActions []string
+		expected        []string
+	}{
+		{
+			name:            "attach_to_transit_gws set",
+			transitGws:      []interface{}{"transit-gw-1", "transit-gw-2"},
+			expectedActions: []string{"get_spoke_transit_failover_priority"},
+			expected:        []string{"transit-gw-2", "transit-gw-1"},
+		},
+		{
+			name: "attach_to_transit_gws not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				body: `{"return": true, "results": {"transit_gw_list": ["transit-gw-2", "transit-gw-1"]}, "reason": ""}`,
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":               "spoke-gw",
+				"attach_to_transit_gws": tt.transitGws,
+			})
+
+			err := readSpokeTransitGws(d, client)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			assert.Equal(t, tt.expected, getStringList(d, "attach_to_transit_gws"))
+		})
+	}
+}
+
+func TestValidateSpokeTransitRouteTable(t *testing.T) {
+	tests := []struct {
+		name            string
+		routeTable      string
+		expectedActions []string
+		expectError     string
+	}{
+		{
+			name:            "route table exists",
+			routeTable:      "rt-prod",
+			expectedActions: []string{"list_transit_gateway_route_tables", "list_transit_gateway_route_tables"},
+		},
+		{
+			name:            "route table missing",
+			routeTable:      "rt-dev",
+			expectedActions: []string{"list_transit_gateway_route_tables"},
+			expectError:     `route table "rt-dev" does not exist on transit gateway transit-gw-1`,
+		},
+		{
+			name: "reset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{body: `{"return": true, "results": {"route_tables": ["default", "rt-prod"]}, "reason": ""}`}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := validateSpokeTransitRouteTable(client, []string{"transit-gw-1", "transit-gw-2"}, tt.routeTable)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestApplySpokeTransitRouteTable(t *testing.T) {
+	tests := []struct {
+		name          string
+		routeTable    string
+		expectedCalls []string
+	}{
+		{
+			name:       "route table",
+			routeTable: "rt-prod",
+			expectedCalls: []string{
+				"edit_spoke_transit_route_table transit-gw-1 rt-prod",
+				"edit_spoke_transit_route_table transit-gw-2 rt-prod",
+			},
+		},
+		{
+			name: "reset",
+			expectedCalls: []string{
+				"edit_spoke_transit_route_table transit-gw-1 ",
+				"edit_spoke_transit_route_table transit-gw-2 ",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			transport := &fakeControllerTransport{
+				respond: func(form url.Values) string {
+					if form.Get("spoke_gw") != "spoke-gw" {
+						t.Errorf("unexpected spoke_gw %q", form.Get("spoke_gw"))
+					}
+					calls = append(calls, form.Get("action")+" "+form.Get("transit_gw")+" "+form.Get("route_table"))
+					return `{"return": true, "results": "done", "reason": ""}`
+				},
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+			err := applySpokeTransitRouteTable(client, "spoke-gw", []string{"transit-gw-1", "transit-gw-2"}, tt.routeTable)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestReadSpokeTransitGwsRouteTable(t *testing.T) {
+	transport := &fakeControllerTransport{respond: func(form url.Values) string {
+		if form.Get("action") == "show_spoke_transit_route_table" {
+			if form.Get("transit_gw") != "transit-gw-2" {
+				t.Errorf("unexpected transit_gw %q", form.Get("transit_gw"))
+			}
+			return `{"return": true, "results": {"route_table": "rt-prod"}, "reason": ""}`
+		}
+		return `{"return": true, "results": {"transit_gw_list": ["transit-gw-2", "transit-gw-1"]}, "reason": ""}`
+	}}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name":               "spoke-gw",
+		"attach_to_transit_gws": []interface{}{"transit-gw-2", "transit-gw-1"},
+		"transit_route_table":   "rt-dev",
+	})
+
+	err := readSpokeTransitGws(d, client)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"get_spoke_transit_failover_priority", "show_spoke_transit_route_table"}, transport.actions)
+	assert.Equal(t, "rt-prod", getString(d, "transit_route_table"))
+}
+
+func TestReadSpokeFireNetInspectionExcludeCidrs(t *testing.T) {
+	tests := []struct {
+		name            string
+		transitGwName   string
+		expectedActions []string
+		expected        []string
+	}{
+		{
+			name:            "attached to FireNet transit",
+			transitGwName:   "transit-gw-1,firenet-transit-gw",
+			expectedActions: []string{"list_vpcs_summary", "list_vpcs_summary", "show_spoke_firenet_inspection_exclude_cidrs"},
+			expected:        []string{"10.0.0.0/16", "10.1.0.0/16"},
+		},
+		{
+			name:            "attached to transit without FireNet",
+			transitGwName:   "transit-gw-1",
+			expectedActions: []string{"list_vpcs_summary"},
+		},
+		{
+			name: "not attached",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{respond: func(form url.Values) string {
+				if form.Get("action") == "list_vpcs_summary" {
+					return `{"return": true, "results": [
+						{"vpc_name": "transit-gw-1"},
+						{"vpc_name": "firenet-transit-gw", "enable_transit_firenet": true}
+					], "reason": ""}`
+				}
+				return `{"return": true, "results": {"exclude_cidrs": ["10.1.0.0/16", "10.0.0.0/16"]}, "reason": ""}`
+			}}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":                          "spoke-gw",
+				"firenet_inspection_exclude_cidrs": []interface{}{"10.2.0.0/16"},
+			})
+
+			err := readSpokeFireNetInspectionExcludeCidrs(d, client, &goaviatrix.Gateway{GwName: "spoke-gw", TransitGwName: tt.transitGwName})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			assert.ElementsMatch(t, tt.expected, getStringSet(d, "firenet_inspection_exclude_cidrs"))
+		})
+	}
+}
+
+func TestApplySpokeFireNetInspectionExcludeCidrs(t *testing.T) {
+	tests := []struct {
+		name            string
+		cidrs           []interface{}
+		transitGwName   string
+		expectedActions []string
+		expectError     string
+	}{
+		{
+			name:            "attached to FireNet transit",
+			cidrs:           []interface{}{"10.0.0.0/16"},
+			transitGwName:   "firenet-transit-gw",
+			expectedActions: []string{"list_vpcs_summary", "list_vpcs_summary", "edit_spoke_firenet_inspection_exclude_cidrs"},
+		},
+		{
+			name:            "attached to transit without FireNet",
+			cidrs:           []interface{}{"10.0.0.0/16"},
+			transitGwName:   "transit-gw-1",
+			expectedActions: []string{"list_vpcs_summary", "list_vpcs_summary"},
+			expectError:     "requires the spoke gateway to be attached to a FireNet enabled transit gateway",
+		},
+		{
+			name:            "cleared",
+			expectedActions: []string{"edit_spoke_firenet_inspection_exclude_cidrs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var excludeCidrs string
+			transport := &fakeControllerTransport{}
+			transport.respond = func(form url.Values) string {
+				switch form.Get("action") {
+				case "list_vpcs_summary":
+					if form.Get("gateway_name") == "spoke-gw" {
+						return `{"return": true, "results": [{"vpc_name": "spoke-gw", "transit_gw_name": "` + tt.transitGwName + `"}], "reason": ""}`
+					}
+					return `{"return": true, "results": [
+						{"vpc_name": "transit-gw-1"},
+						{"vpc_name": "firenet-transit-gw", "enable_firenet": true}
+					], "reason": ""}`
+				case "edit_spoke_firenet_inspection_exclude_cidrs":
+					excludeCidrs = form.Get("exclude_cidrs")
+				}
+				return `{"return": true, "results": "", "reason": ""}`
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+				"gw_name":                          "spoke-gw",
+				"firenet_inspection_exclude_cidrs": tt.cidrs,
+			})
+
+			err := applySpokeFireNetInspectionExcludeCidrs(d, client)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, strings.Join(getStringSet(d, "firenet_inspection_exclude_cidrs"), ","), excludeCidrs)
+		})
+	}
+}
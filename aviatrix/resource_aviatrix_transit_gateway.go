@@ -15,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -35,10 +36,10 @@ var typeMapping = map[string]string{
 
 func resourceAviatrixTransitGateway() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAviatrixTransitGatewayCreate,
-		Read:   resourceAviatrixTransitGatewayRead,
-		Update: resourceAviatrixTransitGatewayUpdate,
-		Delete: resourceAviatrixTransitGatewayDelete,
+		CreateContext: resourceAviatrixTransitGatewayCreateContext,
+		Read:          resourceAviatrixTransitGatewayRead,
+		UpdateContext: resourceAviatrixTransitGatewayUpdateContext,
+		Delete:        resourceAviatrixTransitGatewayDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough, //nolint:staticcheck // SA1019: deprecated but requires structural changes to migrate,
 		},
@@ -1008,9 +1009,22 @@ func resourceAviatrixTransitGatewayCustomizeDiff(_ context.Context, d *schema.Re
 		return err
 	}
 
+	if err := validateGatewayTagsReadable(d); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func resourceAviatrixTransitGatewayCreateContext(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := monitorExcludeListVpcWarnings(d, mustClient(meta))
+	diags = append(diags, insaneModeGwSizeWarnings(d, "gw_size", "ha_gw_size")...)
+	if err := resourceAviatrixTransitGatewayCreate(d, meta); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
 func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface{}) error {
 	client := mustClient(meta)
 
@@ -2002,7 +2016,6 @@ func resourceAviatrixTransitGatewayReadIfRequired(d *schema.ResourceData, meta i
 
 func resourceAviatrixTransitGatewayRead(d *schema.ResourceData, meta interface{}) error {
 	client := mustClient(meta)
-	ignoreTagsConfig := client.IgnoreTagsConfig
 
 	var isImport bool
 	gwName := getString(d, "gw_name")
@@ -2446,12 +2459,7 @@ func resourceAviatrixTransitGatewayRead(d *schema.ResourceData, meta interface{}
 		}
 		mustSet(d, "lan_interface_cidr", lanCidr)
 
-		if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes) {
-			tags := goaviatrix.KeyValueTags(gw.Tags).IgnoreConfig(ignoreTagsConfig)
-			if err := d.Set("tags", tags); err != nil {
-				log.Printf("[WARN] Error setting tags for (%s): %s", d.Id(), err)
-			}
-		}
+		setGatewayTags(d, client, gw.CloudType, gw.Tags)
 
 		if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.OCIRelatedCloudTypes) {
 			if gw.GatewayZone != "" {
@@ -2583,6 +2591,15 @@ func resourceAviatrixTransitGatewayRead(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
+func resourceAviatrixTransitGatewayUpdateContext(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := monitorExcludeListVpcWarnings(d, mustClient(meta))
+	diags = append(diags, insaneModeGwSizeWarnings(d, "gw_size", "ha_gw_size")...)
+	if err := resourceAviatrixTransitGatewayUpdate(d, meta); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
 func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := mustClient(meta)
 
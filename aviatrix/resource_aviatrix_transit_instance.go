@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -53,21 +54,7 @@ func resourceAviatrixTransitInstanceCreate(ctx context.Context, d *schema.Resour
 	}
 
 	cloudType := transitGroup.CloudType
-	gwName := getString(d, "gw_name")
-
-	// Determine if this is a primary gateway (gw_count == 0) or HA gateway (gw_count > 0)
-	gwCount := len(transitGroup.GwUUIDList)
-	isPrimaryGateway := gwCount == 0
-
-	log.Printf("[DEBUG] Transit group %s: CloudType=%d, AccountName=%s, VpcID=%s, VpcRegion=%s, GwUUIDList=%v, isPrimaryGateway=%t",
-		groupUUID, cloudType, transitGroup.AccountName, transitGroup.VpcID, transitGroup.VpcRegion, transitGroup.GwUUIDList, isPrimaryGateway)
-
-	// Use group_name as gw_name if not provided (only for primary gateway)
-	// For HA gateway, allow auto-generation by leaving gw_name empty
-	if gwName == "" && isPrimaryGateway {
-		gwName = transitGroup.GroupName
-		mustSet(d, "gw_name", gwName)
-	}
+	configuredGwName := getString(d, "gw_name")
 
 	// Set computed values from the transit group
 	mustSet(d, "group_name", transitGroup.GroupName)
@@ -75,109 +62,191 @@ func resourceAviatrixTransitInstanceCreate(ctx context.Context, d *schema.Resour
 	mustSet(d, "account_name", transitGroup.AccountName)
 	mustSet(d, "vpc_id", transitGroup.VpcID)
 
-	// Create edge transit gateway for AEP, Equinix, Megaport, Self-managed
-	if goaviatrix.IsCloudType(cloudType, goaviatrix.EdgeRelatedCloudTypes) {
-		err := createEdgeTransitInstance(ctx, d, client, transitGroup, isPrimaryGateway)
-		if err != nil {
-			return diag.FromErr(err)
+	var config *transitInstanceConfig
+	var configDiags diag.Diagnostics
+	var haGwName string
+
+	err = createInGatewayGroup(ctx, groupUUID, transitGroup, client.GetGatewayGroup, func(transitGroup *goaviatrix.GatewayGroup) error {
+		// Determine if this is a primary gateway (gw_count == 0) or HA gateway (gw_count > 0)
+		gwCount := len(transitGroup.GwUUIDList)
+		isPrimaryGateway := gwCount == 0
+
+		log.Printf("[DEBUG] Transit group %s: CloudType=%d, AccountName=%s, VpcID=%s, VpcRegion=%s, GwUUIDList=%v, isPrimaryGateway=%t",
+			groupUUID, cloudType, transitGroup.AccountName, transitGroup.VpcID, transitGroup.VpcRegion, transitGroup.GwUUIDList, isPrimaryGateway)
+
+		// Use group_name as gw_name if not provided (only for primary gateway)
+		// For HA gateway, allow auto-generation by leaving gw_name empty
+		gwName := configuredGwName
+		if gwName == "" && isPrimaryGateway {
+			gwName = transitGroup.GroupName
 		}
-		d.SetId(gwName)
-		return resourceAviatrixTransitInstanceRead(ctx, d, meta)
-	}
+		mustSet(d, "gw_name", gwName)
 
-	// CSP transit gateways
-	if isPrimaryGateway {
-		// Build and validate gateway configuration for primary CSP transit gateways
-		config, diagErr := buildTransitInstanceConfig(ctx, d, client, transitGroup)
-		if diagErr != nil {
-			return diagErr
+		// Create edge transit gateway for AEP, Equinix, Megaport, Self-managed
+		if goaviatrix.IsCloudType(cloudType, goaviatrix.EdgeRelatedCloudTypes) {
+			return createEdgeTransitInstance(ctx, d, client, transitGroup, isPrimaryGateway)
 		}
 
-		log.Printf("[INFO] Creating Primary Aviatrix Transit Instance: %#v", config.gateway)
+		// CSP transit gateways
+		if isPrimaryGateway {
+			// Build and validate gateway configuration for primary CSP transit gateways
+			config, configDiags = buildTransitInstanceConfig(ctx, d, client, transitGroup)
+			if configDiags != nil {
+				return errors.New("invalid transit instance configuration")
+			}
 
-		d.SetId(config.gateway.GwName)
-		err = client.LaunchTransitVpc(config.gateway)
-		if err != nil {
-			return diag.Errorf("failed to create Aviatrix Transit Instance: %v", err)
+			log.Printf("[INFO] Creating Primary Aviatrix Transit Instance: %#v", config.gateway)
+
+			d.SetId(config.gateway.GwName)
+			if err := client.LaunchTransitVpc(config.gateway); err != nil {
+				if goaviatrix.IsGatewayGroupMembershipCollision(err) {
+					// Nothing was launched, the role is decided again on retry
+					d.SetId("")
+					config = nil
+				}
+				return fmt.Errorf("failed to create Aviatrix Transit Instance: %w", err)
+			}
+			return nil
 		}
 
+		var err error
+		haGwName, err = createCspTransitHaInstance(d, client, transitGroup, gwName)
+		return err
+	})
+	if configDiags != nil {
+		return configDiags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if goaviatrix.IsCloudType(cloudType, goaviatrix.EdgeRelatedCloudTypes) {
+		d.SetId(getString(d, "gw_name"))
+		return resourceAviatrixTransitInstanceRead(ctx, d, meta)
+	}
+
+	if config != nil {
 		// Configure post-creation settings
-		if diagErr = configureTransitInstancePostCreate(ctx, d, client, config); diagErr != nil {
+		if diagErr := configureTransitInstancePostCreate(ctx, d, client, config); diagErr != nil {
 			return diagErr
 		}
+		return resourceAviatrixTransitInstanceRead(ctx, d, meta)
+	}
+
+	// Set the ID and gw_name to the returned HA gateway name or the provided name
+	gwName := getString(d, "gw_name")
+	if haGwName != "" {
+		d.SetId(haGwName)
+		mustSet(d, "gw_name", haGwName)
+	} else if gwName != "" {
+		d.SetId(gwName)
 	} else {
-		// Create HA transit gateway using group_uuid
-		transitHaGateway := &goaviatrix.TransitHaGateway{
-			GroupUUID: groupUUID,
-			GwName:    gwName,
-			GwSize:    getString(d, "gw_size"),
-			Subnet:    getString(d, "subnet"),
-		}
+		return diag.Errorf("failed to get HA gateway name from API response")
+	}
 
-		// Zone for Azure
-		zone := getString(d, "zone")
-		if goaviatrix.IsCloudType(cloudType, goaviatrix.AzureArmRelatedCloudTypes) && zone != "" {
-			transitHaGateway.Subnet = fmt.Sprintf("%s~~%s~~", getString(d, "subnet"), zone)
-		}
+	return resourceAviatrixTransitInstanceRead(ctx, d, meta)
+}
 
-		// Zone for GCP
-		if goaviatrix.IsCloudType(cloudType, goaviatrix.GCPRelatedCloudTypes) {
-			transitHaGateway.Zone = transitGroup.VpcRegion
+const transitInstanceCreateMaxAttempts = 3
+
+// transitInstanceCreateRetryDelay is how long to wait before re-fetching the gateway group after a membership collision
+var transitInstanceCreateRetryDelay = 10 * time.Second
+
+// createInGatewayGroup calls create with the given gateway group. When the controller rejects the create because
+// the group membership changed since it was read, e.g. while several instances of one group are created in
+// parallel, the group is re-fetched and create is retried so the primary/HA role follows the current membership.
+func createInGatewayGroup(ctx context.Context, groupUUID string, group *goaviatrix.GatewayGroup,
+	getGroup func(ctx context.Context, groupUUID string) (*goaviatrix.GatewayGroup, error),
+	create func(group *goaviatrix.GatewayGroup) error,
+) error {
+	for attempt := 1; ; attempt++ {
+		err := create(group)
+		if err == nil || !goaviatrix.IsGatewayGroupMembershipCollision(err) {
+			return err
 		}
-
-		// OCI specific
-		if goaviatrix.IsCloudType(cloudType, goaviatrix.OCIRelatedCloudTypes) {
-			transitHaGateway.AvailabilityDomain = getString(d, "availability_domain")
-			transitHaGateway.FaultDomain = getString(d, "fault_domain")
+		if attempt == transitInstanceCreateMaxAttempts {
+			return fmt.Errorf("membership of gateway group %s kept changing after %d attempts: %w", groupUUID, attempt, err)
 		}
 
-		// Insane mode
-		insaneModeAz := getString(d, "insane_mode_az")
-		if insaneModeAz != "" {
-			transitHaGateway.Subnet = strings.Join([]string{transitHaGateway.Subnet, insaneModeAz}, "~~")
-			transitHaGateway.InsaneMode = "yes"
+		log.Printf("[WARN] Membership of gateway group %s changed during create, retrying (attempt %d of %d): %v",
+			groupUUID, attempt, transitInstanceCreateMaxAttempts, err)
+		select {
+		case <-time.After(transitInstanceCreateRetryDelay):
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 
-		// EIP
-		if !getBool(d, "allocate_new_eip") {
-			transitHaGateway.Eip = getString(d, "eip")
+		group, err = getGroup(ctx, groupUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get transit group %s: %w", groupUUID, err)
 		}
+	}
+}
 
-		// Tags
-		if _, tagsOk := d.GetOk("tags"); tagsOk {
-			tagsMap, err := extractTags(d, cloudType)
-			if err == nil {
-				tagsJSON, err := TagsMapToJson(tagsMap)
-				if err == nil {
-					transitHaGateway.TagJSON = tagsJSON
-				}
-			}
-		}
+// createCspTransitHaInstance creates an HA CSP transit gateway in the transit group and returns its name
+func createCspTransitHaInstance(d *schema.ResourceData, client *goaviatrix.Client, transitGroup *goaviatrix.GatewayGroup, gwName string) (string, error) {
+	cloudType := transitGroup.CloudType
 
-		// Auto-generate HA gateway name if not provided
-		if gwName == "" {
-			transitHaGateway.AutoGenHaGwName = "yes"
-		}
+	// Create HA transit gateway using group_uuid
+	transitHaGateway := &goaviatrix.TransitHaGateway{
+		GroupUUID: getString(d, "group_uuid"),
+		GwName:    gwName,
+		GwSize:    getString(d, "gw_size"),
+		Subnet:    getString(d, "subnet"),
+	}
 
-		log.Printf("[INFO] Creating HA Aviatrix Transit Instance: %#v", transitHaGateway)
+	// Zone for Azure
+	zone := getString(d, "zone")
+	if goaviatrix.IsCloudType(cloudType, goaviatrix.AzureArmRelatedCloudTypes) && zone != "" {
+		transitHaGateway.Subnet = fmt.Sprintf("%s~~%s~~", getString(d, "subnet"), zone)
+	}
 
-		haGwName, err := client.CreateTransitHaGw(transitHaGateway)
-		if err != nil {
-			return diag.Errorf("failed to create HA Aviatrix Transit Instance: %v", err)
-		}
+	// Zone for GCP
+	if goaviatrix.IsCloudType(cloudType, goaviatrix.GCPRelatedCloudTypes) {
+		transitHaGateway.Zone = transitGroup.VpcRegion
+	}
 
-		// Set the ID and gw_name to the returned HA gateway name or the provided name
-		if haGwName != "" {
-			d.SetId(haGwName)
-			mustSet(d, "gw_name", haGwName)
-		} else if gwName != "" {
-			d.SetId(gwName)
-		} else {
-			return diag.Errorf("failed to get HA gateway name from API response")
+	// OCI specific
+	if goaviatrix.IsCloudType(cloudType, goaviatrix.OCIRelatedCloudTypes) {
+		transitHaGateway.AvailabilityDomain = getString(d, "availability_domain")
+		transitHaGateway.FaultDomain = getString(d, "fault_domain")
+	}
+
+	// Insane mode
+	insaneModeAz := getString(d, "insane_mode_az")
+	if insaneModeAz != "" {
+		transitHaGateway.Subnet = strings.Join([]string{transitHaGateway.Subnet, insaneModeAz}, "~~")
+		transitHaGateway.InsaneMode = "yes"
+	}
+
+	// EIP
+	if !getBool(d, "allocate_new_eip") {
+		transitHaGateway.Eip = getString(d, "eip")
+	}
+
+	// Tags
+	if _, tagsOk := d.GetOk("tags"); tagsOk {
+		tagsMap, err := extractTags(d, cloudType)
+		if err == nil {
+			tagsJSON, err := TagsMapToJson(tagsMap)
+			if err == nil {
+				transitHaGateway.TagJSON = tagsJSON
+			}
 		}
 	}
 
-	return resourceAviatrixTransitInstanceRead(ctx, d, meta)
+	// Auto-generate HA gateway name if not provided
+	if gwName == "" {
+		transitHaGateway.AutoGenHaGwName = "yes"
+	}
+
+	log.Printf("[INFO] Creating HA Aviatrix Transit Instance: %#v", transitHaGateway)
+
+	haGwName, err := client.CreateTransitHaGw(transitHaGateway)
+	if err != nil {
+		return "", fmt.Errorf("failed to create HA Aviatrix Transit Instance: %w", err)
+	}
+	return haGwName, nil
 }
 
 // createEdgeTransitInstance creates an edge transit gateway (Equinix, AEP/NEO, Megaport, Self-managed)
@@ -185,6 +254,10 @@ func createEdgeTransitInstance(ctx context.Context, d *schema.ResourceData, clie
 	cloudType := transitGroup.CloudType
 	gwName := getString(d, "gw_name")
 
+	if getString(d, "tunnel_encryption_cipher") != "default" || getString(d, "tunnel_forward_secrecy") != "disable" {
+		return fmt.Errorf("tunnel_encryption_cipher and tunnel_forward_secrecy are not supported for Edge Transit Instance")
+	}
+
 	// Get the interface config details
 	interfaces := getSet(d, "interfaces").List()
 	if len(interfaces) == 0 {
@@ -197,11 +270,7 @@ func createEdgeTransitInstance(ctx context.Context, d *schema.ResourceData, clie
 	}
 
 	// Get management egress IP prefix list
-	managementEgressIPPrefixList := getStringSet(d, "management_egress_ip_prefix_list")
-	managementEgressIPPrefix := ""
-	if len(managementEgressIPPrefixList) > 0 {
-		managementEgressIPPrefix = strings.Join(managementEgressIPPrefixList, ",")
-	}
+	managementEgressIPPrefix := strings.Join(normalizeManagementEgressIPPrefixList(getStringSet(d, "management_egress_ip_prefix_list")), ",")
 
 	ztpFileDownloadPath := getString(d, "ztp_file_download_path")
 	ztpFileType := getString(d, "ztp_file_type")
@@ -690,6 +759,13 @@ func configureTransitInstancePostCreate(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
+	// Set tunnel cipher settings
+	if encPolicy, pfsPolicy := getString(d, "tunnel_encryption_cipher"), getString(d, "tunnel_forward_secrecy"); encPolicy != "default" || pfsPolicy != "disable" {
+		if err := client.SetGatewayPhase2Policy(gwName, encPolicy, pfsPolicy); err != nil {
+			return diag.Errorf("could not set tunnel cipher settings during Transit Instance creation: %v", err)
+		}
+	}
+
 	// Set RX queue size
 	if config.rxQueueSize != "" {
 		gwRxQueueSize := &goaviatrix.Gateway{
@@ -777,13 +853,52 @@ func configureTransitInstanceRouting(client *goaviatrix.Client, d *schema.Resour
 	}
 
 	// Customized transit vpc routes
-	var customizedTransitVpcRoutes []string
-	for _, v := range getSet(d, "customized_transit_vpc_routes").List() {
-		customizedTransitVpcRoutes = append(customizedTransitVpcRoutes, mustString(v))
+	if customizedTransitVpcRoutes := getStringSet(d, "customized_transit_vpc_routes"); len(customizedTransitVpcRoutes) != 0 {
+		if err := configureCustomizedTransitVpcRoutes(client, gwName, customizedTransitVpcRoutes); err != nil {
+			return err
+		}
 	}
-	if len(customizedTransitVpcRoutes) != 0 {
-		if err := client.UpdateTransitGatewayCustomizedVpcRoute(gateway.GwName, customizedTransitVpcRoutes); err != nil {
-			return diag.Errorf("couldn't update transit instance customized vpc route: %v", err)
+
+	// Filtered transit vpc routes
+	if filteredTransitVpcRoutes := getStringSet(d, "filtered_transit_vpc_routes"); len(filteredTransitVpcRoutes) != 0 {
+		if err := configureFilteredTransitVpcRoutes(client, gwName, filteredTransitVpcRoutes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// configureCustomizedTransitVpcRoutes configures customized transit VPC routes with retry logic
+func configureCustomizedTransitVpcRoutes(client *goaviatrix.Client, gwName string, routes []string) diag.Diagnostics {
+	for i := 0; ; i++ {
+		log.Printf("[INFO] Editing customized transit vpc routes of transit instance: %s ", gwName)
+		err := client.UpdateTransitGatewayCustomizedVpcRoute(gwName, routes)
+		if err == nil {
+			break
+		}
+		if i <= 10 && strings.Contains(err.Error(), "when it is down") {
+			time.Sleep(10 * time.Second)
+		} else {
+			return diag.Errorf("failed to customize transit vpc routes of transit instance: %s due to: %v", gwName, err)
+		}
+	}
+
+	return nil
+}
+
+// configureFilteredTransitVpcRoutes configures filtered transit VPC routes with retry logic
+func configureFilteredTransitVpcRoutes(client *goaviatrix.Client, gwName string, routes []string) diag.Diagnostics {
+	for i := 0; ; i++ {
+		log.Printf("[INFO] Editing filtered transit vpc routes of transit instance: %s ", gwName)
+		err := client.UpdateTransitGatewayFilteredVpcRoute(gwName, routes)
+		if err == nil {
+			break
+		}
+		if i <= 10 && strings.Contains(err.Error(), "when it is down") {
+			time.Sleep(10 * time.Second)
+		} else {
+			return diag.Errorf("failed to edit filtered transit vpc routes of transit instance: %s due to: %v", gwName, err)
 		}
 	}
 
@@ -874,9 +989,59 @@ func configureExcludedAdvertisedSpokeRoutes(client *goaviatrix.Client, d *schema
 	return nil
 }
 
+// readTransitInstanceIpsecTunnels sets ipsec_tunnels from the tunnel status reported by the controller,
+// sorted by connection name and peer IP so the list does not reorder between refreshes. A failed lookup
+// keeps the last known value instead of failing the refresh.
+func readTransitInstanceIpsecTunnels(d *schema.ResourceData, client *goaviatrix.Client, gwName string) {
+	tunnels, err := client.GetGatewayTunnelStatus(gwName)
+	if err != nil {
+		log.Printf("[WARN] could not get IPsec tunnel status of transit instance %s: %v", gwName, err)
+		return
+	}
+	sort.SliceStable(tunnels, func(i, j int) bool {
+		if tunnels[i].ConnectionName != tunnels[j].ConnectionName {
+			return tunnels[i].ConnectionName < tunnels[j].ConnectionName
+		}
+		return tunnels[i].PeerIP < tunnels[j].PeerIP
+	})
+
+	ipsecTunnels := make([]map[string]interface{}, 0, len(tunnels))
+	for _, tunnel := range tunnels {
+		ipsecTunnels = append(ipsecTunnels, map[string]interface{}{
+			"connection_name": tunnel.ConnectionName,
+			"peer_gw_name":    tunnel.PeerGwName,
+			"peer_ip":         tunnel.PeerIP,
+			"status":          tunnel.Status,
+		})
+	}
+	mustSet(d, "ipsec_tunnels", ipsecTunnels)
+}
+
+// readTransitInstanceBgpNeighbors sets bgp_neighbors from the BGP neighbor status reported by the controller,
+// sorted by neighbor IP and AS number. Transit gateways without BGP have no neighbors to report.
+func readTransitInstanceBgpNeighbors(d *schema.ResourceData, client *goaviatrix.Client, gw *goaviatrix.Gateway) error {
+	if !gw.EnableBgp {
+		return d.Set("bgp_neighbors", nil)
+	}
+	neighbors, err := client.GetGatewayBgpNeighborStatus(gw.GwName)
+	if err != nil {
+		return err
+	}
+
+	bgpNeighbors := make([]map[string]interface{}, 0, len(neighbors))
+	for _, neighbor := range neighbors {
+		bgpNeighbors = append(bgpNeighbors, map[string]interface{}{
+			"remote_ip":   neighbor.RemoteIP,
+			"remote_as":   neighbor.RemoteAS,
+			"status":      neighbor.Status,
+			"bfd_enabled": neighbor.BfdEnabled,
+		})
+	}
+	return d.Set("bgp_neighbors", bgpNeighbors)
+}
+
 func resourceAviatrixTransitInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := mustClient(meta)
-	ignoreTagsConfig := client.IgnoreTagsConfig
 
 	var isImport bool
 	gwName := getString(d, "gw_name")
@@ -908,13 +1073,24 @@ func resourceAviatrixTransitInstanceRead(ctx context.Context, d *schema.Resource
 	mustSet(d, "account_name", gw.AccountName)
 	mustSet(d, "gw_name", gw.GwName)
 	mustSet(d, "gw_size", gw.GwSize)
+	// The transit group is only known from the configuration until the controller reports it, e.g. on import
+	if gw.GroupUUID != "" {
+		mustSet(d, "group_uuid", gw.GroupUUID)
+	}
+	if gw.GroupName != "" {
+		mustSet(d, "group_name", gw.GroupName)
+	}
+	readTransitInstanceIpsecTunnels(d, client, gw.GwName)
+	if err := readTransitInstanceBgpNeighbors(d, client, gw); err != nil {
+		return diag.Errorf("failed to read BGP neighbors of transit instance %s: %v", gw.GwName, err)
+	}
 
 	// Edge cloud type
 	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.EdgeRelatedCloudTypes) {
 		mustSet(d, "vpc_id", gw.VpcID)
 		mustSet(d, "bgp_lan_ip_list", nil)
-		if gw.DeviceID != "" {
-			_ = d.Set("device_id", gw.DeviceID)
+		if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.EDGENEO) {
+			mustSet(d, "device_id", gw.DeviceID)
 		}
 		// Set interfaces
 		if len(gw.Interfaces) != 0 {
@@ -946,11 +1122,22 @@ func resourceAviatrixTransitInstanceRead(ctx context.Context, d *schema.Resource
 				return diag.Errorf("could not set eip map into state: %v", err)
 			}
 		}
-		// Set management egress ip prefix list
+		// Set management egress ip prefix list, keeping the configured prefixes if they only differ in order or whitespace
 		if gw.ManagementEgressIPPrefix == "" {
 			_ = d.Set("management_egress_ip_prefix_list", nil)
 		} else {
-			_ = d.Set("management_egress_ip_prefix_list", strings.Split(gw.ManagementEgressIPPrefix, ","))
+			managementEgressIPPrefixList := normalizeManagementEgressIPPrefixList(strings.Split(gw.ManagementEgressIPPrefix, ","))
+			userPrefixList := normalizeManagementEgressIPPrefixList(getStringSet(d, "management_egress_ip_prefix_list"))
+			if len(goaviatrix.Difference(managementEgressIPPrefixList, userPrefixList)) != 0 ||
+				len(goaviatrix.Difference(userPrefixList, managementEgressIPPrefixList)) != 0 {
+				_ = d.Set("management_egress_ip_prefix_list", managementEgressIPPrefixList)
+			}
+		}
+		// Edge images are managed on the device, the reported image_version is informational only
+		mustSet(d, "software_version", gw.SoftwareVersion)
+		mustSet(d, "image_version", gw.ImageVersion)
+		if isImport {
+			setEdgeTransitInstanceImportDefaults(d)
 		}
 		return nil
 	}
@@ -967,6 +1154,8 @@ func resourceAviatrixTransitInstanceRead(ctx context.Context, d *schema.Resource
 	mustSet(d, "rx_queue_size", gw.RxQueueSize)
 	mustSet(d, "subnet", gw.VpcNet)
 	mustSet(d, "tunnel_detection_time", gw.TunnelDetectionTime)
+	mustSet(d, "tunnel_encryption_cipher", gw.TunnelEncryptionCipher)
+	mustSet(d, "tunnel_forward_secrecy", gw.TunnelForwardSecrecy)
 	mustSet(d, "enable_firenet", gw.EnableFirenet)
 	mustSet(d, "enable_gateway_load_balancer", gw.EnableGatewayLoadBalancer)
 	mustSet(d, "enable_transit_firenet", gw.EnableTransitFirenet)
@@ -1139,6 +1328,9 @@ func resourceAviatrixTransitInstanceRead(ctx context.Context, d *schema.Resource
 	// Customized transit vpc routes
 	mustSet(d, "customized_transit_vpc_routes", gw.CustomizedTransitVpcRoutes)
 
+	// Filtered transit vpc routes
+	mustSet(d, "filtered_transit_vpc_routes", gw.FilteredTransitVpcRoutes)
+
 	// Monitor gateway subnets
 	mustSet(d, "enable_monitor_gateway_subnets", gw.MonitorSubnetsAction == "enable")
 	if err := d.Set("monitor_exclude_list", gw.MonitorExcludeGWList); err != nil {
@@ -1146,12 +1338,7 @@ func resourceAviatrixTransitInstanceRead(ctx context.Context, d *schema.Resource
 	}
 
 	// Tags
-	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.AWSRelatedCloudTypes|goaviatrix.AzureArmRelatedCloudTypes) {
-		tags := goaviatrix.KeyValueTags(gw.Tags).IgnoreConfig(ignoreTagsConfig)
-		if err := d.Set("tags", tags); err != nil {
-			log.Printf("[WARN] Error setting tags for (%s): %s", d.Id(), err)
-		}
-	}
+	setGatewayTags(d, client, gw.CloudType, gw.Tags)
 
 	// OCI specific
 	if goaviatrix.IsCloudType(gw.CloudType, goaviatrix.OCIRelatedCloudTypes) {
@@ -1187,6 +1374,27 @@ func resourceAviatrixTransitInstanceRead(ctx context.Context, d *schema.Resource
 	return nil
 }
 
+// setEdgeTransitInstanceImportDefaults sets the attributes that only apply to CSP transit instances to their
+// defaults. They are never read back for edge transit instances, so without this the plan following an import
+// would show them as changed.
+func setEdgeTransitInstanceImportDefaults(d *schema.ResourceData) {
+	mustSet(d, "allocate_new_eip", true)
+	mustSet(d, "single_az_ha", true)
+	mustSet(d, "tunnel_encryption_cipher", "default")
+	mustSet(d, "tunnel_forward_secrecy", "disable")
+	mustSet(d, "customized_spoke_vpc_routes", "")
+	mustSet(d, "filtered_spoke_vpc_routes", "")
+	mustSet(d, "excluded_advertised_spoke_routes", "")
+	mustSet(d, "bgp_manual_spoke_advertise_cidrs", "")
+	mustSet(d, "enable_transit_firenet", false)
+	mustSet(d, "enable_firenet", false)
+	mustSet(d, "enable_gateway_load_balancer", false)
+	mustSet(d, "enable_bgp_over_lan", false)
+	mustSet(d, "insane_mode_az", "")
+	mustSet(d, "enable_monitor_gateway_subnets", false)
+	mustSet(d, "regenerate_ztp", false)
+}
+
 func resourceAviatrixTransitInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := mustClient(meta)
 
@@ -1219,6 +1427,11 @@ func resourceAviatrixTransitInstanceUpdate(ctx context.Context, d *schema.Resour
 		if err := updateTransitInstanceSize(d, client, gateway); err != nil {
 			return err
 		}
+
+		// Update tunnel cipher settings (not supported for edge)
+		if err := updateTransitInstanceTunnelCipher(d, client, gateway); err != nil {
+			return err
+		}
 	}
 
 	// Update Tags (common to both CSP and edge)
@@ -1251,6 +1464,11 @@ func resourceAviatrixTransitInstanceUpdate(ctx context.Context, d *schema.Resour
 		return err
 	}
 
+	// Upgrade software/image version
+	if err := updateTransitInstanceVersion(d, client, gateway); err != nil {
+		return err
+	}
+
 	d.Partial(false)
 	return resourceAviatrixTransitInstanceRead(ctx, d, meta)
 }
@@ -1396,12 +1614,15 @@ func updateTransitInstanceRouting(d *schema.ResourceData, client *goaviatrix.Cli
 
 	// Customized transit vpc routes
 	if d.HasChange("customized_transit_vpc_routes") {
-		var customizedTransitVpcRoutes []string
-		for _, v := range getSet(d, "customized_transit_vpc_routes").List() {
-			customizedTransitVpcRoutes = append(customizedTransitVpcRoutes, mustString(v))
+		if err := configureCustomizedTransitVpcRoutes(client, gwName, getStringSet(d, "customized_transit_vpc_routes")); err != nil {
+			return err
 		}
-		if err := client.UpdateTransitGatewayCustomizedVpcRoute(gateway.GwName, customizedTransitVpcRoutes); err != nil {
-			return diag.Errorf("couldn't update transit instance customized vpc route: %v", err)
+	}
+
+	// Filtered transit vpc routes
+	if d.HasChange("filtered_transit_vpc_routes") {
+		if err := configureFilteredTransitVpcRoutes(client, gwName, getStringSet(d, "filtered_transit_vpc_routes")); err != nil {
+			return err
 		}
 	}
 
@@ -1504,6 +1725,21 @@ func updateTransitInstanceTunnelDetection(d *schema.ResourceData, client *goavia
 	return nil
 }
 
+// updateTransitInstanceTunnelCipher updates the tunnel encryption cipher and forward secrecy
+func updateTransitInstanceTunnelCipher(d *schema.ResourceData, client *goaviatrix.Client, gateway *goaviatrix.Gateway) diag.Diagnostics {
+	if !d.HasChanges("tunnel_encryption_cipher", "tunnel_forward_secrecy") {
+		return nil
+	}
+
+	encPolicy := getString(d, "tunnel_encryption_cipher")
+	pfsPolicy := getString(d, "tunnel_forward_secrecy")
+	if err := client.SetGatewayPhase2Policy(gateway.GwName, encPolicy, pfsPolicy); err != nil {
+		return diag.Errorf("could not update tunnel cipher settings: %v", err)
+	}
+
+	return nil
+}
+
 // updateTransitInstanceRxQueueSize updates RX queue size
 func updateTransitInstanceRxQueueSize(d *schema.ResourceData, client *goaviatrix.Client) diag.Diagnostics {
 	if !d.HasChange("rx_queue_size") {
@@ -1563,6 +1799,32 @@ func updateTransitInstanceBgpOverLan(d *schema.ResourceData, client *goaviatrix.
 	return nil
 }
 
+// updateTransitInstanceVersion upgrades the transit instance when software_version or image_version changes.
+// Only the changed versions are sent so the controller can pick a compatible counterpart.
+func updateTransitInstanceVersion(d *schema.ResourceData, client *goaviatrix.Client, gateway *goaviatrix.Gateway) diag.Diagnostics {
+	if !d.HasChanges("software_version", "image_version") {
+		return nil
+	}
+
+	var softwareVersion, imageVersion string
+	if d.HasChange("software_version") {
+		softwareVersion = getString(d, "software_version")
+	}
+	if d.HasChange("image_version") {
+		imageVersion = getString(d, "image_version")
+	}
+	if softwareVersion == "" && imageVersion == "" {
+		return nil
+	}
+
+	log.Printf("[INFO] Upgrading Aviatrix Transit Instance %s to software version %q and image version %q", gateway.GwName, softwareVersion, imageVersion)
+	if err := client.UpgradeGateway(gateway, softwareVersion, imageVersion); err != nil {
+		return diag.Errorf("failed to upgrade Aviatrix Transit Instance %s: %v", gateway.GwName, err)
+	}
+
+	return nil
+}
+
 // updateEdgeTransitInstance handles updates specific to edge transit gateways
 func updateEdgeTransitInstance(ctx context.Context, d *schema.ResourceData, client *goaviatrix.Client, gateway *goaviatrix.Gateway) diag.Diagnostics {
 	cloudType := gateway.CloudType
@@ -1590,6 +1852,11 @@ func updateEdgeTransitInstance(ctx context.Context, d *schema.ResourceData, clie
 		return err
 	}
 
+	// Regenerate ZTP file
+	if err := regenerateEdgeTransitInstanceZtpFile(d, client, cloudType, gwName); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1601,16 +1868,28 @@ func validateEdgeTransitInstanceUpdateRestrictions(d *schema.ResourceData) diag.
 	if d.HasChange("gw_size") {
 		return diag.Errorf("updating gw_size is not supported for edge transit instance")
 	}
+	if d.HasChange("image_version") {
+		return diag.Errorf("updating image_version is not supported for edge transit instance")
+	}
+	if d.HasChanges("tunnel_encryption_cipher", "tunnel_forward_secrecy") {
+		return diag.Errorf("updating tunnel_encryption_cipher and tunnel_forward_secrecy is not supported for edge transit instance")
+	}
 	return nil
 }
 
-// updateEdgeTransitInstanceInterfaces updates edge transit gateway interfaces
+// updateEdgeTransitInstanceInterfaces adds and removes interfaces on an existing edge transit gateway in place.
+// For AEP/NEO edge gateways the interface mapping is reconciled with the new interface set as well.
 func updateEdgeTransitInstanceInterfaces(d *schema.ResourceData, client *goaviatrix.Client, cloudType int, gwName string) diag.Diagnostics {
-	if !d.HasChanges("interfaces", "management_egress_ip_prefix_list") {
+	isNeo := goaviatrix.IsCloudType(cloudType, goaviatrix.EDGENEO)
+	if !d.HasChanges("interfaces", "management_egress_ip_prefix_list") && !(isNeo && d.HasChange("interface_mapping")) {
 		return nil
 	}
 
 	interfaceList := getSet(d, "interfaces").List()
+	if len(interfaceList) == 0 {
+		return diag.Errorf("at least one interface is required for Edge Transit Instance")
+	}
+
 	interfaces, err := getInterfaceDetails(interfaceList, cloudType)
 	if err != nil {
 		return diag.Errorf("failed to get interface details: %v", err)
@@ -1621,11 +1900,20 @@ func updateEdgeTransitInstanceInterfaces(d *schema.ResourceData, client *goaviat
 		Interfaces: interfaces,
 	}
 
-	managementEgressIPPrefixList := getStringSet(d, "management_egress_ip_prefix_list")
-	if len(managementEgressIPPrefixList) > 0 {
-		gateway.ManagementEgressIPPrefix = strings.Join(managementEgressIPPrefixList, ",")
+	if isNeo {
+		interfaceMappingInput := getList(d, "interface_mapping")
+		if err := validateEdgeTransitInterfaceMapping(interfaceList, interfaceMappingInput); err != nil {
+			return diag.Errorf("invalid interface_mapping: %v", err)
+		}
+		interfaceMapping, err := getInterfaceMappingDetails(interfaceMappingInput)
+		if err != nil {
+			return diag.Errorf("failed to get the interface mapping details: %v", err)
+		}
+		gateway.InterfaceMapping = interfaceMapping
 	}
 
+	gateway.ManagementEgressIPPrefix = strings.Join(normalizeManagementEgressIPPrefixList(getStringSet(d, "management_egress_ip_prefix_list")), ",")
+
 	if err := client.UpdateEdgeGateway(gateway); err != nil {
 		return diag.Errorf("failed to update edge transit instance interfaces: %v", err)
 	}
@@ -1633,6 +1921,77 @@ func updateEdgeTransitInstanceInterfaces(d *schema.ResourceData, client *goaviat
 	return nil
 }
 
+// validateEdgeTransitInterfaceMapping checks that every interface of an AEP/NEO edge transit has an entry of
+// the same type and index in a user provided interface mapping. An empty mapping uses the Dell defaults.
+func validateEdgeTransitInterfaceMapping(interfaces, interfaceMapping []interface{}) error {
+	if len(interfaceMapping) == 0 {
+		return nil
+	}
+
+	mapped := make(map[string]bool, len(interfaceMapping))
+	for _, v := range interfaceMapping {
+		mapping, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid type %T for interface mapping, expected a map", v)
+		}
+		mapped[fmt.Sprintf("%v%v", mapping["type"], mapping["index"])] = true
+	}
+
+	logicalIfNames, err := getUserInterfaceOrder(interfaces)
+	if err != nil {
+		return err
+	}
+	for _, logicalIfName := range logicalIfNames {
+		ifaceType, ifaceIndex, err := extractInterfaceTypeAndIndex(logicalIfName)
+		if err != nil {
+			return err
+		}
+		if !mapped[fmt.Sprintf("%s%d", ifaceType, ifaceIndex)] {
+			return fmt.Errorf("interface %s has no %s interface mapping with index %d", logicalIfName, ifaceType, ifaceIndex)
+		}
+	}
+	return nil
+}
+
+// regenerateEdgeTransitInstanceZtpFile downloads the ZTP file of the edge transit gateway again when
+// regenerate_ztp is toggled, e.g. after the file was lost or the device was re-imaged.
+func regenerateEdgeTransitInstanceZtpFile(d *schema.ResourceData, client *goaviatrix.Client, cloudType int, gwName string) diag.Diagnostics {
+	if !d.HasChange("regenerate_ztp") {
+		return nil
+	}
+	if !goaviatrix.IsCloudType(cloudType, goaviatrix.EDGEEQUINIX|goaviatrix.EDGEMEGAPORT|goaviatrix.EDGESELFMANAGED) {
+		return diag.Errorf("regenerate_ztp is only supported for Equinix, Megaport and Self-managed edge transit instances")
+	}
+	ztpFileDownloadPath := getString(d, "ztp_file_download_path")
+	if ztpFileDownloadPath == "" {
+		return diag.Errorf("ztp_file_download_path is required to regenerate the ZTP file")
+	}
+
+	ztpFileType := ""
+	if goaviatrix.IsCloudType(cloudType, goaviatrix.EDGESELFMANAGED) {
+		ztpFileType = getString(d, "ztp_file_type")
+	}
+
+	log.Printf("[INFO] Regenerating ZTP file of Edge Transit Instance %s in %s", gwName, ztpFileDownloadPath)
+	if err := client.DownloadEdgeZtpFile(gwName, getString(d, "vpc_id"), ztpFileDownloadPath, ztpFileType); err != nil {
+		return diag.Errorf("failed to regenerate ZTP file for edge transit instance: %v", err)
+	}
+
+	return nil
+}
+
+// normalizeManagementEgressIPPrefixList trims the management egress prefixes and drops empty entries.
+func normalizeManagementEgressIPPrefixList(prefixes []string) []string {
+	normalized := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			normalized = append(normalized, prefix)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
 // updateEdgeTransitInstanceEipMap updates EIP mapping for edge transit gateway
 func updateEdgeTransitInstanceEipMap(ctx context.Context, d *schema.ResourceData, client *goaviatrix.Client, cloudType int, gwName string, wanCount int) diag.Diagnostics {
 	if !d.HasChange("eip_map") {
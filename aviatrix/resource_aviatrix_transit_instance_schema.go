@@ -3,6 +3,7 @@ package aviatrix
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -92,6 +93,22 @@ func transitInstanceOptionalBasicSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.IntBetween(20, 600),
 			Description:  "The IPSec tunnel down detection time for the transit gateway.",
 		},
+		"tunnel_encryption_cipher": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "default",
+			ValidateFunc: validation.StringInSlice([]string{"default", "strong"}, false),
+			Description: "Encryption ciphers for gateway peering tunnels. Config options are default (AES-126-GCM-96) or strong (AES-256-GCM-96). " +
+				"Not supported for edge transit instances.",
+		},
+		"tunnel_forward_secrecy": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "disable",
+			ValidateFunc: validation.StringInSlice([]string{"enable", "disable"}, false),
+			Description: "Perfect Forward Secrecy (PFS) for gateway peering tunnels. Config Options are enable/disable. " +
+				"Not supported for edge transit instances.",
+		},
 		"private_mode_lb_vpc_id": {
 			Type:          schema.TypeString,
 			Optional:      true,
@@ -149,6 +166,15 @@ func transitInstanceOptionalRouteSchema() map[string]*schema.Schema {
 				Type: schema.TypeString,
 			},
 		},
+		"filtered_transit_vpc_routes": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Description: "A list of CIDRs to be filtered from the transit VPC route tables. " +
+				"When configured, the filtered CIDRs or their subnets will be removed from the VPC routing tables.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
 		"bgp_manual_spoke_advertise_cidrs": {
 			Type:             schema.TypeString,
 			Optional:         true,
@@ -362,6 +388,13 @@ func transitInstanceOptionalEdgeSchema() map[string]*schema.Schema {
 			Description:  "ZTP file type. For Self-managed edge transit.",
 			ValidateFunc: validation.StringInSlice([]string{"iso", "cloud-init"}, false),
 		},
+		"regenerate_ztp": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: "Toggle to download the ZTP file of an existing edge transit again into ztp_file_download_path. " +
+				"For Equinix/Megaport/Self-managed edge transit.",
+		},
 		"device_id": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -490,14 +523,25 @@ func transitInstanceOptionalEdgeSchema() map[string]*schema.Schema {
 		"management_egress_ip_prefix_list": {
 			Type:        schema.TypeSet,
 			Optional:    true,
-			Description: "Set of management egress gateway IP/prefix.",
+			Description: "Set of management egress gateway IP/prefix in CIDR format.",
 			Elem: &schema.Schema{
-				Type: schema.TypeString,
+				Type:         schema.TypeString,
+				ValidateFunc: validateManagementEgressIPPrefix,
 			},
 		},
 	}
 }
 
+// validateManagementEgressIPPrefix checks that a management egress prefix is a valid CIDR, ignoring surrounding
+// whitespace which is trimmed before the prefixes are sent to the controller.
+func validateManagementEgressIPPrefix(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	return validation.IsCIDR(strings.TrimSpace(v), k)
+}
+
 // ============================================================================
 // COMPUTED SCHEMA
 // ============================================================================
@@ -569,6 +613,64 @@ func transitInstanceComputedSchema() map[string]*schema.Schema {
 			Description: "List of available BGP LAN interface IPs for Azure transit external device connection creation. " +
 				"Only supports Azure. Available as of provider version R2.21.0+.",
 		},
+		"ipsec_tunnels": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "IPsec tunnels terminated on the transit gateway and their current status.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"connection_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Name of the connection the tunnel belongs to.",
+					},
+					"peer_gw_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Name of the peer gateway, empty for external devices.",
+					},
+					"peer_ip": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "IP address of the tunnel peer.",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Status of the tunnel.",
+					},
+				},
+			},
+		},
+		"bgp_neighbors": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "BGP neighbors of the transit gateway and their current status. Only set if BGP is enabled.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"remote_ip": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "BGP neighbor IP address.",
+					},
+					"remote_as": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "BGP neighbor AS number.",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Status of the BGP session with the neighbor.",
+					},
+					"bfd_enabled": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether BFD is enabled for the BGP neighbor.",
+					},
+				},
+			},
+		},
 		"software_version": {
 			Type:     schema.TypeString,
 			Optional: true,
@@ -582,7 +684,7 @@ func transitInstanceComputedSchema() map[string]*schema.Schema {
 			Optional: true,
 			Computed: true,
 			Description: "image_version can be used to set the desired image version of the gateway. " +
-				"If set, we will attempt to update the gateway to the specified version.",
+				"If set, we will attempt to update the gateway to the specified version. Not supported for edge transit instances.",
 		},
 	}
 }
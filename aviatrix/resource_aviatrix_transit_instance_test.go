@@ -1,13 +1,20 @@
 package aviatrix
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 
 	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
 )
@@ -257,6 +264,16 @@ func TestAccAviatrixTransitInstance_withRoutes(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "customized_spoke_vpc_routes", "10.0.0.0/16,10.1.0.0/16"),
 					resource.TestCheckResourceAttr(resourceName, "filtered_spoke_vpc_routes", "192.168.0.0/16"),
 					resource.TestCheckResourceAttr(resourceName, "excluded_advertised_spoke_routes", "172.16.0.0/16"),
+					testCheckStringSet(resourceName, "customized_transit_vpc_routes", []string{"10.2.0.0/16", "10.3.0.0/16"}),
+					testCheckStringSet(resourceName, "filtered_transit_vpc_routes", []string{"192.168.10.0/24"}),
+				),
+			},
+			{
+				Config: testAccTransitInstanceConfigWithRoutesUpdated(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTransitInstanceExists(resourceName, &gateway),
+					testCheckStringSet(resourceName, "customized_transit_vpc_routes", []string{"10.4.0.0/16"}),
+					testCheckStringSet(resourceName, "filtered_transit_vpc_routes", []string{"192.168.10.0/24", "192.168.20.0/24"}),
 				),
 			},
 		},
@@ -377,6 +394,445 @@ func TestAccAviatrixTransitInstance_withTransitFireNet(t *testing.T) {
 	})
 }
 
+func TestAccAviatrixTransitInstance_softwareVersion(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_transit_instance.test_transit_instance_version"
+
+	skipInstance := os.Getenv("SKIP_TRANSIT_INSTANCE")
+	if skipInstance == "yes" {
+		t.Skip("Skipping Transit instance test as SKIP_TRANSIT_INSTANCE is set")
+	}
+
+	skipInstanceAWS := os.Getenv("SKIP_TRANSIT_INSTANCE_AWS")
+	if skipInstanceAWS == "yes" {
+		t.Skip("Skipping Transit instance software version test as SKIP_TRANSIT_INSTANCE_AWS is set")
+	}
+
+	softwareVersion := os.Getenv("AWS_TRANSIT_INSTANCE_SOFTWARE_VERSION")
+	if softwareVersion == "" {
+		t.Skip("Skipping Transit instance software version test as AWS_TRANSIT_INSTANCE_SOFTWARE_VERSION is not set")
+	}
+
+	msgCommon := ". Set SKIP_TRANSIT_INSTANCE_AWS to yes to skip Transit Instance tests in AWS"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preGatewayCheck(t, msgCommon)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTransitInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTransitInstanceConfigSoftwareVersion(rName, "null"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTransitInstanceExists(resourceName, &gateway),
+					resource.TestCheckResourceAttrSet(resourceName, "software_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "image_version"),
+				),
+			},
+			{
+				Config: testAccTransitInstanceConfigSoftwareVersion(rName, fmt.Sprintf("%q", softwareVersion)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTransitInstanceExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "software_version", softwareVersion),
+				),
+			},
+			{
+				Config:   testAccTransitInstanceConfigSoftwareVersion(rName, fmt.Sprintf("%q", softwareVersion)),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAviatrixTransitInstance_tunnelCipher(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_transit_instance.test_transit_instance_cipher"
+
+	skipInstance := os.Getenv("SKIP_TRANSIT_INSTANCE")
+	if skipInstance == "yes" {
+		t.Skip("Skipping Transit instance test as SKIP_TRANSIT_INSTANCE is set")
+	}
+
+	skipInstanceAWS := os.Getenv("SKIP_TRANSIT_INSTANCE_AWS")
+	if skipInstanceAWS == "yes" {
+		t.Skip("Skipping Transit instance tunnel cipher test as SKIP_TRANSIT_INSTANCE_AWS is set")
+	}
+
+	msgCommon := ". Set SKIP_TRANSIT_INSTANCE_AWS to yes to skip Transit Instance tests in AWS"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			preGatewayCheck(t, msgCommon)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTransitInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTransitInstanceConfigTunnelCipher(rName, "default", "disable"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTransitInstanceExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "tunnel_encryption_cipher", "default"),
+					resource.TestCheckResourceAttr(resourceName, "tunnel_forward_secrecy", "disable"),
+				),
+			},
+			{
+				Config: testAccTransitInstanceConfigTunnelCipher(rName, "strong", "enable"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTransitInstanceExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "tunnel_encryption_cipher", "strong"),
+					resource.TestCheckResourceAttr(resourceName, "tunnel_forward_secrecy", "enable"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAviatrixTransitInstance_edgeImport(t *testing.T) {
+	var gateway goaviatrix.Gateway
+
+	rName := acctest.RandString(5)
+	resourceName := "aviatrix_transit_instance.test_transit_instance_edge"
+
+	skipInstance := os.Getenv("SKIP_TRANSIT_INSTANCE")
+	if skipInstance == "yes" {
+		t.Skip("Skipping Transit instance test as SKIP_TRANSIT_INSTANCE is set")
+	}
+
+	skipInstanceEdge := os.Getenv("SKIP_TRANSIT_INSTANCE_EDGE")
+	if skipInstanceEdge == "yes" {
+		t.Skip("Skipping Transit instance edge import test as SKIP_TRANSIT_INSTANCE_EDGE is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTransitInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTransitInstanceConfigEdge(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTransitInstanceExists(resourceName, &gateway),
+					resource.TestCheckResourceAttr(resourceName, "gw_name", fmt.Sprintf("tfi-edge-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "interfaces.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "management_egress_ip_prefix_list.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "device_id", "aviatrix_edge_neo_device_onboarding.test", "device_id"),
+				),
+			},
+			{
+				ResourceName:       resourceName,
+				ImportState:        true,
+				ImportStateVerify:  true,
+				ImportStatePersist: true,
+			},
+			{
+				Config:   testAccTransitInstanceConfigEdge(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestResourceAviatrixTransitInstanceReadEdgeImport(t *testing.T) {
+	gateways := `{"return": true, "results": [{"vpc_name": "edge-transit", "cloud_type": 262144,
+			"account_name": "edge-account", "vpc_id": "site-1", "vpc_size": "UNKNOWN",
+			"group_uuid": "group-uuid", "group_name": "edge-transit-group", "edge_csp_device_id": "device-1",
+			"mgmt_egress_ip": "198.51.100.0/24,203.0.113.10/32",
+			"interfaces": [
+				{"logical_ifname": "wan0", "ipaddr": "10.230.5.32/24", "gateway_ip": "10.230.5.1", "public_ip": "64.71.24.221"},
+				{"logical_ifname": "mgmt0", "dhcp": true}
+			],
+			"interface_mapping": [{"name": "eth1", "type": "MANAGEMENT", "index": 1}, {"name": "eth0", "type": "WAN", "index": 0}],
+			"gw_software_version": "8.0.0", "gw_image_name": "edge-8.0"}], "reason": ""}`
+	transport := &fakeControllerTransport{
+		respond: func(form url.Values) string {
+			if form.Get("action") == "list_gateway_tunnel_status" {
+				return `{"return": true, "results": [], "reason": ""}`
+			}
+			return gateways
+		},
+	}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixTransitInstance().Schema, map[string]interface{}{})
+	d.SetId("edge-transit")
+
+	diags := resourceAviatrixTransitInstanceRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []string{"list_vpcs_summary", "list_gateway_tunnel_status"}, transport.actions)
+	assert.Empty(t, getList(d, "ipsec_tunnels"))
+
+	assert.Equal(t, "edge-transit", getString(d, "gw_name"))
+	assert.Equal(t, "group-uuid", getString(d, "group_uuid"))
+	assert.Equal(t, "edge-transit-group", getString(d, "group_name"))
+	assert.Equal(t, goaviatrix.EDGENEO, getInt(d, "cloud_type"))
+	assert.Equal(t, "edge-account", getString(d, "account_name"))
+	assert.Equal(t, "site-1", getString(d, "vpc_id"))
+	assert.Equal(t, "device-1", getString(d, "device_id"))
+	assert.ElementsMatch(t, []string{"198.51.100.0/24", "203.0.113.10/32"}, getStringSet(d, "management_egress_ip_prefix_list"))
+
+	interfaces := map[string]map[string]interface{}{}
+	for _, v := range getSet(d, "interfaces").List() {
+		iface := mustMap(v)
+		interfaces[mustString(iface["logical_ifname"])] = iface
+	}
+	assert.Len(t, interfaces, 2)
+	assert.Equal(t, "10.230.5.32/24", interfaces["wan0"]["ip_address"])
+	assert.Equal(t, "10.230.5.1", interfaces["wan0"]["gateway_ip"])
+	assert.Equal(t, "64.71.24.221", interfaces["wan0"]["public_ip"])
+	assert.Equal(t, true, interfaces["mgmt0"]["dhcp"])
+
+	interfaceMapping := getList(d, "interface_mapping")
+	assert.Len(t, interfaceMapping, 2)
+	assert.Equal(t, "eth0", mustMap(interfaceMapping[0])["name"])
+
+	// CSP only attributes are set to their defaults so the configuration matches after import
+	assert.True(t, getBool(d, "allocate_new_eip"))
+	assert.True(t, getBool(d, "single_az_ha"))
+	assert.Equal(t, "default", getString(d, "tunnel_encryption_cipher"))
+	assert.Equal(t, "disable", getString(d, "tunnel_forward_secrecy"))
+}
+
+func TestReadTransitInstanceIpsecTunnels(t *testing.T) {
+	transport := &fakeControllerTransport{
+		body: `{"return": true, "results": [
+			{"connection_name": "transit-peering", "peer_gw_name": "transit-gw-2", "peer_ip": "198.51.100.20", "status": "up"},
+			{"connection_name": "onprem-s2c", "peer_gw_name": "", "peer_ip": "203.0.113.6", "status": "up"},
+			{"connection_name": "onprem-s2c", "peer_gw_name": "", "peer_ip": "203.0.113.5", "status": "down"}
+		], "reason": ""}`,
+	}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixTransitInstance().Schema, map[string]interface{}{
+		"gw_name": "transit-gw",
+	})
+
+	readTransitInstanceIpsecTunnels(d, client, "transit-gw")
+	assert.Equal(t, []string{"list_gateway_tunnel_status"}, transport.actions)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"connection_name": "onprem-s2c", "peer_gw_name": "", "peer_ip": "203.0.113.5", "status": "down"},
+		map[string]interface{}{"connection_name": "onprem-s2c", "peer_gw_name": "", "peer_ip": "203.0.113.6", "status": "up"},
+		map[string]interface{}{"connection_name": "transit-peering", "peer_gw_name": "transit-gw-2", "peer_ip": "198.51.100.20", "status": "up"},
+	}, getList(d, "ipsec_tunnels"))
+}
+
+func TestReadTransitInstanceIpsecTunnelsError(t *testing.T) {
+	transport := &fakeControllerTransport{body: `{"return": false, "reason": "Gateway transit-gw does not exist"}`}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixTransitInstance().Schema, map[string]interface{}{
+		"gw_name": "transit-gw",
+	})
+	tunnels := []interface{}{
+		map[string]interface{}{"connection_name": "onprem-s2c", "peer_gw_name": "", "peer_ip": "203.0.113.5", "status": "up"},
+	}
+	mustSet(d, "ipsec_tunnels", tunnels)
+
+	readTransitInstanceIpsecTunnels(d, client, "transit-gw")
+	assert.Equal(t, tunnels, getList(d, "ipsec_tunnels"))
+}
+
+func TestReadTransitInstanceBgpNeighbors(t *testing.T) {
+	tests := []struct {
+		name            string
+		enableBgp       bool
+		expectedActions []string
+		expected        []interface{}
+	}{
+		{
+			name:            "BGP enabled",
+			enableBgp:       true,
+			expectedActions: []string{"list_gateway_bgp_neighbor_status"},
+			expected: []interface{}{
+				map[string]interface{}{"remote_ip": "10.10.0.2", "remote_as": "65010", "status": "active", "bfd_enabled": false},
+				map[string]interface{}{"remote_ip": "169.254.10.1", "remote_as": "65001", "status": "established", "bfd_enabled": true},
+				map[string]interface{}{"remote_ip": "169.254.10.1", "remote_as": "65002", "status": "established", "bfd_enabled": false},
+			},
+		},
+		{
+			name:     "BGP disabled",
+			expected: []interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeControllerTransport{
+				body: `{"return": true, "results": [
+					{"neighbor_ip": "169.254.10.1", "neighbor_as_num": "65002", "status": "established", "bfd_enabled": false},
+					{"neighbor_ip": "10.10.0.2", "neighbor_as_num": "65010", "status": "active", "bfd_enabled": false},
+					{"neighbor_ip": "169.254.10.1", "neighbor_as_num": "65001", "status": "established", "bfd_enabled": true}
+				], "reason": ""}`,
+			}
+			client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixTransitInstance().Schema, map[string]interface{}{
+				"gw_name": "transit-gw",
+			})
+
+			err := readTransitInstanceBgpNeighbors(d, client, &goaviatrix.Gateway{GwName: "transit-gw", EnableBgp: tt.enableBgp})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedActions, transport.actions)
+			assert.Equal(t, tt.expected, getList(d, "bgp_neighbors"))
+		})
+	}
+}
+
+func TestCreateInGatewayGroup(t *testing.T) {
+	delay := transitInstanceCreateRetryDelay
+	transitInstanceCreateRetryDelay = time.Millisecond
+	t.Cleanup(func() { transitInstanceCreateRetryDelay = delay })
+
+	collision := errors.New("rest API create_multicloud_ha_gateway Post failed: Gateway group transit-group already has a primary gateway")
+	emptyGroup := &goaviatrix.GatewayGroup{GroupName: "transit-group"}
+	memberGroup := &goaviatrix.GatewayGroup{GroupName: "transit-group", GwUUIDList: []string{"primary-uuid"}}
+
+	t.Run("membership collision then success", func(t *testing.T) {
+		var roles []bool
+		getGroupCalls := 0
+		getGroup := func(ctx context.Context, groupUUID string) (*goaviatrix.GatewayGroup, error) {
+			getGroupCalls++
+			assert.Equal(t, "group-uuid", groupUUID)
+			return memberGroup, nil
+		}
+
+		err := createInGatewayGroup(context.Background(), "group-uuid", emptyGroup, getGroup, func(group *goaviatrix.GatewayGroup) error {
+			isPrimary := len(group.GwUUIDList) == 0
+			roles = append(roles, isPrimary)
+			if isPrimary {
+				return collision
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{true, false}, roles, "should retry as HA after the group membership changed")
+		assert.Equal(t, 1, getGroupCalls)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		calls := 0
+		getGroup := func(ctx context.Context, groupUUID string) (*goaviatrix.GatewayGroup, error) {
+			t.Fatal("group should not be re-fetched")
+			return nil, nil
+		}
+
+		err := createInGatewayGroup(context.Background(), "group-uuid", emptyGroup, getGroup, func(group *goaviatrix.GatewayGroup) error {
+			calls++
+			return errors.New("insufficient capacity")
+		})
+		assert.EqualError(t, err, "insufficient capacity")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("attempts are bounded", func(t *testing.T) {
+		calls := 0
+		getGroup := func(ctx context.Context, groupUUID string) (*goaviatrix.GatewayGroup, error) {
+			return emptyGroup, nil
+		}
+
+		err := createInGatewayGroup(context.Background(), "group-uuid", emptyGroup, getGroup, func(group *goaviatrix.GatewayGroup) error {
+			calls++
+			return collision
+		})
+		assert.ErrorIs(t, err, collision)
+		assert.ErrorContains(t, err, "kept changing after 3 attempts")
+		assert.Equal(t, transitInstanceCreateMaxAttempts, calls)
+	})
+
+	t.Run("context cancelled while waiting", func(t *testing.T) {
+		transitInstanceCreateRetryDelay = time.Hour
+		t.Cleanup(func() { transitInstanceCreateRetryDelay = time.Millisecond })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := createInGatewayGroup(ctx, "group-uuid", emptyGroup, nil, func(group *goaviatrix.GatewayGroup) error {
+			return collision
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestUpdateEdgeTransitInstanceInterfaces(t *testing.T) {
+	interfaces := []interface{}{
+		map[string]interface{}{"logical_ifname": "wan0", "ip_address": "10.230.5.32/24", "gateway_ip": "10.230.5.1"},
+		map[string]interface{}{"logical_ifname": "wan1", "dhcp": true},
+		map[string]interface{}{"logical_ifname": "mgmt0", "dhcp": true},
+	}
+
+	t.Run("AEP reconciles interface mapping", func(t *testing.T) {
+		var form url.Values
+		transport := &fakeControllerTransport{
+			respond: func(f url.Values) string {
+				form = f
+				return `{"return": true, "results": "", "reason": ""}`
+			},
+		}
+		client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+		d := schema.TestResourceDataRaw(t, resourceAviatrixTransitInstance().Schema, map[string]interface{}{
+			"gw_name":    "edge-transit",
+			"cloud_type": goaviatrix.EDGENEO,
+			"interfaces": interfaces,
+			"interface_mapping": []interface{}{
+				map[string]interface{}{"name": "eth0", "type": "MANAGEMENT", "index": 0},
+				map[string]interface{}{"name": "eth1", "type": "WAN", "index": 0},
+				map[string]interface{}{"name": "eth2", "type": "WAN", "index": 1},
+			},
+		})
+
+		diags := updateEdgeTransitInstanceInterfaces(d, client, goaviatrix.EDGENEO, "edge-transit")
+		assert.False(t, diags.HasError(), "%v", diags)
+		assert.Equal(t, []string{"update_edge_gateway"}, transport.actions)
+		assert.Equal(t, "edge-transit", form.Get("gateway_name"))
+		assert.NotEmpty(t, form.Get("interfaces"))
+		assert.JSONEq(t, `{"eth0": ["mgmt", "0"], "eth1": ["wan", "0"], "eth2": ["wan", "1"]}`, form.Get("interface_mapping"))
+	})
+
+	t.Run("AEP interface without mapping", func(t *testing.T) {
+		transport := &fakeControllerTransport{body: `{"return": true, "results": "", "reason": ""}`}
+		client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+		d := schema.TestResourceDataRaw(t, resourceAviatrixTransitInstance().Schema, map[string]interface{}{
+			"gw_name":    "edge-transit",
+			"cloud_type": goaviatrix.EDGENEO,
+			"interfaces": interfaces,
+			"interface_mapping": []interface{}{
+				map[string]interface{}{"name": "eth0", "type": "MANAGEMENT", "index": 0},
+				map[string]interface{}{"name": "eth1", "type": "WAN", "index": 0},
+			},
+		})
+
+		diags := updateEdgeTransitInstanceInterfaces(d, client, goaviatrix.EDGENEO, "edge-transit")
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "interface wan1 has no WAN interface mapping with index 1")
+		assert.Empty(t, transport.actions)
+	})
+
+	t.Run("Equinix does not send interface mapping", func(t *testing.T) {
+		var form url.Values
+		transport := &fakeControllerTransport{
+			respond: func(f url.Values) string {
+				form = f
+				return `{"return": true, "results": "", "reason": ""}`
+			},
+		}
+		client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+		d := schema.TestResourceDataRaw(t, resourceAviatrixTransitInstance().Schema, map[string]interface{}{
+			"gw_name":    "edge-transit",
+			"cloud_type": goaviatrix.EDGEEQUINIX,
+			"interfaces": interfaces,
+		})
+
+		diags := updateEdgeTransitInstanceInterfaces(d, client, goaviatrix.EDGEEQUINIX, "edge-transit")
+		assert.False(t, diags.HasError(), "%v", diags)
+		assert.Equal(t, []string{"update_edge_gateway"}, transport.actions)
+		assert.Empty(t, form.Get("interface_mapping"))
+	})
+}
+
 func testAccTransitInstanceConfigBasicAWS(rName string) string {
 	return fmt.Sprintf(`
 resource "aviatrix_account" "test_acc_aws" {
@@ -532,6 +988,55 @@ resource "aviatrix_transit_instance" "test_transit_instance_tags" {
 		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), os.Getenv("AWS_SUBNET"))
 }
 
+func testAccTransitInstanceConfigSoftwareVersion(rName, softwareVersion string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%[1]s"
+	cloud_type         = 1
+	aws_account_number = "%[2]s"
+	aws_iam            = false
+	aws_access_key     = "%[3]s"
+	aws_secret_key     = "%[4]s"
+}
+resource "aviatrix_transit_instance" "test_transit_instance_version" {
+	cloud_type       = 1
+	account_name     = aviatrix_account.test_acc_aws.account_name
+	gw_name          = "tfi-version-%[1]s"
+	vpc_id           = "%[5]s"
+	vpc_reg          = "%[6]s"
+	gw_size          = "t2.micro"
+	subnet           = "%[7]s"
+	software_version = %[8]s
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), os.Getenv("AWS_SUBNET"), softwareVersion)
+}
+
+func testAccTransitInstanceConfigTunnelCipher(rName, cipher, forwardSecrecy string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%[1]s"
+	cloud_type         = 1
+	aws_account_number = "%[2]s"
+	aws_iam            = false
+	aws_access_key     = "%[3]s"
+	aws_secret_key     = "%[4]s"
+}
+resource "aviatrix_transit_instance" "test_transit_instance_cipher" {
+	cloud_type               = 1
+	account_name             = aviatrix_account.test_acc_aws.account_name
+	gw_name                  = "tfi-cipher-%[1]s"
+	vpc_id                   = "%[5]s"
+	vpc_reg                  = "%[6]s"
+	gw_size                  = "t2.micro"
+	subnet                   = "%[7]s"
+	tunnel_encryption_cipher = "%[8]s"
+	tunnel_forward_secrecy   = "%[9]s"
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), os.Getenv("AWS_SUBNET"), cipher, forwardSecrecy)
+}
+
 func testAccTransitInstanceConfigWithRoutes(rName string) string {
 	return fmt.Sprintf(`
 resource "aviatrix_account" "test_acc_aws" {
@@ -554,6 +1059,37 @@ resource "aviatrix_transit_instance" "test_transit_instance_routes" {
 	customized_spoke_vpc_routes        = "10.0.0.0/16,10.1.0.0/16"
 	filtered_spoke_vpc_routes          = "192.168.0.0/16"
 	excluded_advertised_spoke_routes   = "172.16.0.0/16"
+	customized_transit_vpc_routes      = ["10.2.0.0/16", "10.3.0.0/16"]
+	filtered_transit_vpc_routes        = ["192.168.10.0/24"]
+}
+	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
+		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), os.Getenv("AWS_SUBNET"))
+}
+
+func testAccTransitInstanceConfigWithRoutesUpdated(rName string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_aws" {
+	account_name       = "tfa-aws-%s"
+	cloud_type         = 1
+	aws_account_number = "%s"
+	aws_iam            = false
+	aws_access_key     = "%s"
+	aws_secret_key     = "%s"
+}
+resource "aviatrix_transit_instance" "test_transit_instance_routes" {
+	cloud_type   = 1
+	account_name = aviatrix_account.test_acc_aws.account_name
+	gw_name      = "tfi-routes-%[1]s"
+	vpc_id       = "%[5]s"
+	vpc_reg      = "%[6]s"
+	gw_size      = "t2.micro"
+	subnet       = "%[7]s"
+
+	customized_spoke_vpc_routes        = "10.0.0.0/16,10.1.0.0/16"
+	filtered_spoke_vpc_routes          = "192.168.0.0/16"
+	excluded_advertised_spoke_routes   = "172.16.0.0/16"
+	customized_transit_vpc_routes      = ["10.4.0.0/16"]
+	filtered_transit_vpc_routes        = ["192.168.10.0/24", "192.168.20.0/24"]
 }
 	`, rName, os.Getenv("AWS_ACCOUNT_NUMBER"), os.Getenv("AWS_ACCESS_KEY"), os.Getenv("AWS_SECRET_KEY"),
 		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), os.Getenv("AWS_SUBNET"))
@@ -634,6 +1170,61 @@ resource "aviatrix_transit_instance" "test_transit_instance_transit_firenet" {
 		os.Getenv("AWS_VPC_ID"), os.Getenv("AWS_REGION"), os.Getenv("AWS_SUBNET"))
 }
 
+func testAccTransitInstanceConfigEdge(rName string) string {
+	return fmt.Sprintf(`
+resource "aviatrix_account" "test_acc_edge" {
+	account_name = "tfa-edge-%[1]s"
+	cloud_type   = 262144
+}
+resource "aviatrix_edge_neo_device_onboarding" "test" {
+	account_name   = aviatrix_account.test_acc_edge.account_name
+	device_name    = "tfd-edge-%[1]s"
+	serial_number  = "%[2]s"
+	hardware_model = "%[3]s"
+}
+resource "aviatrix_transit_group" "test_transit_group_edge" {
+	group_name          = "tfg-edge-%[1]s"
+	cloud_type          = 262144
+	gw_type             = "EDGETRANSIT"
+	group_instance_size = "UNKNOWN"
+	vpc_id              = "%[4]s"
+	account_name        = aviatrix_account.test_acc_edge.account_name
+}
+resource "aviatrix_transit_instance" "test_transit_instance_edge" {
+	group_uuid                       = aviatrix_transit_group.test_transit_group_edge.group_uuid
+	gw_name                          = "tfi-edge-%[1]s"
+	gw_size                          = "UNKNOWN"
+	device_id                        = aviatrix_edge_neo_device_onboarding.test.device_id
+	management_egress_ip_prefix_list = ["198.51.100.0/24"]
+
+	interfaces {
+		logical_ifname = "wan0"
+		ip_address     = "10.230.5.32/24"
+		gateway_ip     = "10.230.5.100"
+		public_ip      = "64.71.24.221"
+	}
+
+	interfaces {
+		logical_ifname = "mgmt0"
+		dhcp           = true
+	}
+
+	interface_mapping {
+		name  = "eth0"
+		type  = "WAN"
+		index = 0
+	}
+
+	interface_mapping {
+		name  = "eth2"
+		type  = "MANAGEMENT"
+		index = 0
+	}
+}
+	`, rName, os.Getenv("EDGE_NEO_DEVICE_SERIAL_NUMBER"), os.Getenv("EDGE_NEO_DEVICE_HARDWARE_MODEL"),
+		os.Getenv("EDGE_NEO_SITE_ID"))
+}
+
 func testAccCheckTransitInstanceExists(n string, gateway *goaviatrix.Gateway) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
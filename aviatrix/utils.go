@@ -2,17 +2,23 @@ package aviatrix
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net"
 	"regexp"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+	// embed the IANA time zone database for validateTimezone on systems without one, e.g. Windows
+	_ "time/tzdata"
 
 	"github.com/hashicorp/go-version"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -266,6 +272,188 @@ func TagsMapToJson(tagsMap map[string]string) (string, error) {
 	return tagsMapStr, nil
 }
 
+// gatewayTagsCloudTypes are the cloud types whose gateway tags are read back into state.
+const gatewayTagsCloudTypes = goaviatrix.AWSRelatedCloudTypes | goaviatrix.AzureArmRelatedCloudTypes
+
+// validateGatewayTagsReadable returns an error when tags are configured for a cloud type whose gateway tags
+// are not read back, since the tags would otherwise show up as a diff on every plan.
+func validateGatewayTagsReadable(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("cloud_type") {
+		return nil
+	}
+	tags, ok := d.GetOk("tags")
+	if !ok || len(mustMap(tags)) == 0 {
+		return nil
+	}
+	if goaviatrix.IsCloudType(getInt(d, "cloud_type"), gatewayTagsCloudTypes) {
+		return nil
+	}
+	return fmt.Errorf("'tags' can't be read back for cloud type %d and would show a diff on every plan: "+
+		"tags are only supported for %s", getInt(d, "cloud_type"), describeCloudTypes(gatewayTagsCloudTypes))
+}
+
+// cloudTypeNames holds the name of each cloud type, in ascending order, as used in error messages.
+var cloudTypeNames = []struct {
+	cloudType int
+	name      string
+}{
+	{goaviatrix.AWS, "AWS"},
+	{goaviatrix.GCP, "GCP"},
+	{goaviatrix.Azure, "Azure"},
+	{goaviatrix.OCI, "OCI"},
+	{goaviatrix.AzureGov, "AzureGov"},
+	{goaviatrix.AWSGov, "AWSGov"},
+	{goaviatrix.AWSChina, "AWSChina"},
+	{goaviatrix.AzureChina, "AzureChina"},
+	{goaviatrix.AliCloud, "Alibaba Cloud"},
+}
+
+// describeCloudTypes lists the cloud types of the cloudTypes mask for an error message, e.g.
+// "AWS (1), GCP (4) and Azure (8)".
+func describeCloudTypes(cloudTypes int) string {
+	var names []string
+	for _, c := range cloudTypeNames {
+		if goaviatrix.IsCloudType(c.cloudType, cloudTypes) {
+			names = append(names, fmt.Sprintf("%s (%d)", c.name, c.cloudType))
+		}
+	}
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// setGatewayTags sets tags from the tags reported for an AWS or Azure related gateway, leaving out the
+// tags matched by the provider's ignore_tags configuration. Other cloud types are left untouched.
+func setGatewayTags(d *schema.ResourceData, client *goaviatrix.Client, cloudType int, tags map[string]string) {
+	if !goaviatrix.IsCloudType(cloudType, gatewayTagsCloudTypes) {
+		return
+	}
+	if err := d.Set("tags", goaviatrix.KeyValueTags(tags).IgnoreConfig(client.IgnoreTagsConfig)); err != nil {
+		log.Printf("[WARN] Error setting tags for (%s): %s", d.Id(), err)
+	}
+}
+
+// setGatewayAllTags sets all_tags to every tag on the cloud instance of an AWS or Azure related
+// gateway, including the controller managed tags and the tags matched by ignore_tags. all_tags is
+// informational only, so a failed lookup keeps the last known value instead of failing the refresh.
+func setGatewayAllTags(d *schema.ResourceData, client *goaviatrix.Client, cloudType int, gwName string) {
+	if !goaviatrix.IsCloudType(cloudType, gatewayTagsCloudTypes) {
+		return
+	}
+	allTags, err := client.GetAllTags(&goaviatrix.Tags{
+		ResourceType: "gw",
+		ResourceName: gwName,
+		CloudType:    cloudType,
+	})
+	if err != nil {
+		log.Printf("[WARN] could not get all tags of gateway %s: %v", gwName, err)
+		return
+	}
+	if err := d.Set("all_tags", allTags); err != nil {
+		log.Printf("[WARN] Error setting all_tags for (%s): %s", d.Id(), err)
+	}
+}
+
+// insaneModeMinimumGwSizes holds, per cloud, the smallest gateway size supported with Insane Mode and
+// a function ranking gateway sizes of that cloud so that they can be compared against it.
+var insaneModeMinimumGwSizes = []struct {
+	cloudTypes int
+	minimum    string
+	rank       func(gwSize string) (int, bool)
+}{
+	{cloudTypes: goaviatrix.AWSRelatedCloudTypes, minimum: "c5.large", rank: awsGwSizeRank},
+	{cloudTypes: goaviatrix.AzureArmRelatedCloudTypes, minimum: "Standard_D3_v2", rank: azureGwSizeRank},
+}
+
+// awsGwSizeRanks ranks the sizes of an AWS instance type up to xlarge. Larger sizes are written as
+// <n>xlarge and rank above xlarge by n.
+var awsGwSizeRanks = map[string]int{"nano": 1, "micro": 2, "small": 3, "medium": 4, "large": 5, "xlarge": 6}
+
+// awsGwSizeRank ranks an AWS instance type such as c5n.2xlarge by its size, ignoring the family.
+func awsGwSizeRank(gwSize string) (int, bool) {
+	_, size, ok := strings.Cut(strings.ToLower(gwSize), ".")
+	if !ok {
+		return 0, false
+	}
+	if rank, ok := awsGwSizeRanks[size]; ok {
+		return rank, true
+	}
+	if size == "metal" {
+		return math.MaxInt, true
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(size, "xlarge"))
+	if err != nil || !strings.HasSuffix(size, "xlarge") {
+		return 0, false
+	}
+	return awsGwSizeRanks["xlarge"] + n, true
+}
+
+var azureGwSizeRegexp = regexp.MustCompile(`(?i)^standard_[a-z]+(\d+)`)
+
+// azureGwSizeRank ranks an Azure VM size such as Standard_D4s_v3 by the number following its series.
+func azureGwSizeRank(gwSize string) (int, bool) {
+	match := azureGwSizeRegexp.FindStringSubmatch(gwSize)
+	if match == nil {
+		return 0, false
+	}
+	rank, err := strconv.Atoi(match[1])
+	return rank, err == nil
+}
+
+// insaneModeMinimumGwSize returns the minimum gateway size supported with Insane Mode if gwSize is
+// smaller than it for the given cloud type. Sizes that can't be ranked are not reported.
+func insaneModeMinimumGwSize(cloudType int, gwSize string) (string, bool) {
+	for _, sizes := range insaneModeMinimumGwSizes {
+		if !goaviatrix.IsCloudType(cloudType, sizes.cloudTypes) {
+			continue
+		}
+		rank, ok := sizes.rank(gwSize)
+		minimum, _ := sizes.rank(sizes.minimum)
+		if ok && rank < minimum {
+			return sizes.minimum, true
+		}
+	}
+	return "", false
+}
+
+// insaneModeGwSizeWarnings returns a warning for each of the gateway sizes in sizeKeys that is smaller
+// than the minimum supported with Insane Mode, when insane_mode is enabled. The controller decides which
+// sizes it accepts, so undersized gateways are only warned about.
+func insaneModeGwSizeWarnings(d *schema.ResourceData, sizeKeys ...string) diag.Diagnostics {
+	if !getBool(d, "insane_mode") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges(append([]string{"insane_mode"}, sizeKeys...)...) {
+		return nil
+	}
+	var diags diag.Diagnostics
+	for _, key := range sizeKeys {
+		gwSize := getString(d, key)
+		if minimum, ok := insaneModeMinimumGwSize(getInt(d, "cloud_type"), gwSize); ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Gateway size below Insane Mode minimum",
+				Detail: fmt.Sprintf("%s %q of gateway %s is smaller than %s, the minimum size supported with insane_mode. "+
+					"The gateway may fail to launch or perform below Insane Mode throughput.", key, gwSize, getString(d, "gw_name"), minimum),
+			})
+		}
+	}
+	return diags
+}
+
+// getGatewayCached looks up a gateway in the client's cached gateway list, so that refreshing many
+// gateways shares one controller call. Gateways missing from the list, or forgotten after a change, are
+// looked up individually, which returns goaviatrix.ErrNotFound when the gateway no longer exists. Only use it
+// for the gateway of the resource being read, see goaviatrix.Client.ListGateways.
+func getGatewayCached(client *goaviatrix.Client, name string) (*goaviatrix.Gateway, error) {
+	gw, err := client.GetListedGateway(name)
+	if errors.Is(err, goaviatrix.ErrNotFound) {
+		return client.GetGateway(&goaviatrix.Gateway{GwName: name})
+	}
+	return gw, err
+}
+
 // validateAzureEipNameResourceGroup is a SchemaValidateFunc for Azure custom EIP name and resource group.
 func validateAzureEipNameResourceGroup(i interface{}, k string) (warnings []string, errors []error) {
 	v, ok := i.(string)
@@ -286,6 +474,140 @@ func validateAzureEipNameResourceGroup(i interface{}, k string) (warnings []stri
 	return
 }
 
+var (
+	awsIamInstanceProfileNameRegexp = regexp.MustCompile(`^[\w+=,.@-]{1,128}$`)
+	awsIamInstanceProfileArnRegexp  = regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:instance-profile/([\w+=,.@/-]*/)?([\w+=,.@-]{1,128})$`)
+)
+
+// validateAwsIamInstanceProfile is a SchemaValidateFunc for an AWS IAM instance profile given either by name or by ARN.
+func validateAwsIamInstanceProfile(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if !awsIamInstanceProfileNameRegexp.MatchString(v) && !awsIamInstanceProfileArnRegexp.MatchString(v) {
+		errors = append(errors, fmt.Errorf("expected %s to be an IAM instance profile name or ARN, got: %s", k, v))
+	}
+
+	return
+}
+
+// awsIamInstanceProfileName returns the name of the IAM instance profile given either by name or by ARN.
+func awsIamInstanceProfileName(profile string) string {
+	if m := awsIamInstanceProfileArnRegexp.FindStringSubmatch(profile); m != nil {
+		return m[2]
+	}
+	return profile
+}
+
+// DiffSuppressFuncAwsIamInstanceProfile ignores the difference between an IAM instance profile given by name
+// and the ARN of the same profile reported by the controller.
+func DiffSuppressFuncAwsIamInstanceProfile(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && new != "" && awsIamInstanceProfileName(old) == awsIamInstanceProfileName(new)
+}
+
+// validateTimezone is a SchemaValidateFunc for an IANA time zone name such as "America/New_York".
+func validateTimezone(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	// LoadLocation also accepts "" and "Local", neither of which names a time zone
+	if _, err := time.LoadLocation(v); err != nil || v == "" || v == "Local" {
+		errors = append(errors, fmt.Errorf("expected %s to be an IANA time zone name such as \"America/New_York\", got: %s", k, v))
+	}
+
+	return
+}
+
+// validateBgpCommunity is a SchemaValidateFunc for a standard BGP community in the format "AA:NN", where
+// both parts are between 0 and 65535.
+func validateBgpCommunity(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	parts := strings.Split(v, ":")
+	if len(parts) != 2 {
+		errors = append(errors, fmt.Errorf("expected %s to be a BGP community in the format 'AA:NN', got: %s", k, v))
+		return
+	}
+	for _, part := range parts {
+		if n, err := strconv.ParseUint(part, 10, 16); err != nil || strconv.FormatUint(n, 10) != part {
+			errors = append(errors, fmt.Errorf("expected both parts of %s to be integers between 0 and 65535, got: %s", k, v))
+			return
+		}
+	}
+
+	return
+}
+
+var (
+	ipsecPhase1Encryptions = []string{
+		"3DES", "AES-128-CBC", "AES-192-CBC", "AES-256-CBC", "AES-128-GCM-64", "AES-128-GCM-96",
+		"AES-128-GCM-128", "AES-256-GCM-64", "AES-256-GCM-96", "AES-256-GCM-128",
+	}
+	ipsecPhase2Encryptions     = append(slices.Clone(ipsecPhase1Encryptions), "NULL-ENCR")
+	ipsecPhase1Authentications = []string{"SHA-1", "SHA-256", "SHA-384", "SHA-512"}
+	ipsecPhase2Authentications = []string{"NO-AUTH", "HMAC-SHA-1", "HMAC-SHA-256", "HMAC-SHA-384", "HMAC-SHA-512"}
+	ipsecDhGroups              = []string{"1", "2", "5", "14", "15", "16", "17", "18", "19", "20", "21"}
+)
+
+// validateIkeProposal is a SchemaValidateFunc for an IKE proposal in the format "encryption/integrity/dh_group",
+// e.g. "AES-256-CBC/SHA-256/14".
+func validateIkeProposal(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	parts := strings.Split(v, "/")
+	if len(parts) != 3 {
+		errors = append(errors, fmt.Errorf("expected %s to be an IKE proposal in the format 'encryption/integrity/dh_group', got: %s", k, v))
+		return
+	}
+	errors = append(errors, validateIpsecProposalAlgorithm(k, "encryption", parts[0], ipsecPhase1Encryptions)...)
+	errors = append(errors, validateIpsecProposalAlgorithm(k, "integrity", parts[1], ipsecPhase1Authentications)...)
+	errors = append(errors, validateIpsecProposalAlgorithm(k, "DH group", parts[2], ipsecDhGroups)...)
+	return
+}
+
+// validateEspProposal is a SchemaValidateFunc for an ESP proposal in the format "encryption/integrity" or
+// "encryption/integrity/dh_group" when PFS is used, e.g. "AES-256-GCM-128/NO-AUTH/14".
+func validateEspProposal(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	parts := strings.Split(v, "/")
+	if len(parts) != 2 && len(parts) != 3 {
+		errors = append(errors, fmt.Errorf("expected %s to be an ESP proposal in the format 'encryption/integrity[/dh_group]', got: %s", k, v))
+		return
+	}
+	errors = append(errors, validateIpsecProposalAlgorithm(k, "encryption", parts[0], ipsecPhase2Encryptions)...)
+	errors = append(errors, validateIpsecProposalAlgorithm(k, "integrity", parts[1], ipsecPhase2Authentications)...)
+	if len(parts) == 3 {
+		errors = append(errors, validateIpsecProposalAlgorithm(k, "DH group", parts[2], ipsecDhGroups)...)
+	}
+	return
+}
+
+func validateIpsecProposalAlgorithm(k, kind, algorithm string, allowed []string) []error {
+	if !slices.Contains(allowed, algorithm) {
+		return []error{fmt.Errorf("expected %s %s to be one of %q, got: %s", k, kind, allowed, algorithm)}
+	}
+	return nil
+}
+
 func DiffSuppressFuncGatewayVpcId(k, old, new string, d *schema.ResourceData) bool {
 	cloudType := getInt(d, "cloud_type")
 	if goaviatrix.IsCloudType(cloudType, goaviatrix.AzureArmRelatedCloudTypes) {
@@ -528,6 +850,75 @@ func sortSpokeInterfacesByCustomOrder(interfaces []goaviatrix.MegaportInterface,
 	return interfaces
 }
 
+// parseCanonicalIPv4CIDR parses an IPv4 CIDR and rejects it unless it is written exactly in
+// its canonical network form, i.e. without host bits set (10.0.0.1/24), leading zeros in the
+// prefix length (10.0.0.0/08) or IPv4-mapped IPv6 notation (::ffff:10.0.0.0/104).
+func parseCanonicalIPv4CIDR(cidr string) (*net.IPNet, error) {
+	ip, netCIDR, err := net.ParseCIDR(cidr)
+	if err != nil || ip.To4() == nil {
+		return nil, fmt.Errorf("invalid IPv4 CIDR %q", cidr)
+	}
+	if !ip.Equal(netCIDR.IP) {
+		return nil, fmt.Errorf("CIDR %q has host bits set; use %q", cidr, netCIDR.String())
+	}
+	if cidr != netCIDR.String() {
+		return nil, fmt.Errorf("CIDR %q is not canonical; use %q", cidr, netCIDR.String())
+	}
+	return netCIDR, nil
+}
+
+// validateCanonicalIPv4CIDR is a SchemaValidateFunc for attributes holding a single IPv4 CIDR that
+// must be a canonical network CIDR, see parseCanonicalIPv4CIDR.
+func validateCanonicalIPv4CIDR(v interface{}, k string) ([]string, []error) {
+	cidr, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("%q must be a string, got %T", k, v)}
+	}
+	if _, err := parseCanonicalIPv4CIDR(cidr); err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// validateCanonicalCIDR is like validateCanonicalIPv4CIDR but also accepts IPv6 CIDRs, which must not
+// have host bits set.
+func validateCanonicalCIDR(v interface{}, k string) ([]string, []error) {
+	cidr, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("%q must be a string, got %T", k, v)}
+	}
+	ip, netCIDR, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: invalid CIDR %q", k, cidr)}
+	}
+	if ip.To4() != nil {
+		return validateCanonicalIPv4CIDR(v, k)
+	}
+	if !ip.Equal(netCIDR.IP) {
+		return nil, []error{fmt.Errorf("invalid %s: CIDR %q has host bits set; use %q", k, cidr, netCIDR.String())}
+	}
+	return nil, nil
+}
+
+// validateCIDRList is a SchemaValidateFunc for attributes holding a comma separated list of
+// IPv4 CIDRs such as customized_spoke_vpc_routes. Every entry must be a canonical network CIDR.
+func validateCIDRList(v interface{}, k string) ([]string, []error) {
+	cidrList, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("%q must be a string, got %T", k, v)}
+	}
+	if cidrList == "" {
+		return nil, nil
+	}
+	var errs []error
+	for _, cidr := range strings.Split(cidrList, ",") {
+		if _, err := parseCanonicalIPv4CIDR(strings.TrimSpace(cidr)); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s: %w", k, err))
+		}
+	}
+	return nil, errs
+}
+
 // ValidateCIDRRule validates that the string is a valid CIDR rule in the format:
 // a.b.c.d/x [ge y] [le z] where x <= y <= z <= 32
 func ValidateCIDRRule(v interface{}, k string) ([]string, []error) {
@@ -540,15 +931,9 @@ func ValidateCIDRRule(v interface{}, k string) ([]string, []error) {
 	if len(fields) != 1 && len(fields) != 3 && len(fields) != 5 {
 		return nil, []error{fmt.Errorf("invalid CIDR rule %s: invalid number of fields in rule", cidrRuleStr)}
 	}
-	// Parse and validate the CIDR is IPv4
-	ip, netCIDR, err := net.ParseCIDR(fields[0])
-	if err != nil || ip.To4() == nil {
-		return nil, []error{fmt.Errorf("invalid CIDR rule %s: invalid IPv4 CIDR %q", cidrRuleStr, fields[0])}
-	}
-	// Ensure the CIDR is in canonical form
-	if fields[0] != netCIDR.String() {
-		return nil, []error{fmt.Errorf("invalid CIDR rule %s: CIDR %q is not canonical; use %q",
-			cidrRuleStr, fields[0], netCIDR.String())}
+	netCIDR, err := parseCanonicalIPv4CIDR(fields[0])
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid CIDR rule %s: %w", cidrRuleStr, err)}
 	}
 	if len(fields) == 1 {
 		return nil, nil
@@ -1,10 +1,14 @@
 package aviatrix
 
 import (
+	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 
 	"aviatrix.com/terraform-provider-aviatrix/goaviatrix"
@@ -194,6 +198,36 @@ func TestValidateCIDRRule(t *testing.T) {
 			expectedError: true,
 			errorContains: "invalid number of fields",
 		},
+		{
+			name:          "host bits set",
+			rule:          "10.0.0.1/24",
+			expectedError: true,
+			errorContains: "has host bits set; use \"10.0.0.0/24\"",
+		},
+		{
+			name:          "host bits set with qualifiers",
+			rule:          "10.1.2.0/16 ge 24",
+			expectedError: true,
+			errorContains: "has host bits set",
+		},
+		{
+			name:          "leading zero in prefix length",
+			rule:          "10.0.0.0/08",
+			expectedError: true,
+			errorContains: "is not canonical; use \"10.0.0.0/8\"",
+		},
+		{
+			name:          "IPv4-mapped IPv6 notation",
+			rule:          "::ffff:10.0.0.0/104",
+			expectedError: true,
+			errorContains: "is not canonical",
+		},
+		{
+			name:          "IPv6 CIDR",
+			rule:          "2001:db8::/32",
+			expectedError: true,
+			errorContains: "invalid IPv4 CIDR",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -224,6 +258,112 @@ func TestValidateCIDRRule(t *testing.T) {
 	}
 }
 
+func TestValidateCanonicalIPv4CIDR(t *testing.T) {
+	_, errs := validateCanonicalIPv4CIDR("10.0.0.0/16", "allowed_cidrs")
+	assert.Empty(t, errs)
+
+	for input, errorContains := range map[string]string{
+		"10.0.0.1/16":             "has host bits set; use \"10.0.0.0/16\"",
+		"10.0.0.0/16,10.1.0.0/16": "invalid IPv4 CIDR",
+		"2001:db8::/32":           "invalid IPv4 CIDR",
+	} {
+		_, errs := validateCanonicalIPv4CIDR(input, "allowed_cidrs")
+		if assert.Len(t, errs, 1, input) {
+			assert.ErrorContains(t, errs[0], errorContains)
+		}
+	}
+}
+
+func TestValidateCanonicalCIDR(t *testing.T) {
+	for _, input := range []string{"10.0.0.0/16", "2001:db8::/32"} {
+		_, errs := validateCanonicalCIDR(input, "allowed_cidrs")
+		assert.Empty(t, errs, input)
+	}
+
+	for input, errorContains := range map[string]string{
+		"10.0.0.1/16":         "has host bits set; use \"10.0.0.0/16\"",
+		"10.0.0.0/08":         "is not canonical; use \"10.0.0.0/8\"",
+		"2001:db8::1/32":      "has host bits set; use \"2001:db8::/32\"",
+		"10.0.0.300/16":       "invalid CIDR",
+		"::ffff:10.0.0.0/104": "is not canonical; use \"10.0.0.0/8\"",
+	} {
+		_, errs := validateCanonicalCIDR(input, "allowed_cidrs")
+		if assert.Len(t, errs, 1, input) {
+			assert.ErrorContains(t, errs[0], errorContains)
+		}
+	}
+}
+
+func TestValidateCIDRList(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         interface{}
+		expectedError bool
+		errorContains string
+	}{
+		{
+			name:  "empty string",
+			input: "",
+		},
+		{
+			name:  "single CIDR",
+			input: "10.0.0.0/16",
+		},
+		{
+			name:  "multiple CIDRs",
+			input: "10.0.0.0/16,192.168.0.0/24,0.0.0.0/0",
+		},
+		{
+			name:  "spaces around entries",
+			input: "10.0.0.0/16, 10.1.0.0/16",
+		},
+		{
+			name:          "host bits set",
+			input:         "10.0.0.0/16,10.1.0.1/24",
+			expectedError: true,
+			errorContains: "\"10.1.0.1/24\" has host bits set; use \"10.1.0.0/24\"",
+		},
+		{
+			name:          "non-canonical prefix length",
+			input:         "10.0.0.0/016",
+			expectedError: true,
+			errorContains: "is not canonical",
+		},
+		{
+			name:          "not a CIDR",
+			input:         "10.0.0.0",
+			expectedError: true,
+			errorContains: "invalid IPv4 CIDR",
+		},
+		{
+			name:          "trailing comma",
+			input:         "10.0.0.0/16,",
+			expectedError: true,
+			errorContains: "invalid IPv4 CIDR",
+		},
+		{
+			name:          "wrong type",
+			input:         10,
+			expectedError: true,
+			errorContains: "must be a string",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings, errs := validateCIDRList(tc.input, "customized_spoke_vpc_routes")
+			assert.Empty(t, warnings)
+			if !tc.expectedError {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs[0].Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
 func TestValidateIPv6CIDR(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -401,3 +541,474 @@ func TestValidateIPv6AccessTypeFunction(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAwsIamInstanceProfile(t *testing.T) {
+	testCases := []struct {
+		name          string
+		profile       string
+		expectedError bool
+	}{
+		{
+			name:    "profile name",
+			profile: "aviatrix-role-ec2",
+		},
+		{
+			name:    "profile ARN",
+			profile: "arn:aws:iam::123456789012:instance-profile/aviatrix-role-ec2",
+		},
+		{
+			name:    "profile ARN with path",
+			profile: "arn:aws-us-gov:iam::123456789012:instance-profile/network/aviatrix-role-ec2",
+		},
+		{
+			name:          "role ARN",
+			profile:       "arn:aws:iam::123456789012:role/aviatrix-role-ec2",
+			expectedError: true,
+		},
+		{
+			name:          "invalid characters",
+			profile:       "aviatrix role",
+			expectedError: true,
+		},
+		{
+			name:          "empty",
+			profile:       "",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateAwsIamInstanceProfile(tc.profile, "aws_iam_instance_profile")
+			if tc.expectedError {
+				assert.NotEmpty(t, errors)
+			} else {
+				assert.Empty(t, errors)
+			}
+		})
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	testCases := []struct {
+		name          string
+		timezone      string
+		expectedError bool
+	}{
+		{
+			name:     "region zone",
+			timezone: "America/New_York",
+		},
+		{
+			name:     "UTC",
+			timezone: "UTC",
+		},
+		{
+			name:          "abbreviation",
+			timezone:      "PST",
+			expectedError: true,
+		},
+		{
+			name:          "offset",
+			timezone:      "+05:30",
+			expectedError: true,
+		},
+		{
+			name:          "local",
+			timezone:      "Local",
+			expectedError: true,
+		},
+		{
+			name:          "empty",
+			timezone:      "",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateTimezone(tc.timezone, "timezone")
+			if tc.expectedError {
+				assert.NotEmpty(t, errors)
+			} else {
+				assert.Empty(t, errors)
+			}
+		})
+	}
+}
+
+func TestDiffSuppressFuncAwsIamInstanceProfile(t *testing.T) {
+	arn := "arn:aws:iam::123456789012:instance-profile/aviatrix-role-ec2"
+
+	assert.True(t, DiffSuppressFuncAwsIamInstanceProfile("", arn, "aviatrix-role-ec2", nil))
+	assert.True(t, DiffSuppressFuncAwsIamInstanceProfile("", "aviatrix-role-ec2", arn, nil))
+	assert.False(t, DiffSuppressFuncAwsIamInstanceProfile("", arn, "other-profile", nil))
+	assert.False(t, DiffSuppressFuncAwsIamInstanceProfile("", "", "aviatrix-role-ec2", nil))
+}
+
+func TestValidateBgpCommunity(t *testing.T) {
+	testCases := []struct {
+		name          string
+		community     string
+		expectedError bool
+	}{
+		{
+			name:      "standard community",
+			community: "65000:100",
+		},
+		{
+			name:      "boundary values",
+			community: "0:65535",
+		},
+		{
+			name:          "value out of range",
+			community:     "65536:100",
+			expectedError: true,
+		},
+		{
+			name:          "missing value",
+			community:     "65000",
+			expectedError: true,
+		},
+		{
+			name:          "large community",
+			community:     "65000:1:2",
+			expectedError: true,
+		},
+		{
+			name:          "non numeric",
+			community:     "no-export",
+			expectedError: true,
+		},
+		{
+			name:          "signed value",
+			community:     "+65000:100",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateBgpCommunity(tc.community, "bgp_community_outbound_filter")
+			if tc.expectedError {
+				assert.NotEmpty(t, errors)
+			} else {
+				assert.Empty(t, errors)
+			}
+		})
+	}
+}
+
+func TestValidateIkeProposal(t *testing.T) {
+	testCases := []struct {
+		name          string
+		proposal      string
+		expectedError bool
+	}{
+		{
+			name:     "CBC proposal",
+			proposal: "AES-256-CBC/SHA-256/14",
+		},
+		{
+			name:     "GCM proposal",
+			proposal: "AES-256-GCM-128/SHA-512/21",
+		},
+		{
+			name:          "unknown encryption",
+			proposal:      "AES-512-CBC/SHA-256/14",
+			expectedError: true,
+		},
+		{
+			name:          "phase 2 integrity",
+			proposal:      "AES-256-CBC/HMAC-SHA-256/14",
+			expectedError: true,
+		},
+		{
+			name:          "unknown DH group",
+			proposal:      "AES-256-CBC/SHA-256/3",
+			expectedError: true,
+		},
+		{
+			name:          "missing DH group",
+			proposal:      "AES-256-CBC/SHA-256",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateIkeProposal(tc.proposal, "ike_proposals")
+			if tc.expectedError {
+				assert.NotEmpty(t, errors)
+			} else {
+				assert.Empty(t, errors)
+			}
+		})
+	}
+}
+
+func TestValidateEspProposal(t *testing.T) {
+	testCases := []struct {
+		name          string
+		proposal      string
+		expectedError bool
+	}{
+		{
+			name:     "without PFS",
+			proposal: "AES-256-GCM-128/NO-AUTH",
+		},
+		{
+			name:     "with PFS",
+			proposal: "AES-256-CBC/HMAC-SHA-256/14",
+		},
+		{
+			name:     "null encryption",
+			proposal: "NULL-ENCR/HMAC-SHA-1",
+		},
+		{
+			name:          "phase 1 integrity",
+			proposal:      "AES-256-CBC/SHA-256",
+			expectedError: true,
+		},
+		{
+			name:          "unknown DH group",
+			proposal:      "AES-256-CBC/HMAC-SHA-256/22",
+			expectedError: true,
+		},
+		{
+			name:          "too many parts",
+			proposal:      "AES-256-CBC/HMAC-SHA-256/14/15",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errors := validateEspProposal(tc.proposal, "esp_proposals")
+			if tc.expectedError {
+				assert.NotEmpty(t, errors)
+			} else {
+				assert.Empty(t, errors)
+			}
+		})
+	}
+}
+
+func TestSetGatewayTags(t *testing.T) {
+	client := &goaviatrix.Client{
+		IgnoreTagsConfig: &goaviatrix.IgnoreTagsConfig{
+			Keys:        goaviatrix.NewIgnoreTags([]interface{}{"CostCenter"}),
+			KeyPrefixes: goaviatrix.NewIgnoreTags([]interface{}{"aws:", "kubernetes.io/"}),
+		},
+	}
+	controllerTags := map[string]string{
+		"Name":                           "gw",
+		"aws:cloudformation:stack-name":  "network",
+		"kubernetes.io/cluster/platform": "owned",
+		"CostCenter":                     "1234",
+	}
+
+	resources := map[string]*schema.Resource{
+		"aviatrix_gateway":       resourceAviatrixGateway(),
+		"aviatrix_spoke_gateway": resourceAviatrixSpokeGateway(),
+	}
+	for name, r := range resources {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"gw_name": "gw",
+				"tags":    map[string]interface{}{"Name": "gw"},
+			})
+
+			setGatewayTags(d, client, goaviatrix.AWS, controllerTags)
+			assert.Equal(t, map[string]interface{}{"Name": "gw"}, d.Get("tags"))
+
+			setGatewayTags(d, client, goaviatrix.GCP, map[string]string{"name": "gcp-gw"})
+			assert.Equal(t, map[string]interface{}{"Name": "gw"}, d.Get("tags"))
+		})
+	}
+}
+
+func TestGetGatewayCached(t *testing.T) {
+	transport := &fakeControllerTransport{respond: func(form url.Values) string {
+		switch form.Get("gateway_name") {
+		case "":
+			return `{"return": true, "results": [{"vpc_name": "spoke-gw-1"}, {"vpc_name": "spoke-gw-2"}], "reason": ""}`
+		case "spoke-gw-1":
+			return `{"return": true, "results": [{"vpc_name": "spoke-gw-1", "vpc_size": "t3.large"}], "reason": ""}`
+		default:
+			return `{"return": true, "results": [], "reason": ""}`
+		}
+	}}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+
+	gw, err := getGatewayCached(client, "spoke-gw-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "spoke-gw-1", gw.GwName)
+	gw, err = getGatewayCached(client, "spoke-gw-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "spoke-gw-2", gw.GwName)
+	assert.Equal(t, []string{"list_vpcs_summary"}, transport.actions)
+
+	_, err = getGatewayCached(client, "deleted-gw")
+	assert.ErrorIs(t, err, goaviatrix.ErrNotFound)
+	assert.Equal(t, []string{"list_vpcs_summary", "list_vpcs_summary"}, transport.actions)
+
+	// A forgotten gateway is looked up again, e.g. after its resource was updated.
+	client.ForgetCachedGateway("spoke-gw-1")
+	gw, err = getGatewayCached(client, "spoke-gw-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "t3.large", gw.GwSize)
+	assert.Len(t, transport.actions, 3)
+}
+
+func TestSetGatewayAllTags(t *testing.T) {
+	transport := &fakeControllerTransport{body: `{"return": true, "results": {
+		"usr_tags": {"Name": "gw", "CostCenter": "1234"},
+		"sys_tags": {"aviatrix-gw-name": "gw"}
+	}, "reason": ""}`}
+	client := &goaviatrix.Client{
+		HTTPClient: &http.Client{Transport: transport},
+		CID:        "cid",
+		IgnoreTagsConfig: &goaviatrix.IgnoreTagsConfig{
+			Keys: goaviatrix.NewIgnoreTags([]interface{}{"CostCenter"}),
+		},
+	}
+	controllerTags := map[string]string{"Name": "gw", "CostCenter": "1234"}
+
+	resources := map[string]*schema.Resource{
+		"aviatrix_gateway":       resourceAviatrixGateway(),
+		"aviatrix_spoke_gateway": resourceAviatrixSpokeGateway(),
+	}
+	for name, r := range resources {
+		t.Run(name, func(t *testing.T) {
+			transport.actions = nil
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"gw_name": "gw",
+				"tags":    map[string]interface{}{"Name": "gw"},
+			})
+
+			setGatewayTags(d, client, goaviatrix.AWS, controllerTags)
+			setGatewayAllTags(d, client, goaviatrix.AWS, "gw")
+			assert.Equal(t, map[string]interface{}{"Name": "gw"}, d.Get("tags"))
+			assert.Equal(t, map[string]interface{}{
+				"Name":             "gw",
+				"CostCenter":       "1234",
+				"aviatrix-gw-name": "gw",
+			}, d.Get("all_tags"))
+			assert.Equal(t, []string{"list_resource_tags"}, transport.actions)
+
+			transport.actions = nil
+			setGatewayAllTags(d, client, goaviatrix.GCP, "gw")
+			assert.Empty(t, transport.actions)
+		})
+	}
+}
+
+func TestSetGatewayAllTagsError(t *testing.T) {
+	transport := &fakeControllerTransport{body: `{"return": false, "reason": "unknown action"}`}
+	client := &goaviatrix.Client{HTTPClient: &http.Client{Transport: transport}, CID: "cid"}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, map[string]interface{}{
+		"gw_name": "gw",
+	})
+	mustSet(d, "all_tags", map[string]string{"Name": "gw"})
+
+	setGatewayAllTags(d, client, goaviatrix.AWS, "gw")
+	assert.Equal(t, map[string]interface{}{"Name": "gw"}, d.Get("all_tags"))
+	assert.Equal(t, []string{"list_resource_tags"}, transport.actions)
+}
+
+func TestInsaneModeMinimumGwSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		cloudType  int
+		gwSize     string
+		undersized bool
+	}{
+		{"AWS medium undersized", goaviatrix.AWS, "t3.medium", true},
+		{"AWS micro undersized", goaviatrix.AWS, "t2.micro", true},
+		{"AWSGov small undersized", goaviatrix.AWSGov, "c5.small", true},
+		{"AWS c5 minimum", goaviatrix.AWS, "c5.large", false},
+		{"AWS xlarge valid", goaviatrix.AWS, "c5n.xlarge", false},
+		{"AWS 4xlarge valid", goaviatrix.AWS, "c6in.4xlarge", false},
+		{"AWS metal valid", goaviatrix.AWS, "c5n.metal", false},
+		{"AWS unknown size not reported", goaviatrix.AWS, "custom", false},
+		{"Azure B2 undersized", goaviatrix.Azure, "Standard_B2ms", true},
+		{"Azure D2_v2 undersized", goaviatrix.Azure, "Standard_D2_v2", true},
+		{"Azure lowercase d2_v2 undersized", goaviatrix.Azure, "standard_d2_v2", true},
+		{"Azure D3_v2 minimum", goaviatrix.Azure, "Standard_D3_v2", false},
+		{"Azure D4s_v3 valid", goaviatrix.Azure, "Standard_D4s_v3", false},
+		{"Azure F16 valid", goaviatrix.Azure, "Standard_F16", false},
+		{"GCP not checked", goaviatrix.GCP, "n1-standard-1", false},
+		{"empty HA size", goaviatrix.AWS, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, undersized := insaneModeMinimumGwSize(tt.cloudType, tt.gwSize)
+			assert.Equal(t, tt.undersized, undersized)
+		})
+	}
+}
+
+func TestInsaneModeGwSizeWarnings(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           map[string]interface{}
+		expectedWarnings []string
+	}{
+		{
+			name: "undersized gw_size",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.AWS, "gw_size": "t3.medium", "insane_mode": true,
+			},
+			expectedWarnings: []string{`gw_size "t3.medium" of gateway test-spoke is smaller than c5.large`},
+		},
+		{
+			name: "undersized gw_size and ha_gw_size",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.Azure, "gw_size": "Standard_B2ms", "ha_gw_size": "Standard_D2_v2", "insane_mode": true,
+			},
+			expectedWarnings: []string{
+				`gw_size "Standard_B2ms" of gateway test-spoke is smaller than Standard_D3_v2`,
+				`ha_gw_size "Standard_D2_v2" of gateway test-spoke is smaller than Standard_D3_v2`,
+			},
+		},
+		{
+			name: "valid sizes",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.AWS, "gw_size": "c5.xlarge", "ha_gw_size": "c5.large", "insane_mode": true,
+			},
+		},
+		{
+			name: "small size without insane mode",
+			config: map[string]interface{}{
+				"cloud_type": goaviatrix.AWS, "gw_size": "t3.medium",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{"gw_name": "test-spoke"}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, resourceAviatrixSpokeGateway().Schema, config)
+
+			diags := insaneModeGwSizeWarnings(d, "gw_size", "ha_gw_size")
+			assert.Len(t, diags, len(tt.expectedWarnings))
+			for i, warning := range tt.expectedWarnings {
+				assert.Equal(t, diag.Warning, diags[i].Severity)
+				assert.Contains(t, diags[i].Detail, warning)
+			}
+		})
+	}
+}
+
+func TestDescribeCloudTypes(t *testing.T) {
+	assert.Equal(t, "", describeCloudTypes(0))
+	assert.Equal(t, "GCP (4)", describeCloudTypes(goaviatrix.GCP))
+	assert.Equal(t, "AWS (1), AWSGov (256) and AWSChina (1024)", describeCloudTypes(goaviatrix.AWSRelatedCloudTypes))
+	assert.Equal(t, "AWS (1), Azure (8), AzureGov (32), AWSGov (256), AWSChina (1024) and AzureChina (2048)",
+		describeCloudTypes(gatewayTagsCloudTypes))
+}
@@ -61,11 +61,15 @@ func (c *Client) GetDomainConn(domainConn *DomainConn) error {
 	if err != nil {
 		return err
 	}
+	// domains of the same TGW may be listed by name only, domains of a peered TGW always as tgw_name:domain_name
 	connectedDomains := data.Results.ConnectedDomainNames
 	for i := range connectedDomains {
 		if connectedDomains[i] == domainConn.TgwName2+":"+domainConn.DomainName2 {
 			return nil
 		}
+		if domainConn.TgwName1 == domainConn.TgwName2 && connectedDomains[i] == domainConn.DomainName2 {
+			return nil
+		}
 	}
 	return ErrNotFound
 }
@@ -0,0 +1,77 @@
+package goaviatrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDomainConn(t *testing.T) {
+	tests := []struct {
+		name        string
+		tgwName2    string
+		response    string
+		expectedErr error
+		expectError bool
+	}{
+		{
+			name:     "same tgw",
+			tgwName2: "tgw",
+			response: `{"return": true, "results": {"connected_domain_names": ["Shared_Service_Domain", "prod"]}, "reason": ""}`,
+		},
+		{
+			name:     "same tgw with tgw prefix",
+			tgwName2: "tgw",
+			response: `{"return": true, "results": {"connected_domain_names": ["tgw:prod"]}, "reason": ""}`,
+		},
+		{
+			name:        "same tgw only connected to a peered tgw",
+			tgwName2:    "tgw",
+			response:    `{"return": true, "results": {"connected_domain_names": ["tgw2:prod"]}, "reason": ""}`,
+			expectedErr: ErrNotFound,
+		},
+		{
+			name:     "peered tgw",
+			tgwName2: "tgw2",
+			response: `{"return": true, "results": {"connected_domain_names": ["tgw2:prod"]}, "reason": ""}`,
+		},
+		{
+			name:        "peered tgw only connected to the same tgw",
+			tgwName2:    "tgw2",
+			response:    `{"return": true, "results": {"connected_domain_names": ["prod"]}, "reason": ""}`,
+			expectedErr: ErrNotFound,
+		},
+		{
+			name:        "domain does not exist",
+			tgwName2:    "tgw",
+			response:    `{"return": false, "reason": "Route domain dev does not exist"}`,
+			expectedErr: ErrNotFound,
+		},
+		{
+			name:        "controller error",
+			tgwName2:    "tgw",
+			response:    `{"return": false, "reason": "TGW is busy"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			err := client.GetDomainConn(&DomainConn{TgwName1: "tgw", DomainName1: "dev", TgwName2: tt.tgwName2, DomainName2: "prod"})
+			switch {
+			case tt.expectedErr != nil:
+				assert.ErrorIs(t, err, tt.expectedErr)
+			case tt.expectError:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrNotFound)
+			default:
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, "list_connected_route_domains", rt.form.Get("action"))
+			assert.Equal(t, "tgw", rt.form.Get("tgw_name"))
+			assert.Equal(t, "dev", rt.form.Get("route_domain_name"))
+		})
+	}
+}
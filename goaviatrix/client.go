@@ -60,8 +60,14 @@ type Client struct {
 	ControllerIP     string
 	baseURL          string
 	IgnoreTagsConfig *IgnoreTagsConfig
-	cachedAccounts   []Account
-	cacheMutex       sync.Mutex
+	// RetryCount and RetryDelay configure how RetryableDo retries failed calls.
+	RetryCount     int
+	RetryDelay     time.Duration
+	cachedAccounts []Account
+	cacheMutex     sync.Mutex
+	// cachedGateways holds the result of ListGateways, see ListGateways.
+	cachedGateways    *gatewayCache
+	gatewayCacheMutex sync.Mutex
 }
 
 type GetApiTokenResp struct {
@@ -178,7 +184,15 @@ func (c *Client) LoginForCloudn() error {
 //
 //	init()
 func NewClient(username string, password string, controllerIP string, HTTPClient *http.Client, ignoreTagsConfig *IgnoreTagsConfig) (*Client, error) {
-	client := &Client{Username: username, Password: password, HTTPClient: HTTPClient, ControllerIP: controllerIP, IgnoreTagsConfig: ignoreTagsConfig}
+	client := &Client{
+		Username:         username,
+		Password:         password,
+		HTTPClient:       HTTPClient,
+		ControllerIP:     controllerIP,
+		IgnoreTagsConfig: ignoreTagsConfig,
+		RetryCount:       DefaultRetryCount,
+		RetryDelay:       DefaultRetryDelay,
+	}
 	return client.init(controllerIP)
 }
 
@@ -0,0 +1,25 @@
+package goaviatrix
+
+import (
+	"context"
+)
+
+type ControllerEntitlements struct {
+	Entitlements map[string]bool `json:"entitlements"`
+}
+
+// GetControllerEntitlements returns the feature flags permitted by the controller license.
+func (c *Client) GetControllerEntitlements(ctx context.Context) (*ControllerEntitlements, error) {
+	endpoint := "controller-entitlements"
+
+	var data ControllerEntitlements
+	err := c.GetAPIContext25(ctx, &data, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if data.Entitlements == nil {
+		return nil, ErrNotFound
+	}
+
+	return &data, nil
+}
@@ -0,0 +1,28 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetControllerEntitlements(t *testing.T) {
+	client, rt := newRecordingClient(`{"entitlements": {"copilot": true, "distributed_firewalling": false}}`)
+
+	entitlements, err := client.GetControllerEntitlements(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"copilot": true, "distributed_firewalling": false}, entitlements.Entitlements)
+	assert.Equal(t, http.MethodGet, rt.method)
+	assert.Equal(t, "/v2.5/api/controller-entitlements", rt.path)
+}
+
+func TestGetControllerEntitlementsNotFound(t *testing.T) {
+	client, _ := newRecordingClient(`{}`)
+
+	entitlements, err := client.GetControllerEntitlements(context.Background())
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Nil(t, entitlements)
+}
@@ -18,8 +18,15 @@ import (
 
 const (
 	gatewayPhase2PolicyEndpoint = "ipsec-peering-policy"
+	gatewayMetadataEndpoint     = "gateway-metadata"
 )
 
+// CustomizedRoute is a customized VPC route of a gateway together with its next hop.
+type CustomizedRoute struct {
+	Cidr    string `json:"cidr"`
+	NextHop string `json:"next_hop"`
+}
+
 // Gateway simple struct to hold gateway details
 type Gateway struct {
 	AccountName                  string `form:"account_name,omitempty" json:"account_name,omitempty"`
@@ -94,6 +101,7 @@ type Gateway struct {
 	PeeringHASubnet                 string            `form:"public_subnet,omitempty"`
 	NewZone                         string            `form:"new_zone,omitempty"`
 	NewSubnet                       string            `form:"new_subnet,omitempty"`
+	IamInstanceProfile              string            `form:"iam_instance_profile,omitempty" json:"iam_instance_profile,omitempty"`
 	InsaneMode                      string            `form:"insane_mode,omitempty" json:"high_perf,omitempty"`
 	InstState                       string            `form:"inst_state,omitempty" json:"inst_state,omitempty"`
 	IntraVMRoute                    string            `form:"intra_vm_route,omitempty" json:"intra_vm_route,omitempty"`
@@ -167,6 +175,7 @@ type Gateway struct {
 	MonitorExcludeGWList            []string `form:"monitor_exclude_gw_list,omitempty" json:"monitor_exclude_gw_list,omitempty"`
 	FqdnLanCidr                     string   `form:"fqdn_lan_cidr,omitempty"`
 	RouteTable                      string
+	CustomizedRoutes                []CustomizedRoute                   `json:"customized_routes,omitempty"`
 	EnablePrivateOob                bool                                `json:"private_oob"`
 	OobManagementSubnet             string                              `json:"oob_mgmt_subnet"`
 	LanVpcID                        string                              `form:"lan_vpc,omitempty"`
@@ -174,6 +183,7 @@ type Gateway struct {
 	CreateFQDNGateway               bool                                `form:"create_firewall_gw,omitempty"`
 	PrivateVpcDefaultEnabled        bool                                `json:"private_vpc_default_enabled"`
 	SkipPublicVpcUpdateEnabled      bool                                `json:"skip_public_vpc_update_enabled"`
+	CloudRouteTablePropagationOff   bool                                `json:"cloud_route_table_propagation_disabled,omitempty"`
 	EnableMultitierTransit          bool                                `json:"multitier_transit"`
 	AutoAdvertiseCidrsEnabled       bool                                `json:"auto_advertise_s2c_cidrs,omitempty"`
 	TunnelDetectionTime             int                                 `json:"detection_time"`
@@ -185,6 +195,7 @@ type Gateway struct {
 	BgpEcmp                         bool                                `json:"bgp_ecmp"`
 	EnableActiveStandby             bool                                `json:"enable_active_standby"`
 	EnableActiveStandbyPreemptive   bool                                `json:"enabled_active_standby_preemptive"`
+	ActiveStandbyFailoverDelay      int                                 `json:"active_standby_failover_delay,omitempty"`
 	EnableBgpOverLan                bool                                `json:"enable_bgp_over_lan"`
 	EnableTransitSummarizeCidrToTgw bool                                `json:"enable_transit_summarize_cidr_to_tgw"`
 	EnableSegmentation              bool                                `json:"enable_segmentation"`
@@ -195,6 +206,7 @@ type Gateway struct {
 	EnableEgressTransitFirenet      bool                                `json:"enable_egress_transit_firenet"`
 	EnablePreserveAsPath            bool                                `json:"preserve_as_path"`
 	CustomizedTransitVpcRoutes      []string                            `json:"customized_transit_vpc_routes"`
+	FilteredTransitVpcRoutes        []string                            `json:"filtered_transit_vpc_routes"`
 	EnableAdvertiseTransitCidr      bool                                `json:"enable_advertise_transit_cidr"`
 	EnableLearnedCidrsApproval      bool                                `json:"enable_learned_cidrs_approval"`
 	BgpManualSpokeAdvertiseCidrs    []string                            `json:"bgp_manual_spoke_advertise_cidrs"`
@@ -208,6 +220,7 @@ type Gateway struct {
 	HaGw                            HaGateway                           `json:"hagw_details"`
 	AvailabilityDomain              string                              `form:"availability_domain,omitempty"`
 	FaultDomain                     string                              `form:"fault_domain,omitempty" json:"fault_domain"`
+	AdditionalFaultDomains          []string                            `json:"additional_fault_domains,omitempty"`
 	EnableSpotInstance              bool                                `form:"spot_instance,omitempty" json:"spot_instance"`
 	SpotPrice                       string                              `form:"spot_price,omitempty" json:"spot_price"`
 	DeleteSpot                      bool                                `form:"delete_spot,omitempty" json:"delete_spot"`
@@ -240,11 +253,14 @@ type Gateway struct {
 	TunnelEncryptionCipher          string                              `json:"ph2_encryption_policy,omitempty"`
 	TunnelForwardSecrecy            string                              `json:"ph2_pfs_policy,omitempty"`
 	PrivateRouteTableConfig         []string                            `json:"private_route_table_config,omitempty"`
+	GcpPrivateRoutes                []string                            `json:"gcp_private_routes,omitempty"`
+	GroupUUID                       string                              `json:"group_uuid,omitempty"`
 }
 
 type HaGateway struct {
 	GwName                   string                 `json:"vpc_name"`
 	CloudType                int                    `json:"cloud_type"`
+	VpcRegion                string                 `json:"vpc_region"`
 	GwSize                   string                 `json:"vpc_size"`
 	VpcNet                   string                 `json:"public_subnet"`
 	PublicIP                 string                 `json:"public_ip"`
@@ -264,6 +280,9 @@ type HaGateway struct {
 	Interfaces               []EdgeTransitInterface `json:"interfaces,omitempty"`
 	ManagementEgressIPPrefix string                 `json:"mgmt_egress_ip,omitempty"`
 	SubnetIPv6Cidr           string                 `json:"gw_subnet_ipv6_cidr,omitempty"`
+	PublicIPv6               string                 `json:"public_ipv6,omitempty"`
+	TunnelDetectionTime      int                    `json:"detection_time"`
+	JumboFrame               bool                   `json:"jumbo_frame,omitempty"`
 }
 
 type BackupLinkInfo struct {
@@ -416,6 +435,11 @@ type GatewayPhase2PolicyResponse struct {
 	Ph2PfsPolicy        string `json:"ph2_pfs_policy,omitempty"`
 }
 
+// GatewayMetadata holds the user defined metadata the controller stores for a gateway.
+type GatewayMetadata struct {
+	Description string `json:"description"`
+}
+
 func (c *Client) CreateGateway(gateway *Gateway) error {
 	gateway.CID = c.CID
 	gateway.Action = "connect_container"
@@ -514,6 +538,18 @@ func (c *Client) EditPrivateRouteTableConfig(gateway *Gateway, routeTables []str
 	return c.PostAPI(data["action"], data, BasicCheck)
 }
 
+// EditGcpPrivateRoutes sets the routes of the VPC network of a GCP gateway that are treated as private
+// routes. An empty list removes all private routes.
+func (c *Client) EditGcpPrivateRoutes(gateway *Gateway, routes []string) error {
+	data := map[string]string{
+		"action":         "edit_gcp_private_routes",
+		"CID":            c.CID,
+		"gateway_name":   gateway.GwName,
+		"private_routes": strings.Join(routes, ","),
+	}
+	return c.PostAPI(data["action"], data, BasicCheck)
+}
+
 func (c *Client) EnableGuardDutyEnforcement(gateway *Gateway) error {
 	data := map[string]string{
 		"action":       "enable_public_subnet_filtering_guard_duty_enforced_mode",
@@ -581,6 +617,108 @@ func (c *Client) GetGateway(gateway *Gateway) (*Gateway, error) {
 	return nil, ErrNotFound
 }
 
+// gatewayCache holds the raw list_vpcs_summary results by gateway name. Every lookup decodes its own
+// Gateway, so callers never share slices or maps with the cache or with each other.
+type gatewayCache struct {
+	names []string
+	raw   map[string]json.RawMessage
+}
+
+// ListGateways returns every gateway on the controller using a single list_vpcs_summary call. The
+// list is fetched once per client, and the provider starts a new client for every plan and apply, so
+// refreshing many gateway resources only queries the controller once. Resources that change a
+// gateway drop it from the cache with ForgetCachedGateway before reading it back. Writes are not
+// tracked otherwise, so a cached gateway may be stale once another resource changed it in the same
+// apply. Lookups of gateways managed by other resources during an apply must use GetGateway.
+func (c *Client) ListGateways() ([]Gateway, error) {
+	c.gatewayCacheMutex.Lock()
+	defer c.gatewayCacheMutex.Unlock()
+	if err := c.loadGatewayCache(); err != nil {
+		return nil, err
+	}
+
+	var gwList []Gateway
+	for _, name := range c.cachedGateways.names {
+		raw, ok := c.cachedGateways.raw[name]
+		if !ok {
+			continue
+		}
+		gw, err := decodeListedGateway(raw)
+		if err != nil {
+			return nil, err
+		}
+		gwList = append(gwList, *gw)
+	}
+	return gwList, nil
+}
+
+// GetListedGateway returns the gateway with the given name from the gateway list cached by
+// ListGateways. It returns ErrNotFound if the gateway is not in the list or was forgotten.
+func (c *Client) GetListedGateway(name string) (*Gateway, error) {
+	c.gatewayCacheMutex.Lock()
+	defer c.gatewayCacheMutex.Unlock()
+	if err := c.loadGatewayCache(); err != nil {
+		return nil, err
+	}
+
+	raw, ok := c.cachedGateways.raw[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return decodeListedGateway(raw)
+}
+
+// ForgetCachedGateway drops a gateway from the cached gateway list, so that the next lookup of the
+// gateway queries the controller.
+func (c *Client) ForgetCachedGateway(name string) {
+	c.gatewayCacheMutex.Lock()
+	defer c.gatewayCacheMutex.Unlock()
+	if c.cachedGateways != nil {
+		delete(c.cachedGateways.raw, name)
+	}
+}
+
+// loadGatewayCache fetches the gateway list unless it is already cached. The caller must hold
+// gatewayCacheMutex.
+func (c *Client) loadGatewayCache() error {
+	if c.cachedGateways != nil {
+		return nil
+	}
+	action := "list_vpcs_summary"
+	params := map[string]string{
+		"CID":    c.CID,
+		"action": action,
+	}
+	var data struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	err := c.GetAPI(&data, action, params, BasicCheck)
+	if err != nil {
+		return err
+	}
+	cache := &gatewayCache{raw: make(map[string]json.RawMessage, len(data.Results))}
+	for _, raw := range data.Results {
+		gw, err := decodeListedGateway(raw)
+		if err != nil {
+			return err
+		}
+		cache.names = append(cache.names, gw.GwName)
+		cache.raw[gw.GwName] = raw
+	}
+	c.cachedGateways = cache
+	return nil
+}
+
+func decodeListedGateway(raw json.RawMessage) (*Gateway, error) {
+	var gw Gateway
+	if err := json.Unmarshal(raw, &gw); err != nil {
+		return nil, fmt.Errorf("json decode of list_vpcs_summary gateway failed: %w", err)
+	}
+	// AllocateNewEipRead should default to true when not set by backend
+	gw.AllocateNewEipRead = gw.AllocateNewEipReadPtr == nil || *gw.AllocateNewEipReadPtr
+	return &gw, nil
+}
+
 func (c *Client) GetTransitGatewayList(ctx context.Context) ([]Gateway, error) {
 	action := "list_vpcs_summary"
 	params := map[string]string{
@@ -778,6 +916,52 @@ func (c *Client) SetVpnGatewayAuthentication(gateway *VpnGatewayAuth) error {
 	return c.PostAPI(gateway.Action, gateway, BasicCheck)
 }
 
+// SetVpnClientCertAuth enables certificate-based authentication of the VPN clients of the gateway, with
+// client certificates signed by the CA certificate caCertName. An empty caCertName disables it.
+func (c *Client) SetVpnClientCertAuth(gwName, caCertName string) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "disable_vpn_client_cert_auth",
+		"gateway_name": gwName,
+	}
+	if caCertName != "" {
+		form["action"] = "enable_vpn_client_cert_auth"
+		form["ca_cert_name"] = caCertName
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetVpnClientCertAuth returns the name of the CA certificate used to authenticate the VPN clients of the
+// gateway, or an empty string if certificate-based authentication is disabled.
+func (c *Client) GetVpnClientCertAuth(gwName string) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_vpn_client_cert_auth",
+		"gateway_name": gwName,
+	}
+
+	type VpnClientCertAuthResults struct {
+		Enabled    bool   `json:"enabled"`
+		CaCertName string `json:"ca_cert_name"`
+	}
+
+	type VpnClientCertAuthResp struct {
+		Return  bool                     `json:"return"`
+		Results VpnClientCertAuthResults `json:"results"`
+		Reason  string                   `json:"reason"`
+	}
+
+	var resp VpnClientCertAuthResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	if !resp.Results.Enabled {
+		return "", nil
+	}
+	return resp.Results.CaCertName, nil
+}
+
 func (c *Client) EnableVpcDNSServer(gateway *Gateway) error {
 	form := map[string]string{
 		"CID":          c.CID,
@@ -871,6 +1055,37 @@ func (c *Client) EnableEncryptVolume(gateway *Gateway) error {
 	return c.PostAPI(form["action"], form, checkFunc)
 }
 
+// GetKmsKeyIDByAlias resolves the ARN of an AWS KMS key alias to the ID of the key it refers to, looking it
+// up with the given access account.
+func (c *Client) GetKmsKeyIDByAlias(accountName, aliasArn string) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_kms_key_id_by_alias",
+		"account_name": accountName,
+		"key_alias":    aliasArn,
+	}
+
+	type KmsKeyResults struct {
+		KeyID string `json:"key_id"`
+	}
+
+	type KmsKeyResp struct {
+		Return  bool          `json:"return"`
+		Results KmsKeyResults `json:"results"`
+		Reason  string        `json:"reason"`
+	}
+
+	var resp KmsKeyResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	return resp.Results.KeyID, nil
+}
+
+// EditGatewayCustomRoutes replaces the customized VPC routes of a gateway. Routes in
+// gateway.CustomizedRoutes are sent with their next hops and take precedence over
+// gateway.CustomizedSpokeVpcRoutes.
 func (c *Client) EditGatewayCustomRoutes(gateway *Gateway) error {
 	form := map[string]string{
 		"CID":          c.CID,
@@ -878,6 +1093,15 @@ func (c *Client) EditGatewayCustomRoutes(gateway *Gateway) error {
 		"gateway_name": gateway.GwName,
 		"cidr":         strings.Join(gateway.CustomizedSpokeVpcRoutes, ","),
 	}
+	if len(gateway.CustomizedRoutes) != 0 {
+		var cidrs, nextHops []string
+		for _, route := range gateway.CustomizedRoutes {
+			cidrs = append(cidrs, route.Cidr)
+			nextHops = append(nextHops, route.NextHop)
+		}
+		form["cidr"] = strings.Join(cidrs, ",")
+		form["next_hop"] = strings.Join(nextHops, ",")
+	}
 
 	return c.PostAPI(form["action"], form, BasicCheck)
 }
@@ -1201,6 +1425,17 @@ func (c *Client) UpdateTransitGatewayCustomizedVpcRoute(gateway string, customiz
 	return c.PostAPI(params["action"], params, BasicCheck)
 }
 
+func (c *Client) UpdateTransitGatewayFilteredVpcRoute(gateway string, filteredTransitVpcRoutes []string) error {
+	params := map[string]string{
+		"action":          "edit_transit_gateway_filtered_vpc_route",
+		"CID":             c.CID,
+		"gateway_name":    gateway,
+		"filtered_routes": strings.Join(filteredTransitVpcRoutes, ","),
+	}
+
+	return c.PostAPI(params["action"], params, BasicCheck)
+}
+
 func (c *Client) EnableJumboFrame(gateway *Gateway) error {
 	action := "enable_jumbo_frame"
 	form := map[string]string{
@@ -1279,6 +1514,59 @@ func (c *Client) DisableSkipPublicRouteUpdate(gw *Gateway) error {
 	return c.PostAPI(form["action"], form, BasicCheck)
 }
 
+// SetCloudRouteTablePropagation enables or disables the programming of the cloud-native route tables of
+// the gateway's VPC/VNet by the controller.
+func (c *Client) SetCloudRouteTablePropagation(gw *Gateway, enable bool) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "disable_cloud_route_table_propagation",
+		"gateway_name": gw.GwName,
+	}
+	if enable {
+		form["action"] = "enable_cloud_route_table_propagation"
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// SetGatewayAdminSSH enables or disables admin SSH access to the gateway instance.
+func (c *Client) SetGatewayAdminSSH(gwName string, enable bool) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "disable_gateway_admin_ssh",
+		"gateway_name": gwName,
+	}
+	if enable {
+		form["action"] = "enable_gateway_admin_ssh"
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetGatewayAdminSSH returns whether admin SSH access to the gateway instance is enabled.
+func (c *Client) GetGatewayAdminSSH(gwName string) (bool, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_admin_ssh",
+		"gateway_name": gwName,
+	}
+
+	type GatewayAdminSSHResults struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	type GatewayAdminSSHResp struct {
+		Return  bool                   `json:"return"`
+		Results GatewayAdminSSHResults `json:"results"`
+		Reason  string                 `json:"reason"`
+	}
+
+	var resp GatewayAdminSSHResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return false, err
+	}
+	return resp.Results.Enabled, nil
+}
+
 // Entity should be gateway name or "Controller"
 func (c *Client) GetTunnelDetectionTime(entity string) (int, error) {
 	form := map[string]string{
@@ -1338,6 +1626,18 @@ func (c *Client) SetRxQueueSize(gateway *Gateway) error {
 	return c.PostAPI(form["action"], form, BasicCheck)
 }
 
+// UpdateSpotPrice changes the max spot price of a running spot instance gateway
+func (c *Client) UpdateSpotPrice(gwName, price string) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "update_spot_instance_price",
+		"gateway_name": gwName,
+		"spot_price":   price,
+	}
+
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
 func DiffSuppressFuncGatewaySNat(k, old, new string, d *schema.ResourceData) bool {
 	// connection_policy
 	raw := d.Get("connection_policy")
@@ -1550,6 +1850,436 @@ func (c *Client) DisableIPv6(gateway *Gateway) error {
 	return c.PostAPI(action, form, BasicCheck)
 }
 
+// GetGatewayIPv6Operational returns whether the IPv6 stack of the gateway is up, as opposed to only
+// being configured.
+func (c *Client) GetGatewayIPv6Operational(gwName string) (bool, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_gateway_ipv6_status",
+		"gateway_name": gwName,
+	}
+
+	type GatewayIPv6StatusResults struct {
+		Operational bool `json:"operational"`
+	}
+
+	type GatewayIPv6StatusResp struct {
+		Return  bool                     `json:"return"`
+		Results GatewayIPv6StatusResults `json:"results"`
+		Reason  string                   `json:"reason"`
+	}
+
+	var resp GatewayIPv6StatusResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return false, err
+	}
+	return resp.Results.Operational, nil
+}
+
+// GetGatewayEipAllocationID returns the AWS allocation ID of the Elastic IP associated with the gateway.
+func (c *Client) GetGatewayEipAllocationID(gwName string) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_gateway_eip_allocation_id",
+		"gateway_name": gwName,
+	}
+
+	type EipAllocationResults struct {
+		AllocationID string `json:"allocation_id"`
+	}
+
+	type EipAllocationResp struct {
+		Return  bool                 `json:"return"`
+		Results EipAllocationResults `json:"results"`
+		Reason  string               `json:"reason"`
+	}
+
+	var resp EipAllocationResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	return resp.Results.AllocationID, nil
+}
+
+// GetInstanceVpcID returns the ID of the VPC the given cloud instance belongs to.
+func (c *Client) GetInstanceVpcID(accountName, region, instanceID string) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_instance_vpc_id",
+		"account_name": accountName,
+		"region":       region,
+		"instance_id":  instanceID,
+	}
+
+	type InstanceVpcResults struct {
+		VpcID string `json:"vpc_id"`
+	}
+
+	type InstanceVpcResp struct {
+		Return  bool               `json:"return"`
+		Results InstanceVpcResults `json:"results"`
+		Reason  string             `json:"reason"`
+	}
+
+	var resp InstanceVpcResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	return resp.Results.VpcID, nil
+}
+
+// GetInstanceIDsByName returns the IDs of the cloud instances in the given VPC whose Name tag is instanceName.
+func (c *Client) GetInstanceIDsByName(accountName, region, vpcID, instanceName string) ([]string, error) {
+	form := map[string]string{
+		"CID":           c.CID,
+		"action":        "list_instance_ids_by_name",
+		"account_name":  accountName,
+		"region":        region,
+		"vpc_id":        vpcID,
+		"instance_name": instanceName,
+	}
+
+	type InstanceIDsResults struct {
+		InstanceIDs []string `json:"instance_ids"`
+	}
+
+	type InstanceIDsResp struct {
+		Return  bool               `json:"return"`
+		Results InstanceIDsResults `json:"results"`
+		Reason  string             `json:"reason"`
+	}
+
+	var resp InstanceIDsResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results.InstanceIDs, nil
+}
+
+// GetGatewayAttachedRouteTables returns the IDs of the AWS route tables programmed by the gateway.
+func (c *Client) GetGatewayAttachedRouteTables(gwName string) ([]string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "list_gateway_attached_route_tables",
+		"gateway_name": gwName,
+	}
+
+	type AttachedRouteTablesResults struct {
+		RouteTableIDs []string `json:"route_table_ids"`
+	}
+
+	type AttachedRouteTablesResp struct {
+		Return  bool                       `json:"return"`
+		Results AttachedRouteTablesResults `json:"results"`
+		Reason  string                     `json:"reason"`
+	}
+
+	var resp AttachedRouteTablesResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results.RouteTableIDs, nil
+}
+
+// GetGatewaySnatPublicIP returns the public IP that the egress traffic of the gateway is source NATed to.
+func (c *Client) GetGatewaySnatPublicIP(gwName string) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_gateway_snat_public_ip",
+		"gateway_name": gwName,
+	}
+
+	type SnatPublicIPResults struct {
+		PublicIP string `json:"public_ip"`
+	}
+
+	type SnatPublicIPResp struct {
+		Return  bool                `json:"return"`
+		Results SnatPublicIPResults `json:"results"`
+		Reason  string              `json:"reason"`
+	}
+
+	var resp SnatPublicIPResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	return resp.Results.PublicIP, nil
+}
+
+// GatewayMaintenanceWindow is the weekly window, in UTC, in which the controller may upgrade a gateway.
+type GatewayMaintenanceWindow struct {
+	DayOfWeek string `json:"day_of_week"`
+	Hour      int    `json:"hour"`
+}
+
+// SetGatewayMaintenanceWindow registers the window in which the controller may upgrade the gateway.
+// A nil window removes it so that upgrades are no longer restricted.
+func (c *Client) SetGatewayMaintenanceWindow(gwName string, window *GatewayMaintenanceWindow) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "delete_gateway_maintenance_window",
+		"gateway_name": gwName,
+	}
+	if window != nil {
+		form["action"] = "set_gateway_maintenance_window"
+		form["day_of_week"] = window.DayOfWeek
+		form["hour"] = strconv.Itoa(window.Hour)
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetGatewayMaintenanceWindow returns the window in which the controller may upgrade the gateway, or nil if
+// none is registered.
+func (c *Client) GetGatewayMaintenanceWindow(gwName string) (*GatewayMaintenanceWindow, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_gateway_maintenance_window",
+		"gateway_name": gwName,
+	}
+
+	type GatewayMaintenanceWindowResp struct {
+		Return  bool                     `json:"return"`
+		Results GatewayMaintenanceWindow `json:"results"`
+		Reason  string                   `json:"reason"`
+	}
+
+	var resp GatewayMaintenanceWindowResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Results.DayOfWeek == "" {
+		return nil, nil
+	}
+	return &resp.Results, nil
+}
+
+// GatewaySnmpConfig is the SNMP agent configuration of a gateway.
+type GatewaySnmpConfig struct {
+	Version      string   `json:"version"`
+	Community    string   `json:"community"`
+	AllowedCidrs []string `json:"allowed_cidrs"`
+}
+
+// SetGatewaySnmpConfig enables the SNMP agent of the gateway with the given configuration. A nil configuration
+// disables the agent.
+func (c *Client) SetGatewaySnmpConfig(gwName string, snmp *GatewaySnmpConfig) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "disable_gateway_snmp",
+		"gateway_name": gwName,
+	}
+	if snmp != nil {
+		form["action"] = "enable_gateway_snmp"
+		form["version"] = snmp.Version
+		form["community"] = snmp.Community
+		form["allowed_cidrs"] = strings.Join(snmp.AllowedCidrs, ",")
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetGatewaySnmpConfig returns the SNMP agent configuration of the gateway, or nil if the agent is disabled.
+func (c *Client) GetGatewaySnmpConfig(gwName string) (*GatewaySnmpConfig, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_gateway_snmp_config",
+		"gateway_name": gwName,
+	}
+
+	type GatewaySnmpConfigResults struct {
+		Enabled bool `json:"enabled"`
+		GatewaySnmpConfig
+	}
+
+	type GatewaySnmpConfigResp struct {
+		Return  bool                     `json:"return"`
+		Results GatewaySnmpConfigResults `json:"results"`
+		Reason  string                   `json:"reason"`
+	}
+
+	var resp GatewaySnmpConfigResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Results.Enabled {
+		return nil, nil
+	}
+	return &resp.Results.GatewaySnmpConfig, nil
+}
+
+// GatewayTunnelStatus is the state of one IPsec tunnel terminated on a gateway.
+type GatewayTunnelStatus struct {
+	ConnectionName string `json:"connection_name"`
+	PeerGwName     string `json:"peer_gw_name"`
+	PeerIP         string `json:"peer_ip"`
+	Status         string `json:"status"`
+}
+
+// GetGatewayTunnelStatus returns the IPsec tunnels of the gateway together with their current status.
+func (c *Client) GetGatewayTunnelStatus(gwName string) ([]GatewayTunnelStatus, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "list_gateway_tunnel_status",
+		"gateway_name": gwName,
+	}
+
+	type GatewayTunnelStatusResp struct {
+		Return  bool                  `json:"return"`
+		Results []GatewayTunnelStatus `json:"results"`
+		Reason  string                `json:"reason"`
+	}
+
+	var resp GatewayTunnelStatusResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// GetGatewayBgpNeighborStatus returns the BGP neighbors of the gateway together with their current status,
+// sorted by remote IP and then remote AS number.
+func (c *Client) GetGatewayBgpNeighborStatus(gwName string) ([]ExternalBgpPeer, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "list_gateway_bgp_neighbor_status",
+		"gateway_name": gwName,
+	}
+
+	type GatewayBgpNeighborStatusResp struct {
+		Return  bool              `json:"return"`
+		Results []ExternalBgpPeer `json:"results"`
+		Reason  string            `json:"reason"`
+	}
+
+	var resp GatewayBgpNeighborStatusResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	neighbors := resp.Results
+	sortExternalBgpPeers(neighbors)
+	return neighbors, nil
+}
+
+// GetGatewayPeeringTunnelNames returns the names the controller assigned to the peering tunnels of the gateway.
+func (c *Client) GetGatewayPeeringTunnelNames(gwName string) ([]string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "list_gateway_peering_tunnels",
+		"gateway_name": gwName,
+	}
+
+	type GatewayPeeringTunnel struct {
+		TunnelName string `json:"tunnel_name"`
+	}
+
+	type GatewayPeeringTunnelsResp struct {
+		Return  bool                   `json:"return"`
+		Results []GatewayPeeringTunnel `json:"results"`
+		Reason  string                 `json:"reason"`
+	}
+
+	var resp GatewayPeeringTunnelsResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, tunnel := range resp.Results {
+		names = append(names, tunnel.TunnelName)
+	}
+	return names, nil
+}
+
+// ListGatewayInstanceSizes returns the instance sizes available for gateways of the given cloud type
+// in the given region.
+func (c *Client) ListGatewayInstanceSizes(cloudType int, region string) ([]string, error) {
+	form := map[string]string{
+		"CID":        c.CID,
+		"action":     "list_gateway_instance_sizes",
+		"cloud_type": strconv.Itoa(cloudType),
+		"region":     region,
+	}
+
+	type GatewayInstanceSizesResp struct {
+		Return  bool     `json:"return"`
+		Results []string `json:"results"`
+		Reason  string   `json:"reason"`
+	}
+
+	var resp GatewayInstanceSizesResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// GatewayPrivateIPAllocation describes the private IP addresses allocated to a gateway from its subnet.
+type GatewayPrivateIPAllocation struct {
+	PrivateIPCidr       string   `json:"private_ip_cidr"`
+	AllocatedPrivateIPs []string `json:"allocated_private_ips"`
+}
+
+// GetGatewayPrivateIPAllocation returns the subnet CIDR the gateway's private IP addresses are allocated from
+// together with every private IP address currently allocated to the gateway.
+func (c *Client) GetGatewayPrivateIPAllocation(gwName string) (*GatewayPrivateIPAllocation, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_gateway_private_ip_allocation",
+		"gateway_name": gwName,
+	}
+
+	type GatewayPrivateIPAllocationResp struct {
+		Return  bool                       `json:"return"`
+		Results GatewayPrivateIPAllocation `json:"results"`
+		Reason  string                     `json:"reason"`
+	}
+
+	var resp GatewayPrivateIPAllocationResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Results, nil
+}
+
+// GatewayUtilization is the current resource utilization reported by a gateway instance.
+type GatewayUtilization struct {
+	CpuPercent    float64 `json:"cpu_percent"`
+	MemoryPercent float64 `json:"memory_percent"`
+}
+
+// GetGatewayUtilization returns the current CPU and memory utilization of the gateway.
+func (c *Client) GetGatewayUtilization(gwName string) (*GatewayUtilization, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_gateway_resource_utilization",
+		"gateway_name": gwName,
+	}
+
+	type GatewayUtilizationResp struct {
+		Return  bool               `json:"return"`
+		Results GatewayUtilization `json:"results"`
+		Reason  string             `json:"reason"`
+	}
+
+	var resp GatewayUtilizationResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Results, nil
+}
+
 // SetGatewayPhase2Policy sets the phase2 encryption and pfs policy for the specified gateway.
 func (c *Client) SetGatewayPhase2Policy(gwName, encPolicy string, pfsPolicy string) error {
 	request := GatewayPhase2PolicyRequest{
@@ -1565,3 +2295,27 @@ func (c *Client) SetGatewayPhase2Policy(gwName, encPolicy string, pfsPolicy stri
 
 	return nil
 }
+
+// SetGatewayDescription stores the free-text description label of the specified gateway.
+// An empty description removes the label.
+func (c *Client) SetGatewayDescription(ctx context.Context, gwName, description string) error {
+	endpoint := fmt.Sprintf("%s/%s", gatewayMetadataEndpoint, gwName)
+	err := c.PutAPIContext25(ctx, endpoint, GatewayMetadata{Description: description})
+	if err != nil {
+		return fmt.Errorf("failed to set gateway description: %w", err)
+	}
+
+	return nil
+}
+
+// GetGatewayDescription returns the free-text description label of the specified gateway.
+func (c *Client) GetGatewayDescription(ctx context.Context, gwName string) (string, error) {
+	var metadata GatewayMetadata
+	endpoint := fmt.Sprintf("%s/%s", gatewayMetadataEndpoint, gwName)
+	err := c.GetAPIContext25(ctx, &metadata, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get gateway description: %w", err)
+	}
+
+	return metadata.Description, nil
+}
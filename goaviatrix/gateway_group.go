@@ -3,6 +3,7 @@ package goaviatrix
 import (
 	"context"
 	"fmt"
+	"strings"
 )
 
 // GatewayGroup represents a Gateway Group resource
@@ -148,6 +149,29 @@ func (c *Client) GetGatewayGroup(ctx context.Context, groupUUID string) (*Gatewa
 	return &resp.Results, nil
 }
 
+// gatewayGroupMembershipCollisionReasons are fragments of the reasons the controller returns when a gateway
+// is created in a gateway group whose membership changed since it was read.
+var gatewayGroupMembershipCollisionReasons = []string{
+	"gateway group membership has changed",
+	"already has a primary gateway",
+	"does not have a primary gateway",
+}
+
+// IsGatewayGroupMembershipCollision reports whether err was caused by the gateway group membership changing
+// between reading the group and creating a gateway in it.
+func IsGatewayGroupMembershipCollision(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, reason := range gatewayGroupMembershipCollisionReasons {
+		if strings.Contains(msg, reason) {
+			return true
+		}
+	}
+	return false
+}
+
 // UpdateGatewayGroup updates an existing gateway group
 func (c *Client) UpdateGatewayGroup(ctx context.Context, gatewayGroup *GatewayGroup) error {
 	gatewayGroup.Action = "update_gateway_group"
@@ -0,0 +1,738 @@
+package goaviatrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingRoundTripper answers every request with a fixed JSON body and records the
+// last request it received. Form encoded and multipart bodies as well as query strings
+// are decoded into form.
+type recordingRoundTripper struct {
+	body       string
+	statusCode int
+
+	method string
+	path   string
+	raw    []byte
+	form   url.Values
+
+	// requests counts every request received.
+	requests int
+}
+
+func (r *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.requests++
+	r.method = req.Method
+	r.path = req.URL.Path
+	r.raw = nil
+	r.form = req.URL.Query()
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			return nil, err
+		}
+		r.form = url.Values(req.MultipartForm.Value)
+	} else if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.raw = data
+		if strings.Contains(req.Header.Get("Content-Type"), "x-www-form-urlencoded") {
+			r.form, err = url.ParseQuery(string(data))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	statusCode := r.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	resp := &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(bytes.NewBufferString(r.body)),
+		Header:     make(http.Header),
+	}
+	resp.Header.Set("Content-Type", "application/json")
+	return resp, nil
+}
+
+// newRecordingClient returns a Client answering with the given JSON body together with the
+// round tripper recording the requests it sends.
+func newRecordingClient(body string) (*Client, *recordingRoundTripper) {
+	rt := &recordingRoundTripper{body: body}
+	return &Client{
+		HTTPClient: &http.Client{Transport: rt},
+		CID:        "mockCID",
+	}, rt
+}
+
+func TestUpdateTransitGatewayFilteredVpcRoute(t *testing.T) {
+	tests := []struct {
+		name           string
+		response       string
+		routes         []string
+		expectedRoutes string
+		expectError    string
+	}{
+		{
+			name:           "set filtered routes",
+			response:       `{"return": true, "results": "Filtered routes updated", "reason": ""}`,
+			routes:         []string{"10.0.0.0/16", "10.1.0.0/16"},
+			expectedRoutes: "10.0.0.0/16,10.1.0.0/16",
+		},
+		{
+			name:           "clear filtered routes",
+			response:       `{"return": true, "results": "Filtered routes updated", "reason": ""}`,
+			routes:         nil,
+			expectedRoutes: "",
+		},
+		{
+			name:        "gateway down",
+			response:    `{"return": false, "reason": "Cannot edit gateway when it is down"}`,
+			routes:      []string{"10.0.0.0/16"},
+			expectError: "when it is down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			err := client.UpdateTransitGatewayFilteredVpcRoute("transit-gw", tt.routes)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_transit_gateway_filtered_vpc_route", rt.form.Get("action"))
+			assert.Equal(t, "transit-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedRoutes, rt.form.Get("filtered_routes"))
+		})
+	}
+}
+
+func TestSetGatewayDescription(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		statusCode  int
+		response    string
+		expectError bool
+	}{
+		{
+			name:        "set description",
+			description: "inventory: team-a",
+			response:    `{"description": "inventory: team-a"}`,
+		},
+		{
+			name:        "update description",
+			description: "inventory: team-b",
+			response:    `{"description": "inventory: team-b"}`,
+		},
+		{
+			name:        "clear description",
+			description: "",
+			response:    `{"description": ""}`,
+		},
+		{
+			name:        "controller rejects description",
+			description: "inventory: team-a",
+			statusCode:  http.StatusBadRequest,
+			response:    `{"message": "gateway spoke-gw not found"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+			rt.statusCode = tt.statusCode
+
+			err := client.SetGatewayDescription(context.Background(), "spoke-gw", tt.description)
+			if tt.expectError {
+				assert.ErrorContains(t, err, "gateway spoke-gw not found")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, http.MethodPut, rt.method)
+			assert.Equal(t, "/v2.5/api/gateway-metadata/spoke-gw", rt.path)
+
+			var sent GatewayMetadata
+			assert.NoError(t, json.Unmarshal(rt.raw, &sent))
+			assert.Equal(t, tt.description, sent.Description)
+		})
+	}
+}
+
+func TestGetGatewayDescription(t *testing.T) {
+	client, rt := newRecordingClient(`{"description": "inventory: team-a"}`)
+
+	description, err := client.GetGatewayDescription(context.Background(), "spoke-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, "inventory: team-a", description)
+	assert.Equal(t, http.MethodGet, rt.method)
+	assert.Equal(t, "/v2.5/api/gateway-metadata/spoke-gw", rt.path)
+}
+
+func TestListGateways(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": [
+		{"vpc_name": "spoke-gw", "newly_allocated_eip": false},
+		{"vpc_name": "transit-gw"}
+	], "reason": ""}`)
+
+	gateways, err := client.ListGateways()
+	assert.NoError(t, err)
+	assert.Len(t, gateways, 2)
+	assert.Equal(t, "spoke-gw", gateways[0].GwName)
+	assert.False(t, gateways[0].AllocateNewEipRead)
+	assert.True(t, gateways[1].AllocateNewEipRead)
+	assert.Equal(t, "list_vpcs_summary", rt.form.Get("action"))
+	assert.Empty(t, rt.form.Get("gateway_name"))
+
+	// Modifying the returned gateways must not leak into the cache.
+	gateways[0].GwName = "renamed"
+	gateways[0].CustomizedSpokeVpcRoutes = []string{"10.0.0.0/16"}
+	gateways, err = client.ListGateways()
+	assert.NoError(t, err)
+	assert.Equal(t, "spoke-gw", gateways[0].GwName)
+	assert.Empty(t, gateways[0].CustomizedSpokeVpcRoutes)
+	assert.Equal(t, 1, rt.requests)
+
+	// Writes keep the cached list.
+	assert.NoError(t, client.PostAPI("edit_gateway", map[string]string{"action": "edit_gateway"}, BasicCheck))
+	gw, err := client.GetListedGateway("spoke-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, "spoke-gw", gw.GwName)
+	assert.Equal(t, 2, rt.requests)
+
+	// A forgotten gateway is no longer listed.
+	client.ForgetCachedGateway("spoke-gw")
+	_, err = client.GetListedGateway("spoke-gw")
+	assert.ErrorIs(t, err, ErrNotFound)
+	gateways, err = client.ListGateways()
+	assert.NoError(t, err)
+	assert.Len(t, gateways, 1)
+	assert.Equal(t, "transit-gw", gateways[0].GwName)
+	assert.Equal(t, 2, rt.requests)
+}
+
+func TestGetGatewayEipAllocationID(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"allocation_id": "eipalloc-0a1b2c3d4e5f67890"}, "reason": ""}`)
+
+	allocationID, err := client.GetGatewayEipAllocationID("spoke-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, "eipalloc-0a1b2c3d4e5f67890", allocationID)
+	assert.Equal(t, "get_gateway_eip_allocation_id", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestGetGatewayEipAllocationIDError(t *testing.T) {
+	client, _ := newRecordingClient(`{"return": false, "reason": "Gateway spoke-gw does not exist"}`)
+
+	allocationID, err := client.GetGatewayEipAllocationID("spoke-gw")
+	assert.ErrorContains(t, err, "does not exist")
+	assert.Empty(t, allocationID)
+}
+
+func TestGetGatewayUtilization(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"cpu_percent": 42.5, "memory_percent": 61.25}, "reason": ""}`)
+
+	utilization, err := client.GetGatewayUtilization("spoke-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, &GatewayUtilization{CpuPercent: 42.5, MemoryPercent: 61.25}, utilization)
+	assert.Equal(t, "get_gateway_resource_utilization", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestGetInstanceVpcID(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"vpc_id": "vpc-0a1b2c3d"}, "reason": ""}`)
+
+	vpcID, err := client.GetInstanceVpcID("aws-account", "us-west-2", "i-0a1b2c3d4e5f60001")
+	assert.NoError(t, err)
+	assert.Equal(t, "vpc-0a1b2c3d", vpcID)
+	assert.Equal(t, "get_instance_vpc_id", rt.form.Get("action"))
+	assert.Equal(t, "aws-account", rt.form.Get("account_name"))
+	assert.Equal(t, "us-west-2", rt.form.Get("region"))
+	assert.Equal(t, "i-0a1b2c3d4e5f60001", rt.form.Get("instance_id"))
+}
+
+func TestGetInstanceIDsByName(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"instance_ids": ["i-0a1b2c3d4e5f60001", "i-0a1b2c3d4e5f60002"]}, "reason": ""}`)
+
+	instanceIDs, err := client.GetInstanceIDsByName("aws-account", "us-west-2", "vpc-0a1b2c3d", "bastion")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"i-0a1b2c3d4e5f60001", "i-0a1b2c3d4e5f60002"}, instanceIDs)
+	assert.Equal(t, "list_instance_ids_by_name", rt.form.Get("action"))
+	assert.Equal(t, "aws-account", rt.form.Get("account_name"))
+	assert.Equal(t, "us-west-2", rt.form.Get("region"))
+	assert.Equal(t, "vpc-0a1b2c3d", rt.form.Get("vpc_id"))
+	assert.Equal(t, "bastion", rt.form.Get("instance_name"))
+}
+
+func TestGetGatewaySnatPublicIP(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"public_ip": "203.0.113.10"}, "reason": ""}`)
+
+	publicIP, err := client.GetGatewaySnatPublicIP("spoke-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, "203.0.113.10", publicIP)
+	assert.Equal(t, "get_gateway_snat_public_ip", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestUpdateSpotPrice(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": "done", "reason": ""}`)
+
+	err := client.UpdateSpotPrice("spoke-gw", "0.12")
+	assert.NoError(t, err)
+	assert.Equal(t, "update_spot_instance_price", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+	assert.Equal(t, "0.12", rt.form.Get("spot_price"))
+}
+
+func TestSetGatewayAdminSSH(t *testing.T) {
+	tests := []struct {
+		name           string
+		enable         bool
+		expectedAction string
+	}{
+		{
+			name:           "enable",
+			enable:         true,
+			expectedAction: "enable_gateway_admin_ssh",
+		},
+		{
+			name:           "disable",
+			expectedAction: "disable_gateway_admin_ssh",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "done", "reason": ""}`)
+
+			err := client.SetGatewayAdminSSH("spoke-gw", tt.enable)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAction, rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+		})
+	}
+}
+
+func TestGetGatewayAdminSSH(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"enabled": false}, "reason": ""}`)
+
+	enabled, err := client.GetGatewayAdminSSH("spoke-gw")
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+	assert.Equal(t, "show_gateway_admin_ssh", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestGetGatewayAttachedRouteTables(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"route_table_ids": ["rtb-0a1b2c3e", "rtb-0a1b2c3d"]}, "reason": ""}`)
+
+	routeTableIDs, err := client.GetGatewayAttachedRouteTables("spoke-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rtb-0a1b2c3e", "rtb-0a1b2c3d"}, routeTableIDs)
+	assert.Equal(t, "list_gateway_attached_route_tables", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestGetGatewayTunnelStatus(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": [
+		{"connection_name": "transit-peering", "peer_gw_name": "transit-gw-2", "peer_ip": "198.51.100.20", "status": "up"},
+		{"connection_name": "onprem-s2c", "peer_gw_name": "", "peer_ip": "203.0.113.5", "status": "down"}
+	], "reason": ""}`)
+
+	tunnels, err := client.GetGatewayTunnelStatus("transit-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, []GatewayTunnelStatus{
+		{ConnectionName: "transit-peering", PeerGwName: "transit-gw-2", PeerIP: "198.51.100.20", Status: "up"},
+		{ConnectionName: "onprem-s2c", PeerIP: "203.0.113.5", Status: "down"},
+	}, tunnels)
+	assert.Equal(t, "list_gateway_tunnel_status", rt.form.Get("action"))
+	assert.Equal(t, "transit-gw", rt.form.Get("gateway_name"))
+}
+
+func TestGetGatewayBgpNeighborStatus(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": [
+		{"neighbor_ip": "169.254.10.1", "neighbor_as_num": "65001", "status": "established", "bfd_enabled": true},
+		{"neighbor_ip": "10.10.0.2", "neighbor_as_num": "65010", "status": "active", "bfd_enabled": false}
+	], "reason": ""}`)
+
+	neighbors, err := client.GetGatewayBgpNeighborStatus("transit-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalBgpPeer{
+		{RemoteIP: "10.10.0.2", RemoteAS: "65010", Status: "active"},
+		{RemoteIP: "169.254.10.1", RemoteAS: "65001", Status: "established", BfdEnabled: true},
+	}, neighbors)
+	assert.Equal(t, "list_gateway_bgp_neighbor_status", rt.form.Get("action"))
+	assert.Equal(t, "transit-gw", rt.form.Get("gateway_name"))
+}
+
+func TestSetGatewayMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name           string
+		window         *GatewayMaintenanceWindow
+		expectedAction string
+		expectedDay    string
+		expectedHour   string
+	}{
+		{
+			name:           "set window",
+			window:         &GatewayMaintenanceWindow{DayOfWeek: "Sunday", Hour: 3},
+			expectedAction: "set_gateway_maintenance_window",
+			expectedDay:    "Sunday",
+			expectedHour:   "3",
+		},
+		{
+			name:           "remove window",
+			expectedAction: "delete_gateway_maintenance_window",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Maintenance window updated", "reason": ""}`)
+
+			err := client.SetGatewayMaintenanceWindow("spoke-gw", tt.window)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAction, rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedDay, rt.form.Get("day_of_week"))
+			assert.Equal(t, tt.expectedHour, rt.form.Get("hour"))
+		})
+	}
+}
+
+func TestGetGatewayMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected *GatewayMaintenanceWindow
+	}{
+		{
+			name:     "window registered",
+			response: `{"return": true, "results": {"day_of_week": "Sunday", "hour": 3}, "reason": ""}`,
+			expected: &GatewayMaintenanceWindow{DayOfWeek: "Sunday", Hour: 3},
+		},
+		{
+			name:     "no window",
+			response: `{"return": true, "results": {}, "reason": ""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			window, err := client.GetGatewayMaintenanceWindow("spoke-gw")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, window)
+			assert.Equal(t, "get_gateway_maintenance_window", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+		})
+	}
+}
+
+func TestGetGatewayPeeringTunnelNames(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": [{"tunnel_name": "spoke-gw--transit-gw-1"}], "reason": ""}`)
+
+	names, err := client.GetGatewayPeeringTunnelNames("spoke-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"spoke-gw--transit-gw-1"}, names)
+	assert.Equal(t, "list_gateway_peering_tunnels", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestEditGcpPrivateRoutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		routes         []string
+		expectedRoutes string
+	}{
+		{
+			name:           "set private routes",
+			routes:         []string{"default-route-1", "onprem-route"},
+			expectedRoutes: "default-route-1,onprem-route",
+		},
+		{
+			name:           "clear private routes",
+			routes:         nil,
+			expectedRoutes: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Private routes updated", "reason": ""}`)
+
+			err := client.EditGcpPrivateRoutes(&Gateway{GwName: "spoke-gw"}, tt.routes)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gcp_private_routes", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedRoutes, rt.form.Get("private_routes"))
+		})
+	}
+}
+
+func TestGetGatewayIPv6Operational(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"operational": true}, "reason": ""}`)
+
+	operational, err := client.GetGatewayIPv6Operational("spoke-gw")
+	assert.NoError(t, err)
+	assert.True(t, operational)
+	assert.Equal(t, "get_gateway_ipv6_status", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestListGatewayInstanceSizes(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": ["t3.small", "c5n.xlarge"], "reason": ""}`)
+
+	sizes, err := client.ListGatewayInstanceSizes(AWS, "us-east-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"t3.small", "c5n.xlarge"}, sizes)
+	assert.Equal(t, "list_gateway_instance_sizes", rt.form.Get("action"))
+	assert.Equal(t, "1", rt.form.Get("cloud_type"))
+	assert.Equal(t, "us-east-1", rt.form.Get("region"))
+}
+
+func TestGetGatewayPrivateIPAllocation(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"private_ip_cidr": "10.0.1.0/24",
+		"allocated_private_ips": ["10.0.1.10", "10.0.1.11"]}, "reason": ""}`)
+
+	allocation, err := client.GetGatewayPrivateIPAllocation("spoke-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, &GatewayPrivateIPAllocation{
+		PrivateIPCidr:       "10.0.1.0/24",
+		AllocatedPrivateIPs: []string{"10.0.1.10", "10.0.1.11"},
+	}, allocation)
+	assert.Equal(t, "get_gateway_private_ip_allocation", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestSetVpnClientCertAuth(t *testing.T) {
+	tests := []struct {
+		name           string
+		caCertName     string
+		expectedAction string
+	}{
+		{
+			name:           "enable client certificate authentication",
+			caCertName:     "vpn-client-ca",
+			expectedAction: "enable_vpn_client_cert_auth",
+		},
+		{
+			name:           "disable client certificate authentication",
+			expectedAction: "disable_vpn_client_cert_auth",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Client certificate authentication updated", "reason": ""}`)
+
+			err := client.SetVpnClientCertAuth("vpn-gw", tt.caCertName)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAction, rt.form.Get("action"))
+			assert.Equal(t, "vpn-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.caCertName, rt.form.Get("ca_cert_name"))
+		})
+	}
+}
+
+func TestGetVpnClientCertAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected string
+	}{
+		{
+			name:     "enabled",
+			response: `{"return": true, "results": {"enabled": true, "ca_cert_name": "vpn-client-ca"}, "reason": ""}`,
+			expected: "vpn-client-ca",
+		},
+		{
+			name:     "disabled",
+			response: `{"return": true, "results": {"enabled": false, "ca_cert_name": "vpn-client-ca"}, "reason": ""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			caCertName, err := client.GetVpnClientCertAuth("vpn-gw")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, caCertName)
+			assert.Equal(t, "get_vpn_client_cert_auth", rt.form.Get("action"))
+			assert.Equal(t, "vpn-gw", rt.form.Get("gateway_name"))
+		})
+	}
+}
+
+func TestSetCloudRouteTablePropagation(t *testing.T) {
+	tests := []struct {
+		name           string
+		enable         bool
+		expectedAction string
+	}{
+		{
+			name:           "enable propagation",
+			enable:         true,
+			expectedAction: "enable_cloud_route_table_propagation",
+		},
+		{
+			name:           "disable propagation",
+			expectedAction: "disable_cloud_route_table_propagation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Route table propagation updated", "reason": ""}`)
+
+			err := client.SetCloudRouteTablePropagation(&Gateway{GwName: "spoke-gw"}, tt.enable)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAction, rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+		})
+	}
+}
+
+func TestSetGatewaySnmpConfig(t *testing.T) {
+	tests := []struct {
+		name              string
+		snmp              *GatewaySnmpConfig
+		expectedAction    string
+		expectedVersion   string
+		expectedCommunity string
+		expectedCidrs     string
+	}{
+		{
+			name: "enable SNMP",
+			snmp: &GatewaySnmpConfig{
+				Version:      "v2c",
+				Community:    "monitoring",
+				AllowedCidrs: []string{"10.10.0.0/24", "10.20.0.0/24"},
+			},
+			expectedAction:    "enable_gateway_snmp",
+			expectedVersion:   "v2c",
+			expectedCommunity: "monitoring",
+			expectedCidrs:     "10.10.0.0/24,10.20.0.0/24",
+		},
+		{
+			name:           "disable SNMP",
+			expectedAction: "disable_gateway_snmp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "SNMP updated", "reason": ""}`)
+
+			err := client.SetGatewaySnmpConfig("spoke-gw", tt.snmp)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAction, rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedVersion, rt.form.Get("version"))
+			assert.Equal(t, tt.expectedCommunity, rt.form.Get("community"))
+			assert.Equal(t, tt.expectedCidrs, rt.form.Get("allowed_cidrs"))
+		})
+	}
+}
+
+func TestGetGatewaySnmpConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected *GatewaySnmpConfig
+	}{
+		{
+			name: "SNMP enabled",
+			response: `{"return": true, "results": {"enabled": true, "version": "v2c", "community": "monitoring",
+				"allowed_cidrs": ["10.10.0.0/24"]}, "reason": ""}`,
+			expected: &GatewaySnmpConfig{Version: "v2c", Community: "monitoring", AllowedCidrs: []string{"10.10.0.0/24"}},
+		},
+		{
+			name:     "SNMP disabled",
+			response: `{"return": true, "results": {"enabled": false}, "reason": ""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			snmp, err := client.GetGatewaySnmpConfig("spoke-gw")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, snmp)
+			assert.Equal(t, "get_gateway_snmp_config", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+		})
+	}
+}
+
+func TestGetKmsKeyIDByAlias(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"key_id": "1234abcd-12ab-34cd-56ef-1234567890ab"}, "reason": ""}`)
+
+	keyID, err := client.GetKmsKeyIDByAlias("aws-account", "arn:aws:kms:us-west-2:111122223333:alias/gateway-volumes")
+	assert.NoError(t, err)
+	assert.Equal(t, "1234abcd-12ab-34cd-56ef-1234567890ab", keyID)
+	assert.Equal(t, "get_kms_key_id_by_alias", rt.form.Get("action"))
+	assert.Equal(t, "aws-account", rt.form.Get("account_name"))
+	assert.Equal(t, "arn:aws:kms:us-west-2:111122223333:alias/gateway-volumes", rt.form.Get("key_alias"))
+}
+
+func TestEditGatewayCustomRoutes(t *testing.T) {
+	tests := []struct {
+		name             string
+		gateway          *Gateway
+		expectedCidr     string
+		expectedNextHops []string
+	}{
+		{
+			name:         "CIDRs only",
+			gateway:      &Gateway{GwName: "spoke-gw", CustomizedSpokeVpcRoutes: []string{"10.0.0.0/16", "10.1.0.0/16"}},
+			expectedCidr: "10.0.0.0/16,10.1.0.0/16",
+		},
+		{
+			name: "CIDRs with next hops",
+			gateway: &Gateway{GwName: "spoke-gw", CustomizedRoutes: []CustomizedRoute{
+				{Cidr: "10.0.0.0/16", NextHop: "10.10.0.5"},
+				{Cidr: "10.1.0.0/16", NextHop: "10.10.0.6"},
+			}},
+			expectedCidr:     "10.0.0.0/16,10.1.0.0/16",
+			expectedNextHops: []string{"10.10.0.5,10.10.0.6"},
+		},
+		{
+			name:         "clear routes",
+			gateway:      &Gateway{GwName: "spoke-gw"},
+			expectedCidr: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Customized routes updated", "reason": ""}`)
+
+			err := client.EditGatewayCustomRoutes(tt.gateway)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_custom_routes", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedCidr, rt.form.Get("cidr"))
+			assert.Equal(t, tt.expectedNextHops, rt.form["next_hop"])
+		})
+	}
+}
@@ -23,6 +23,7 @@ type RemoteSyslog struct {
 	PublicCertificate   string `form:"public_certificate,omitempty"`
 	PrivateKey          string `form:"private_key,omitempty"`
 	ExcludeGatewayInput string `form:"exclude_gateway_list,omitempty"`
+	Severity            string `form:"severity,omitempty" json:"severity"`
 }
 
 type PortWrapper string
@@ -58,6 +59,7 @@ type RemoteSyslogResp struct {
 	ExcludedGateways []string    `json:"excluded_gateway"`
 	Status           string      `json:"status"`
 	Notls            bool        `json:"notls"`
+	Severity         string      `json:"severity"`
 }
 
 func (c *Client) EnableRemoteSyslog(r *RemoteSyslog) error {
@@ -72,6 +74,9 @@ func (c *Client) EnableRemoteSyslog(r *RemoteSyslog) error {
 		"template":             r.Template,
 		"exclude_gateway_list": r.ExcludeGatewayInput,
 	}
+	if r.Severity != "" {
+		params["severity"] = r.Severity
+	}
 
 	var files []File
 
@@ -0,0 +1,56 @@
+package goaviatrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnableRemoteSyslogSeverity(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity string
+	}{
+		{
+			name:     "severity set",
+			severity: "warning",
+		},
+		{
+			name:     "controller default",
+			severity: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Remote syslog enabled", "reason": ""}`)
+
+			err := client.EnableRemoteSyslog(&RemoteSyslog{
+				Index:    1,
+				Server:   "1.2.3.4",
+				Port:     514,
+				Protocol: "UDP",
+				Severity: tt.severity,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "enable_remote_syslog_logging", rt.form.Get("action"))
+			assert.Equal(t, "1", rt.form.Get("index"))
+			_, sent := rt.form["severity"]
+			assert.Equal(t, tt.severity != "", sent)
+			assert.Equal(t, tt.severity, rt.form.Get("severity"))
+		})
+	}
+}
+
+func TestGetRemoteSyslogStatusSeverity(t *testing.T) {
+	client, rt := newRecordingClient(`{
+		"return": true,
+		"results": {"server": "1.2.3.4", "port": 514, "protocol": "UDP", "index": "1", "status": "enabled", "severity": "err"},
+		"reason": ""
+	}`)
+
+	status, err := client.GetRemoteSyslogStatus(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "err", status.Severity)
+	assert.Equal(t, "get_remote_syslog_logging_status", rt.form.Get("action"))
+}
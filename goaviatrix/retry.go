@@ -0,0 +1,56 @@
+package goaviatrix
+
+import (
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultRetryCount is the number of times RetryableDo retries a failed call unless configured otherwise.
+	DefaultRetryCount = 10
+	// DefaultRetryDelay is the time RetryableDo waits before the first retry unless configured otherwise.
+	DefaultRetryDelay = 10 * time.Second
+	// MaxRetryDelay caps the time RetryableDo waits between attempts.
+	MaxRetryDelay = 2 * time.Minute
+)
+
+// RetryableDo calls fn until it succeeds, returns an error rejected by retryable or has been retried
+// RetryCount times. It waits RetryDelay before the first retry and doubles the wait after every
+// attempt, up to MaxRetryDelay. The error of the last attempt is returned.
+func (c *Client) RetryableDo(retryable func(error) bool, fn func() error) error {
+	for i := 0; ; i++ {
+		err := fn()
+		if err == nil || i >= c.RetryCount || !retryable(err) {
+			return err
+		}
+		delay := retryBackoff(c.RetryDelay, i)
+		log.Debugf("Retrying in %s after attempt %d failed: %s", delay, i+1, err)
+		time.Sleep(delay)
+	}
+}
+
+// retryBackoff returns the time to wait after the given zero-based failed attempt: base doubled once
+// per earlier attempt, capped at MaxRetryDelay. A base above MaxRetryDelay is used as is.
+func retryBackoff(base time.Duration, attempt int) time.Duration {
+	if base >= MaxRetryDelay {
+		return base
+	}
+	delay := base
+	for i := 0; i < attempt && delay < MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > MaxRetryDelay {
+		return MaxRetryDelay
+	}
+	return delay
+}
+
+// IsGatewayDownError returns whether err reports that the gateway or its HA gateway is down, which is
+// expected to be transient while the gateway is being brought up.
+func IsGatewayDownError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "when it is down") || strings.Contains(msg, "hagw is down") ||
+		strings.Contains(msg, "gateway is down")
+}
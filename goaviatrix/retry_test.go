@@ -0,0 +1,99 @@
+package goaviatrix
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryableDo(t *testing.T) {
+	gatewayDown := errors.New("Cannot edit gateway spoke-gw when it is down")
+	tests := []struct {
+		name             string
+		retryCount       int
+		errs             []error
+		expectError      string
+		expectedAttempts int
+	}{
+		{
+			name:             "success",
+			retryCount:       3,
+			errs:             []error{nil},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "transient gateway down errors",
+			retryCount:       3,
+			errs:             []error{gatewayDown, errors.New("spoke-gw-hagw is down"), nil},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "retries exhausted",
+			retryCount:       2,
+			errs:             []error{gatewayDown, gatewayDown, gatewayDown, nil},
+			expectError:      "when it is down",
+			expectedAttempts: 3,
+		},
+		{
+			name:             "retries disabled",
+			retryCount:       0,
+			errs:             []error{gatewayDown, nil},
+			expectError:      "when it is down",
+			expectedAttempts: 1,
+		},
+		{
+			name:             "non retryable error",
+			retryCount:       3,
+			errs:             []error{errors.New("invalid CIDR 10.0.0.0/33"), nil},
+			expectError:      "invalid CIDR",
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{RetryCount: tt.retryCount}
+			attempts := 0
+
+			err := client.RetryableDo(IsGatewayDownError, func() error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedAttempts, attempts)
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 0, expected: 10 * time.Second},
+		{attempt: 1, expected: 20 * time.Second},
+		{attempt: 3, expected: 80 * time.Second},
+		{attempt: 4, expected: MaxRetryDelay},
+		{attempt: 100, expected: MaxRetryDelay},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, retryBackoff(10*time.Second, tt.attempt), "attempt %d", tt.attempt)
+	}
+	assert.Equal(t, time.Duration(0), retryBackoff(0, 5))
+	assert.Equal(t, 5*time.Minute, retryBackoff(5*time.Minute, 3))
+}
+
+func TestIsGatewayDownError(t *testing.T) {
+	assert.True(t, IsGatewayDownError(errors.New("Cannot edit gateway spoke-gw when it is down")))
+	assert.True(t, IsGatewayDownError(errors.New("spoke-gw-hagw is down")))
+	assert.True(t, IsGatewayDownError(errors.New("gateway is down")))
+	assert.False(t, IsGatewayDownError(errors.New("gateway spoke-gw not found")))
+}
@@ -47,6 +47,8 @@ type SecurityDomainDetails struct {
 	AviatrixFirewallDomain bool      `json:"firewall_domain,omitempty"`
 	NativeEgressDomain     bool      `json:"egress_domain,omitempty"`
 	NativeFirewallDomain   bool      `json:"native_firewall_domain,omitempty"`
+	InspectionEnabled      bool      `json:"inspection_enabled,omitempty"`
+	FirewallInstances      []string  `json:"firewall_instances,omitempty"`
 }
 
 type VPCSolo struct {
@@ -199,6 +201,28 @@ func (c *Client) GetSecurityDomainDetails(ctx context.Context, domain *SecurityD
 	return &data.Results[0], nil
 }
 
+// ListSecurityDomains returns the details of all network domains of the given AWS TGW.
+func (c *Client) ListSecurityDomains(ctx context.Context, tgwName string) ([]SecurityDomainDetails, error) {
+	params := map[string]string{
+		"action":   "list_tgw_security_domain_details",
+		"CID":      c.CID,
+		"tgw_name": tgwName,
+	}
+
+	type Resp struct {
+		Return  bool                    `json:"return"`
+		Results []SecurityDomainDetails `json:"results"`
+		Reason  string                  `json:"reason"`
+	}
+
+	var data Resp
+	err := c.GetAPIContext(ctx, &data, params["action"], params, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return data.Results, nil
+}
+
 func (c *Client) EnableIntraDomainInspection(ctx context.Context, intraDomainInspection *IntraDomainInspection) error {
 	params := map[string]string{
 		"action":               "enable_tgw_intra_domain_inspection",
@@ -0,0 +1,51 @@
+package goaviatrix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListSecurityDomains(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": [
+		{"name": "Default_Domain"},
+		{"name": "firewall-domain", "firewall_domain": true, "inspection_enabled": true},
+		{"name": "egress-domain", "egress_domain": true}
+	], "reason": ""}`)
+
+	domains, err := client.ListSecurityDomains(context.Background(), "tgw-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []SecurityDomainDetails{
+		{Name: "Default_Domain"},
+		{Name: "firewall-domain", AviatrixFirewallDomain: true, InspectionEnabled: true},
+		{Name: "egress-domain", NativeEgressDomain: true},
+	}, domains)
+	assert.Equal(t, "list_tgw_security_domain_details", rt.form.Get("action"))
+	assert.Equal(t, "tgw-1", rt.form.Get("tgw_name"))
+	assert.Empty(t, rt.form.Get("route_domain_name"))
+}
+
+func TestListSecurityDomainsEmpty(t *testing.T) {
+	client, _ := newRecordingClient(`{"return": true, "results": [], "reason": ""}`)
+
+	domains, err := client.ListSecurityDomains(context.Background(), "tgw-1")
+	assert.NoError(t, err)
+	assert.Empty(t, domains)
+}
+
+func TestCreateAndDeleteDomainConnection(t *testing.T) {
+	awsTgw := &AWSTgw{Name: "tgw"}
+	client, rt := newRecordingClient(`{"return": true, "results": "ok", "reason": ""}`)
+
+	assert.NoError(t, client.CreateDomainConnection(awsTgw, "dev", "prod"))
+	assert.Equal(t, "add_connection_between_route_domains", rt.form.Get("action"))
+	assert.Equal(t, "tgw", rt.form.Get("tgw_name"))
+	assert.Equal(t, "dev", rt.form.Get("source_route_domain_name"))
+	assert.Equal(t, "prod", rt.form.Get("destination_route_domain_name"))
+
+	assert.NoError(t, client.DeleteDomainConnection(awsTgw, "dev", "prod"))
+	assert.Equal(t, "delete_connection_between_route_domains", rt.form.Get("action"))
+	assert.Equal(t, "dev", rt.form.Get("source_route_domain_name"))
+	assert.Equal(t, "prod", rt.form.Get("destination_route_domain_name"))
+}
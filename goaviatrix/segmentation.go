@@ -3,7 +3,8 @@ package goaviatrix
 import "strings"
 
 type SegmentationSecurityDomain struct {
-	DomainName string
+	DomainName            string
+	AssociatedConnections []SegmentationSecurityDomainAssociation
 }
 
 type SegmentationSecurityDomainConnectionPolicy struct {
@@ -67,6 +68,17 @@ func (c *Client) GetSegmentationSecurityDomain(domain *SegmentationSecurityDomai
 		return nil, ErrNotFound
 	}
 
+	associations, err := c.listSegmentationSecurityDomainAssociations()
+	if err != nil {
+		return nil, err
+	}
+	domain.AssociatedConnections = nil
+	for _, association := range associations {
+		if association.SecurityDomainName == domain.DomainName {
+			domain.AssociatedConnections = append(domain.AssociatedConnections, association)
+		}
+	}
+
 	return domain, nil
 }
 
@@ -147,7 +159,9 @@ func (c *Client) DeleteSegmentationSecurityDomainAssociation(association *Segmen
 	return c.PostAPI(action, data, BasicCheck)
 }
 
-func (c *Client) GetSegmentationSecurityDomainAssociation(association *SegmentationSecurityDomainAssociation) (*SegmentationSecurityDomainAssociation, error) {
+// listSegmentationSecurityDomainAssociations returns the attachments associated to any network domain, with
+// the attachment names in the format used by aviatrix_segmentation_network_domain_association.
+func (c *Client) listSegmentationSecurityDomainAssociations() ([]SegmentationSecurityDomainAssociation, error) {
 	form := map[string]string{
 		"CID":    c.CID,
 		"action": "list_multi_cloud_domain_attachments",
@@ -177,7 +191,7 @@ func (c *Client) GetSegmentationSecurityDomainAssociation(association *Segmentat
 		return nil, err
 	}
 
-	found := false
+	associations := make([]SegmentationSecurityDomainAssociation, 0, len(data.Results.Attachments))
 	for _, attachment := range data.Results.Attachments {
 		if attachment.Type == "EDGESPOKE" {
 			attachmentNameElements := strings.Split(attachment.Name, ":")
@@ -189,9 +203,26 @@ func (c *Client) GetSegmentationSecurityDomainAssociation(association *Segmentat
 			attachment.Name = siteId + ":" + vlanId
 		}
 
-		if attachment.Domain == association.SecurityDomainName && attachment.Name == association.AttachmentName {
+		associations = append(associations, SegmentationSecurityDomainAssociation{
+			TransitGatewayName: attachment.TransitName,
+			SecurityDomainName: attachment.Domain,
+			AttachmentName:     attachment.Name,
+		})
+	}
+	return associations, nil
+}
+
+func (c *Client) GetSegmentationSecurityDomainAssociation(association *SegmentationSecurityDomainAssociation) (*SegmentationSecurityDomainAssociation, error) {
+	associations, err := c.listSegmentationSecurityDomainAssociations()
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, attachment := range associations {
+		if attachment.SecurityDomainName == association.SecurityDomainName && attachment.AttachmentName == association.AttachmentName {
 			found = true
-			association.TransitGatewayName = attachment.TransitName
+			association.TransitGatewayName = attachment.TransitGatewayName
 		}
 	}
 
@@ -0,0 +1,33 @@
+package goaviatrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListSegmentationSecurityDomainAssociations(t *testing.T) {
+	client, _ := newRecordingClient(`{"return": true, "results": {"attachments": [
+		{"name": "spoke-gw", "domain": "domain-a", "transit_name": "transit-gw", "type": "SPOKE"},
+		{"name": "edge-spoke:edge-site", "domain": "domain-a", "transit_name": "transit-gw", "type": "EDGESPOKE"},
+		{"name": "site-1:vlan:100", "domain": "domain-b", "transit_name": "transit-gw", "type": "EDGEVLAN"}
+	]}, "reason": ""}`)
+
+	associations, err := client.listSegmentationSecurityDomainAssociations()
+	assert.NoError(t, err)
+	assert.Equal(t, []SegmentationSecurityDomainAssociation{
+		{TransitGatewayName: "transit-gw", SecurityDomainName: "domain-a", AttachmentName: "spoke-gw"},
+		{TransitGatewayName: "transit-gw", SecurityDomainName: "domain-a", AttachmentName: "edge-spoke"},
+		{TransitGatewayName: "transit-gw", SecurityDomainName: "domain-b", AttachmentName: "site-1:100"},
+	}, associations)
+}
+
+func TestGetSegmentationSecurityDomainNotFound(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": ["domain-b"], "reason": ""}`)
+
+	domain, err := client.GetSegmentationSecurityDomain(&SegmentationSecurityDomain{DomainName: "domain-a"})
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Nil(t, domain)
+	assert.Equal(t, "list_multi_cloud_security_domain_names", rt.form.Get("action"))
+}
@@ -64,6 +64,9 @@ func (c *Client) ModifySplitTunnel(splitTunnel *SplitTunnel) error {
 		"search_domains":   splitTunnel.SearchDomains,
 	}
 
+	if splitTunnel.SaveTemplate != "" {
+		form["save_template"] = splitTunnel.SaveTemplate
+	}
 	if splitTunnel.Dns == "true" {
 		form["dns"] = "true"
 	}
@@ -0,0 +1,52 @@
+package goaviatrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModifySplitTunnelSaveTemplate(t *testing.T) {
+	tests := []struct {
+		name                 string
+		saveTemplate         string
+		expectedSaveTemplate string
+		expectSent           bool
+	}{
+		{
+			name:                 "save template",
+			saveTemplate:         "yes",
+			expectedSaveTemplate: "yes",
+			expectSent:           true,
+		},
+		{
+			name:                 "do not save template",
+			saveTemplate:         "no",
+			expectedSaveTemplate: "no",
+			expectSent:           true,
+		},
+		{
+			name:       "save template not specified",
+			expectSent: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Split tunnel modified", "reason": ""}`)
+
+			err := client.ModifySplitTunnel(&SplitTunnel{
+				VpcID:        "vpc-0123",
+				ElbName:      "vpn-gw",
+				SplitTunnel:  "yes",
+				SaveTemplate: tt.saveTemplate,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "modify_split_tunnel", rt.form.Get("action"))
+			assert.Equal(t, "modify", rt.form.Get("command"))
+			_, sent := rt.form["save_template"]
+			assert.Equal(t, tt.expectSent, sent)
+			assert.Equal(t, tt.expectedSaveTemplate, rt.form.Get("save_template"))
+		})
+	}
+}
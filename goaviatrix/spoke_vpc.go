@@ -1,7 +1,10 @@
 package goaviatrix
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -36,11 +39,13 @@ type SpokeVpc struct {
 	BgpManualSpokeAdvertiseCidrs string `form:"bgp_manual_spoke,omitempty"`
 	EncVolume                    string `form:"enc_volume,omitempty"`
 	CustomerManagedKeys          string `form:"cmk,omitempty"`
+	IamInstanceProfile           string `form:"iam_instance_profile,omitempty"`
 	EnablePrivateOob             string `form:"private_oob,omitempty"`
 	OobManagementSubnet          string `form:"oob_mgmt_subnet,omitempty"`
 	HAOobManagementSubnet        string
 	AvailabilityDomain           string   `form:"availability_domain,omitempty"`
 	FaultDomain                  string   `form:"fault_domain,omitempty"`
+	AdditionalFaultDomains       string   `form:"additional_fault_domains,omitempty"`
 	EnableSpotInstance           bool     `form:"spot_instance,omitempty"`
 	SpotPrice                    string   `form:"spot_price,omitempty"`
 	DeleteSpot                   bool     `form:"delete_spot,omitempty"`
@@ -76,6 +81,25 @@ type SpokeGatewayAdvancedConfig struct {
 	ApprovedLearnedCidrs              []string
 }
 
+// ExternalBgpPeer describes an on-prem BGP neighbor connected to a gateway through an
+// external device connection. Status is only reported by GetGatewayBgpNeighborStatus.
+type ExternalBgpPeer struct {
+	RemoteIP   string `json:"neighbor_ip"`
+	RemoteAS   string `json:"neighbor_as_num"`
+	Status     string `json:"status,omitempty"`
+	BfdEnabled bool   `json:"bfd_enabled"`
+}
+
+// sortExternalBgpPeers sorts BGP peers by remote IP and then remote AS number.
+func sortExternalBgpPeers(peers []ExternalBgpPeer) {
+	sort.SliceStable(peers, func(i, j int) bool {
+		if peers[i].RemoteIP != peers[j].RemoteIP {
+			return peers[i].RemoteIP < peers[j].RemoteIP
+		}
+		return peers[i].RemoteAS < peers[j].RemoteAS
+	})
+}
+
 type SpokeGatewayAdvancedConfigResp struct {
 	Return  bool                                 `json:"return"`
 	Results SpokeGatewayAdvancedConfigRespResult `json:"results"`
@@ -150,6 +174,112 @@ func (c *Client) SpokeLeaveTransit(spoke *SpokeVpc) error {
 	return c.PostAPI(action, data, BasicCheck)
 }
 
+// SetSpokeTransitFailoverPriority sets the order in which the spoke gateway prefers the transit gateways it is
+// attached to. The first transit gateway is preferred, the others are failed over to in order.
+func (c *Client) SetSpokeTransitFailoverPriority(spokeGwName string, transitGwNames []string) error {
+	form := map[string]string{
+		"CID":             c.CID,
+		"action":          "set_spoke_transit_failover_priority",
+		"spoke_gw":        spokeGwName,
+		"transit_gw_list": strings.Join(transitGwNames, ","),
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeTransitFailoverPriority returns the transit gateways the spoke gateway is attached to, in failover
+// priority order.
+func (c *Client) GetSpokeTransitFailoverPriority(spokeGwName string) ([]string, error) {
+	form := map[string]string{
+		"CID":      c.CID,
+		"action":   "get_spoke_transit_failover_priority",
+		"spoke_gw": spokeGwName,
+	}
+
+	type SpokeTransitFailoverPriorityResults struct {
+		TransitGwList []string `json:"transit_gw_list"`
+	}
+
+	type SpokeTransitFailoverPriorityResp struct {
+		Return  bool                                `json:"return"`
+		Results SpokeTransitFailoverPriorityResults `json:"results"`
+		Reason  string                              `json:"reason"`
+	}
+
+	var resp SpokeTransitFailoverPriorityResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results.TransitGwList, nil
+}
+
+// SetSpokeTransitRouteTable associates the attachment of a spoke gateway to a transit gateway with the given
+// transit route table. An empty route table restores the default association.
+func (c *Client) SetSpokeTransitRouteTable(spokeGwName, transitGwName, routeTable string) error {
+	form := map[string]string{
+		"CID":         c.CID,
+		"action":      "edit_spoke_transit_route_table",
+		"spoke_gw":    spokeGwName,
+		"transit_gw":  transitGwName,
+		"route_table": routeTable,
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeTransitRouteTable returns the transit route table the attachment of a spoke gateway to a transit
+// gateway is associated with, or an empty string for the default association.
+func (c *Client) GetSpokeTransitRouteTable(spokeGwName, transitGwName string) (string, error) {
+	form := map[string]string{
+		"CID":        c.CID,
+		"action":     "show_spoke_transit_route_table",
+		"spoke_gw":   spokeGwName,
+		"transit_gw": transitGwName,
+	}
+
+	type SpokeTransitRouteTableResults struct {
+		RouteTable string `json:"route_table"`
+	}
+
+	type SpokeTransitRouteTableResp struct {
+		Return  bool                          `json:"return"`
+		Results SpokeTransitRouteTableResults `json:"results"`
+		Reason  string                        `json:"reason"`
+	}
+
+	var resp SpokeTransitRouteTableResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	return resp.Results.RouteTable, nil
+}
+
+// ListTransitRouteTables returns the names of the route tables of a transit gateway.
+func (c *Client) ListTransitRouteTables(transitGwName string) ([]string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "list_transit_gateway_route_tables",
+		"gateway_name": transitGwName,
+	}
+
+	type TransitRouteTablesResults struct {
+		RouteTables []string `json:"route_tables"`
+	}
+
+	type TransitRouteTablesResp struct {
+		Return  bool                      `json:"return"`
+		Results TransitRouteTablesResults `json:"results"`
+		Reason  string                    `json:"reason"`
+	}
+
+	var resp TransitRouteTablesResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results.RouteTables, nil
+}
+
 func (c *Client) EnableHaSpokeVpc(spoke *SpokeVpc) error {
 	form := map[string]string{
 		"CID":     c.CID,
@@ -336,6 +466,705 @@ func (c *Client) DisableActiveStandbySpoke(spokeGateway *SpokeVpc) error {
 	return c.PostAPI(action, form, BasicCheck)
 }
 
+// SetSpokeActiveStandbyFailoverDelay sets the number of seconds an active-standby spoke gateway waits
+// before failing over to the standby instance. A delay of 0 restores the controller default.
+func (c *Client) SetSpokeActiveStandbyFailoverDelay(spokeGateway *SpokeVpc, delay int) error {
+	form := map[string]string{
+		"CID":            c.CID,
+		"action":         "set_active_standby_failover_delay",
+		"gateway_name":   spokeGateway.GwName,
+		"failover_delay": strconv.Itoa(delay),
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeActiveGatewayInstance returns which instance of an active-standby spoke gateway pair is
+// currently active, either "primary" or "ha". An empty string is returned if the controller does
+// not report an active instance.
+func (c *Client) GetSpokeActiveGatewayInstance(spokeGateway *SpokeVpc) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_active_standby_status",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type ActiveStandbyStatusResults struct {
+		ActiveGateway string `json:"active_gateway"`
+	}
+
+	type ActiveStandbyStatusResp struct {
+		Return  bool                       `json:"return"`
+		Results ActiveStandbyStatusResults `json:"results"`
+		Reason  string                     `json:"reason"`
+	}
+
+	var resp ActiveStandbyStatusResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case resp.Results.ActiveGateway == "":
+		return "", nil
+	case strings.HasSuffix(resp.Results.ActiveGateway, "-hagw"):
+		return "ha", nil
+	default:
+		return "primary", nil
+	}
+}
+
+// GetSpokeExternalBgpPeers returns the external BGP peers of a spoke gateway sorted by remote IP
+// and then remote AS number.
+func (c *Client) GetSpokeExternalBgpPeers(spokeGateway *SpokeVpc) ([]ExternalBgpPeer, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "list_gateway_external_bgp_neighbors",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type ExternalBgpPeersResp struct {
+		Return  bool              `json:"return"`
+		Results []ExternalBgpPeer `json:"results"`
+		Reason  string            `json:"reason"`
+	}
+
+	var resp ExternalBgpPeersResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := resp.Results
+	sortExternalBgpPeers(peers)
+	return peers, nil
+}
+
+type BgpAdvertisedRoute struct {
+	Cidr string `json:"cidr"`
+}
+
+// GetSpokeBgpAdvertisedRoutes returns the routes a BGP spoke gateway currently advertises to its
+// BGP peers, sorted by CIDR.
+func (c *Client) GetSpokeBgpAdvertisedRoutes(spokeGateway *SpokeVpc) ([]BgpAdvertisedRoute, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "list_gateway_bgp_advertised_routes",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type BgpAdvertisedRoutesResp struct {
+		Return  bool                 `json:"return"`
+		Results []BgpAdvertisedRoute `json:"results"`
+		Reason  string               `json:"reason"`
+	}
+
+	var resp BgpAdvertisedRoutesResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := resp.Results
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].Cidr < routes[j].Cidr
+	})
+	return routes, nil
+}
+
+// SetSpokeTcpMssClamp sets the TCP MSS clamping value of a spoke gateway. A value of 0 disables
+// MSS clamping.
+func (c *Client) SetSpokeTcpMssClamp(spokeGateway *SpokeVpc, tcpMss int) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "edit_gateway_tcp_mss_clamp",
+		"gateway_name": spokeGateway.GwName,
+		"tcp_mss":      strconv.Itoa(tcpMss),
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeTcpMssClamp returns the TCP MSS clamping value of a spoke gateway, or 0 if MSS clamping
+// is disabled.
+func (c *Client) GetSpokeTcpMssClamp(spokeGateway *SpokeVpc) (int, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_tcp_mss_clamp",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type TcpMssClampResults struct {
+		TcpMss int `json:"tcp_mss"`
+	}
+
+	type TcpMssClampResp struct {
+		Return  bool               `json:"return"`
+		Results TcpMssClampResults `json:"results"`
+		Reason  string             `json:"reason"`
+	}
+
+	var resp TcpMssClampResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Results.TcpMss, nil
+}
+
+// SetSpokePeeringKeepaliveInterval sets the keepalive interval in seconds of the transit peering
+// connections of a spoke gateway. A value of 0 restores the controller default.
+func (c *Client) SetSpokePeeringKeepaliveInterval(spokeGateway *SpokeVpc, interval int) error {
+	form := map[string]string{
+		"CID":                c.CID,
+		"action":             "edit_gateway_peering_keepalive_interval",
+		"gateway_name":       spokeGateway.GwName,
+		"keepalive_interval": strconv.Itoa(interval),
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokePeeringKeepaliveInterval returns the keepalive interval in seconds of the transit peering
+// connections of a spoke gateway, or 0 if the controller default is used.
+func (c *Client) GetSpokePeeringKeepaliveInterval(spokeGateway *SpokeVpc) (int, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_peering_keepalive_interval",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type PeeringKeepaliveIntervalResults struct {
+		KeepaliveInterval int `json:"keepalive_interval"`
+	}
+
+	type PeeringKeepaliveIntervalResp struct {
+		Return  bool                            `json:"return"`
+		Results PeeringKeepaliveIntervalResults `json:"results"`
+		Reason  string                          `json:"reason"`
+	}
+
+	var resp PeeringKeepaliveIntervalResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Results.KeepaliveInterval, nil
+}
+
+// SetSpokeTimezone sets the IANA time zone of the log timestamps of a spoke gateway. An empty timezone
+// restores the time zone of the controller.
+func (c *Client) SetSpokeTimezone(spokeGateway *SpokeVpc, timezone string) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "edit_gateway_timezone",
+		"gateway_name": spokeGateway.GwName,
+		"timezone":     timezone,
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeTimezone returns the IANA time zone of the log timestamps of a spoke gateway, or an empty
+// string if the time zone of the controller is used.
+func (c *Client) GetSpokeTimezone(spokeGateway *SpokeVpc) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_timezone",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type SpokeTimezoneResults struct {
+		Timezone string `json:"timezone"`
+	}
+
+	type SpokeTimezoneResp struct {
+		Return  bool                 `json:"return"`
+		Results SpokeTimezoneResults `json:"results"`
+		Reason  string               `json:"reason"`
+	}
+
+	var resp SpokeTimezoneResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	return resp.Results.Timezone, nil
+}
+
+// SetSpokeFireNetInspectionExcludeCidrs sets the CIDRs whose traffic bypasses FireNet inspection on the
+// transit gateways the spoke gateway is attached to. An empty list inspects all traffic.
+func (c *Client) SetSpokeFireNetInspectionExcludeCidrs(spokeGateway *SpokeVpc, cidrs []string) error {
+	form := map[string]string{
+		"CID":           c.CID,
+		"action":        "edit_spoke_firenet_inspection_exclude_cidrs",
+		"gateway_name":  spokeGateway.GwName,
+		"exclude_cidrs": strings.Join(cidrs, ","),
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeFireNetInspectionExcludeCidrs returns the CIDRs whose traffic bypasses FireNet inspection on
+// the transit gateways the spoke gateway is attached to.
+func (c *Client) GetSpokeFireNetInspectionExcludeCidrs(spokeGateway *SpokeVpc) ([]string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_spoke_firenet_inspection_exclude_cidrs",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type ExcludeCidrsResults struct {
+		ExcludeCidrs []string `json:"exclude_cidrs"`
+	}
+
+	type ExcludeCidrsResp struct {
+		Return  bool                `json:"return"`
+		Results ExcludeCidrsResults `json:"results"`
+		Reason  string              `json:"reason"`
+	}
+
+	var resp ExcludeCidrsResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results.ExcludeCidrs, nil
+}
+
+// SetSpokeDefaultEgressAction sets whether egress traffic of a spoke gateway that is not matched by
+// any policy is allowed or denied. Valid actions are "allow" and "deny".
+func (c *Client) SetSpokeDefaultEgressAction(spokeGateway *SpokeVpc, action string) error {
+	form := map[string]string{
+		"CID":                   c.CID,
+		"action":                "edit_gateway_default_egress_action",
+		"gateway_name":          spokeGateway.GwName,
+		"default_egress_action": action,
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeDefaultEgressAction returns the default egress action of a spoke gateway. Gateways without
+// a configured default egress action allow egress traffic.
+func (c *Client) GetSpokeDefaultEgressAction(spokeGateway *SpokeVpc) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_default_egress_action",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type DefaultEgressActionResults struct {
+		DefaultEgressAction string `json:"default_egress_action"`
+	}
+
+	type DefaultEgressActionResp struct {
+		Return  bool                       `json:"return"`
+		Results DefaultEgressActionResults `json:"results"`
+		Reason  string                     `json:"reason"`
+	}
+
+	var resp DefaultEgressActionResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	if resp.Results.DefaultEgressAction == "" {
+		return "allow", nil
+	}
+	return resp.Results.DefaultEgressAction, nil
+}
+
+// SetSpokeDnsForwarding sets whether a spoke gateway forwards the DNS queries it receives and the resolvers
+// it forwards them to. Targets are ignored when forwarding is disabled.
+func (c *Client) SetSpokeDnsForwarding(spokeGateway *SpokeVpc, enable bool, targets []string) error {
+	form := map[string]string{
+		"CID":                    c.CID,
+		"action":                 "edit_gateway_dns_forwarding",
+		"gateway_name":           spokeGateway.GwName,
+		"dns_forwarding":         "no",
+		"dns_forwarding_targets": "",
+	}
+	if enable {
+		form["dns_forwarding"] = "yes"
+		form["dns_forwarding_targets"] = strings.Join(targets, ",")
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeDnsForwarding returns whether a spoke gateway forwards DNS queries and the resolvers it forwards
+// them to.
+func (c *Client) GetSpokeDnsForwarding(spokeGateway *SpokeVpc) (bool, []string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_dns_forwarding",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type DnsForwardingResults struct {
+		DnsForwarding        bool     `json:"dns_forwarding"`
+		DnsForwardingTargets []string `json:"dns_forwarding_targets"`
+	}
+
+	type DnsForwardingResp struct {
+		Return  bool                 `json:"return"`
+		Results DnsForwardingResults `json:"results"`
+		Reason  string               `json:"reason"`
+	}
+
+	var resp DnsForwardingResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return false, nil, err
+	}
+	return resp.Results.DnsForwarding, resp.Results.DnsForwardingTargets, nil
+}
+
+// SetSpokeBgpNeighborPassive sets whether the BGP spoke gateway waits for its BGP neighbors to
+// initiate the session instead of connecting to them.
+func (c *Client) SetSpokeBgpNeighborPassive(spokeGateway *SpokeVpc, passive bool) error {
+	form := map[string]string{
+		"CID":                  c.CID,
+		"action":               "edit_gateway_bgp_neighbor_passive",
+		"gateway_name":         spokeGateway.GwName,
+		"bgp_neighbor_passive": "no",
+	}
+	if passive {
+		form["bgp_neighbor_passive"] = "yes"
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeBgpNeighborPassive returns whether the BGP neighbors of a spoke gateway are in passive mode.
+func (c *Client) GetSpokeBgpNeighborPassive(spokeGateway *SpokeVpc) (bool, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_bgp_neighbor_passive",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type BgpNeighborPassiveResults struct {
+		BgpNeighborPassive bool `json:"bgp_neighbor_passive"`
+	}
+
+	type BgpNeighborPassiveResp struct {
+		Return  bool                      `json:"return"`
+		Results BgpNeighborPassiveResults `json:"results"`
+		Reason  string                    `json:"reason"`
+	}
+
+	var resp BgpNeighborPassiveResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return false, err
+	}
+	return resp.Results.BgpNeighborPassive, nil
+}
+
+// SetSpokeHaMode sets how the BGP spoke gateway and its HA gateway share traffic, either "standby" or
+// "active_active".
+func (c *Client) SetSpokeHaMode(spokeGateway *SpokeVpc, haMode string) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "set_spoke_gateway_ha_mode",
+		"gateway_name": spokeGateway.GwName,
+		"ha_mode":      haMode,
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeHaMode returns how the BGP spoke gateway and its HA gateway share traffic.
+func (c *Client) GetSpokeHaMode(spokeGateway *SpokeVpc) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_spoke_gateway_ha_mode",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type SpokeHaModeResults struct {
+		HaMode string `json:"ha_mode"`
+	}
+
+	type SpokeHaModeResp struct {
+		Return  bool               `json:"return"`
+		Results SpokeHaModeResults `json:"results"`
+		Reason  string             `json:"reason"`
+	}
+
+	var resp SpokeHaModeResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	return resp.Results.HaMode, nil
+}
+
+// SetSpokeBgpSummaryCidrs sets the summary CIDRs a BGP spoke gateway advertises in place of the more
+// specific routes they contain. An empty list removes all summary CIDRs.
+func (c *Client) SetSpokeBgpSummaryCidrs(spokeGateway *SpokeVpc, cidrs []string) error {
+	form := map[string]string{
+		"CID":               c.CID,
+		"action":            "edit_gateway_bgp_summary_cidrs",
+		"gateway_name":      spokeGateway.GwName,
+		"bgp_summary_cidrs": strings.Join(cidrs, ","),
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeBgpSummaryCidrs returns the summary CIDRs advertised by a BGP spoke gateway.
+func (c *Client) GetSpokeBgpSummaryCidrs(spokeGateway *SpokeVpc) ([]string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_bgp_summary_cidrs",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type BgpSummaryCidrsResults struct {
+		BgpSummaryCidrs []string `json:"bgp_summary_cidrs"`
+	}
+
+	type BgpSummaryCidrsResp struct {
+		Return  bool                   `json:"return"`
+		Results BgpSummaryCidrsResults `json:"results"`
+		Reason  string                 `json:"reason"`
+	}
+
+	var resp BgpSummaryCidrsResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results.BgpSummaryCidrs, nil
+}
+
+// SetSpokeBgpCommunityOutboundFilter sets the BGP communities a BGP spoke gateway is allowed to advertise
+// to its peers. An empty list removes the filter so that all communities are advertised.
+func (c *Client) SetSpokeBgpCommunityOutboundFilter(spokeGateway *SpokeVpc, communities []string) error {
+	form := map[string]string{
+		"CID":                           c.CID,
+		"action":                        "edit_gateway_bgp_community_outbound_filter",
+		"gateway_name":                  spokeGateway.GwName,
+		"bgp_community_outbound_filter": strings.Join(communities, ","),
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeBgpCommunityOutboundFilter returns the BGP communities a BGP spoke gateway is allowed to advertise.
+func (c *Client) GetSpokeBgpCommunityOutboundFilter(spokeGateway *SpokeVpc) ([]string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_bgp_community_outbound_filter",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type BgpCommunityOutboundFilterResults struct {
+		BgpCommunityOutboundFilter []string `json:"bgp_community_outbound_filter"`
+	}
+
+	type BgpCommunityOutboundFilterResp struct {
+		Return  bool                              `json:"return"`
+		Results BgpCommunityOutboundFilterResults `json:"results"`
+		Reason  string                            `json:"reason"`
+	}
+
+	var resp BgpCommunityOutboundFilterResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results.BgpCommunityOutboundFilter, nil
+}
+
+// SetSpokeBgpRouteMaps sets the BGP route maps a BGP spoke gateway applies to the routes it imports from and
+// exports to its peers. An empty name removes the route map in that direction.
+func (c *Client) SetSpokeBgpRouteMaps(spokeGateway *SpokeVpc, importRouteMap, exportRouteMap string) error {
+	form := map[string]string{
+		"CID":              c.CID,
+		"action":           "edit_gateway_bgp_route_maps",
+		"gateway_name":     spokeGateway.GwName,
+		"import_route_map": importRouteMap,
+		"export_route_map": exportRouteMap,
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeBgpRouteMaps returns the names of the BGP import and export route maps of a BGP spoke gateway.
+func (c *Client) GetSpokeBgpRouteMaps(spokeGateway *SpokeVpc) (string, string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_bgp_route_maps",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type BgpRouteMapsResults struct {
+		ImportRouteMap string `json:"import_route_map"`
+		ExportRouteMap string `json:"export_route_map"`
+	}
+
+	type BgpRouteMapsResp struct {
+		Return  bool                `json:"return"`
+		Results BgpRouteMapsResults `json:"results"`
+		Reason  string              `json:"reason"`
+	}
+
+	var resp BgpRouteMapsResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", "", err
+	}
+	return resp.Results.ImportRouteMap, resp.Results.ExportRouteMap, nil
+}
+
+// SetSpokeBgpNextHopSelf enables or disables next-hop-self on the routes a BGP spoke gateway advertises to
+// its iBGP peers.
+func (c *Client) SetSpokeBgpNextHopSelf(spokeGateway *SpokeVpc, enable bool) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "disable_gateway_bgp_next_hop_self",
+		"gateway_name": spokeGateway.GwName,
+	}
+	if enable {
+		form["action"] = "enable_gateway_bgp_next_hop_self"
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeBgpNextHopSelf returns whether next-hop-self is enabled on a BGP spoke gateway.
+func (c *Client) GetSpokeBgpNextHopSelf(spokeGateway *SpokeVpc) (bool, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_bgp_next_hop_self",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type BgpNextHopSelfResults struct {
+		NextHopSelf bool `json:"next_hop_self"`
+	}
+
+	type BgpNextHopSelfResp struct {
+		Return  bool                  `json:"return"`
+		Results BgpNextHopSelfResults `json:"results"`
+		Reason  string                `json:"reason"`
+	}
+
+	var resp BgpNextHopSelfResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return false, err
+	}
+	return resp.Results.NextHopSelf, nil
+}
+
+// ListBgpRouteMapNames returns the names of the BGP route maps configured on the controller.
+func (c *Client) ListBgpRouteMapNames() ([]string, error) {
+	form := map[string]string{
+		"CID":    c.CID,
+		"action": "list_bgp_route_maps",
+	}
+
+	type BgpRouteMap struct {
+		Name string `json:"name"`
+	}
+
+	type BgpRouteMapsListResp struct {
+		Return  bool          `json:"return"`
+		Results []BgpRouteMap `json:"results"`
+		Reason  string        `json:"reason"`
+	}
+
+	var resp BgpRouteMapsListResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(resp.Results))
+	for _, routeMap := range resp.Results {
+		names = append(names, routeMap.Name)
+	}
+	return names, nil
+}
+
+// SetSpokeBgpPrefixCommunities sets the BGP communities a spoke gateway attaches to the given advertised
+// CIDRs. An empty map removes all prefix communities.
+func (c *Client) SetSpokeBgpPrefixCommunities(spokeGateway *SpokeVpc, prefixCommunities map[string][]string) error {
+	if prefixCommunities == nil {
+		prefixCommunities = map[string][]string{}
+	}
+	prefixCommunitiesJson, err := json.Marshal(prefixCommunities)
+	if err != nil {
+		return fmt.Errorf("could not marshal BGP prefix communities: %w", err)
+	}
+	form := map[string]string{
+		"CID":                c.CID,
+		"action":             "edit_gateway_bgp_prefix_communities",
+		"gateway_name":       spokeGateway.GwName,
+		"prefix_communities": string(prefixCommunitiesJson),
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeBgpPrefixCommunities returns the BGP communities a spoke gateway attaches to its advertised CIDRs,
+// keyed by CIDR.
+func (c *Client) GetSpokeBgpPrefixCommunities(spokeGateway *SpokeVpc) (map[string][]string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_bgp_prefix_communities",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type BgpPrefixCommunitiesResults struct {
+		PrefixCommunities map[string][]string `json:"prefix_communities"`
+	}
+
+	type BgpPrefixCommunitiesResp struct {
+		Return  bool                        `json:"return"`
+		Results BgpPrefixCommunitiesResults `json:"results"`
+		Reason  string                      `json:"reason"`
+	}
+
+	var resp BgpPrefixCommunitiesResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results.PrefixCommunities, nil
+}
+
+// SetSpokeIpsecProposals sets the IKE and ESP proposals offered by a spoke gateway for its IPsec tunnels.
+// Empty lists restore the proposals of the tunnel cipher settings.
+func (c *Client) SetSpokeIpsecProposals(spokeGateway *SpokeVpc, ikeProposals, espProposals []string) error {
+	form := map[string]string{
+		"CID":           c.CID,
+		"action":        "edit_gateway_ipsec_proposals",
+		"gateway_name":  spokeGateway.GwName,
+		"ike_proposals": strings.Join(ikeProposals, ","),
+		"esp_proposals": strings.Join(espProposals, ","),
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeIpsecProposals returns the IKE and ESP proposals configured on a spoke gateway.
+func (c *Client) GetSpokeIpsecProposals(spokeGateway *SpokeVpc) ([]string, []string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_gateway_ipsec_proposals",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type IpsecProposalsResults struct {
+		IkeProposals []string `json:"ike_proposals"`
+		EspProposals []string `json:"esp_proposals"`
+	}
+
+	type IpsecProposalsResp struct {
+		Return  bool                  `json:"return"`
+		Results IpsecProposalsResults `json:"results"`
+		Reason  string                `json:"reason"`
+	}
+
+	var resp IpsecProposalsResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Results.IkeProposals, resp.Results.EspProposals, nil
+}
+
 func (c *Client) SetPrependASPathSpoke(spokeGateway *SpokeVpc, prependASPath []string) error {
 	action, subaction := "edit_aviatrix_spoke_advanced_config", "prepend_as_path"
 	return c.PostAPI(action+"/"+subaction, struct {
@@ -415,6 +1244,17 @@ func (c *Client) DisableSpokeLearnedCidrsApproval(gateway *SpokeVpc) error {
 	return c.PostAPI(form["action"], form, BasicCheck)
 }
 
+func (c *Client) SetSpokeLearnedCIDRsApprovalMode(gateway *SpokeVpc, mode string) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "set_bgp_gateway_cidr_approval_mode",
+		"gateway_name": gateway.GwName,
+		"mode":         mode,
+	}
+
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
 func (c *Client) UpdateSpokePendingApprovedCidrs(gateway *SpokeVpc) error {
 	form := map[string]string{
 		"CID":          c.CID,
@@ -518,3 +1358,81 @@ func (c *Client) DisableGlobalVpc(gateway *Gateway) error {
 	}
 	return c.PostAPI(form["action"], form, BasicCheck)
 }
+
+// SetSpokeEgressFqdnGateway routes the egress traffic of a spoke gateway through the named FQDN
+// gateway for egress filtering. An empty fqdnGwName removes the association.
+func (c *Client) SetSpokeEgressFqdnGateway(spokeGateway *SpokeVpc, fqdnGwName string) error {
+	form := map[string]string{
+		"CID":               c.CID,
+		"action":            "edit_spoke_egress_fqdn_gateway",
+		"gateway_name":      spokeGateway.GwName,
+		"fqdn_gateway_name": fqdnGwName,
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeEgressFqdnGateway returns the name of the FQDN gateway associated with the egress of a
+// spoke gateway, or an empty string if there is none.
+func (c *Client) GetSpokeEgressFqdnGateway(spokeGateway *SpokeVpc) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_spoke_egress_fqdn_gateway",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type SpokeEgressFqdnGatewayResults struct {
+		FqdnGatewayName string `json:"fqdn_gateway_name"`
+	}
+
+	type SpokeEgressFqdnGatewayResp struct {
+		Return  bool                          `json:"return"`
+		Results SpokeEgressFqdnGatewayResults `json:"results"`
+		Reason  string                        `json:"reason"`
+	}
+
+	var resp SpokeEgressFqdnGatewayResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	return resp.Results.FqdnGatewayName, nil
+}
+
+// SetSpokeEgressViaConnection directs the default egress traffic of a spoke gateway through the named
+// site2cloud or external device connection. An empty connName restores the default egress path.
+func (c *Client) SetSpokeEgressViaConnection(spokeGateway *SpokeVpc, connName string) error {
+	form := map[string]string{
+		"CID":             c.CID,
+		"action":          "edit_spoke_egress_connection",
+		"gateway_name":    spokeGateway.GwName,
+		"connection_name": connName,
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
+
+// GetSpokeEgressViaConnection returns the name of the connection the default egress traffic of a spoke
+// gateway is directed through, or an empty string if it uses the default egress path.
+func (c *Client) GetSpokeEgressViaConnection(spokeGateway *SpokeVpc) (string, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "show_spoke_egress_connection",
+		"gateway_name": spokeGateway.GwName,
+	}
+
+	type SpokeEgressConnectionResults struct {
+		ConnectionName string `json:"connection_name"`
+	}
+
+	type SpokeEgressConnectionResp struct {
+		Return  bool                         `json:"return"`
+		Results SpokeEgressConnectionResults `json:"results"`
+		Reason  string                       `json:"reason"`
+	}
+
+	var resp SpokeEgressConnectionResp
+	err := c.GetAPI(&resp, form["action"], form, BasicCheck)
+	if err != nil {
+		return "", err
+	}
+	return resp.Results.ConnectionName, nil
+}
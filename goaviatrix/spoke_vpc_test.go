@@ -0,0 +1,1077 @@
+package goaviatrix
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockJSONClient returns a Client whose HTTP requests are answered with the given JSON body.
+func newMockJSONClient(body string) *Client {
+	mockResponse := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+		Header:     make(http.Header),
+	}
+	mockResponse.Header.Set("Content-Type", "application/json")
+
+	return &Client{
+		HTTPClient: NewMockHTTPClient(mockResponse, nil),
+		CID:        "mockCID",
+	}
+}
+
+func TestGetSpokeActiveGatewayInstance(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "primary active",
+			response: `{"return": true, "results": {"active_gateway": "spoke-gw"}, "reason": ""}`,
+			expected: "primary",
+		},
+		{
+			name:     "ha active",
+			response: `{"return": true, "results": {"active_gateway": "spoke-gw-hagw"}, "reason": ""}`,
+			expected: "ha",
+		},
+		{
+			name:     "no active gateway reported",
+			response: `{"return": true, "results": {}, "reason": ""}`,
+			expected: "",
+		},
+		{
+			name:        "API error",
+			response:    `{"return": false, "reason": "Gateway spoke-gw does not exist"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockJSONClient(tt.response)
+
+			active, err := client.GetSpokeActiveGatewayInstance(&SpokeVpc{GwName: "spoke-gw"})
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, active)
+		})
+	}
+}
+
+func TestGetSpokeExternalBgpPeers(t *testing.T) {
+	client := newMockJSONClient(`{
+		"return": true,
+		"results": [
+			{"neighbor_ip": "169.254.10.1", "neighbor_as_num": "65002", "bfd_enabled": false},
+			{"neighbor_ip": "10.10.0.2", "neighbor_as_num": "65010", "bfd_enabled": true},
+			{"neighbor_ip": "169.254.10.1", "neighbor_as_num": "65001", "bfd_enabled": true}
+		],
+		"reason": ""
+	}`)
+
+	peers, err := client.GetSpokeExternalBgpPeers(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalBgpPeer{
+		{RemoteIP: "10.10.0.2", RemoteAS: "65010", BfdEnabled: true},
+		{RemoteIP: "169.254.10.1", RemoteAS: "65001", BfdEnabled: true},
+		{RemoteIP: "169.254.10.1", RemoteAS: "65002", BfdEnabled: false},
+	}, peers)
+}
+
+func TestGetSpokeExternalBgpPeersNoPeers(t *testing.T) {
+	client := newMockJSONClient(`{"return": true, "results": [], "reason": ""}`)
+
+	peers, err := client.GetSpokeExternalBgpPeers(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Empty(t, peers)
+}
+
+func TestGetSpokeBgpAdvertisedRoutes(t *testing.T) {
+	client, rt := newRecordingClient(`{
+		"return": true,
+		"results": [
+			{"cidr": "10.20.0.0/16"},
+			{"cidr": "10.1.0.0/16"},
+			{"cidr": "192.168.10.0/24"}
+		],
+		"reason": ""
+	}`)
+
+	routes, err := client.GetSpokeBgpAdvertisedRoutes(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Equal(t, []BgpAdvertisedRoute{
+		{Cidr: "10.1.0.0/16"},
+		{Cidr: "10.20.0.0/16"},
+		{Cidr: "192.168.10.0/24"},
+	}, routes)
+	assert.Equal(t, "list_gateway_bgp_advertised_routes", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestGetSpokeBgpAdvertisedRoutesError(t *testing.T) {
+	client := newMockJSONClient(`{"return": false, "reason": "BGP is not enabled on gateway spoke-gw"}`)
+
+	routes, err := client.GetSpokeBgpAdvertisedRoutes(&SpokeVpc{GwName: "spoke-gw"})
+	assert.ErrorContains(t, err, "BGP is not enabled")
+	assert.Nil(t, routes)
+}
+
+func TestSetSpokePeeringKeepaliveInterval(t *testing.T) {
+	tests := []struct {
+		name             string
+		interval         int
+		expectedInterval string
+	}{
+		{
+			name:             "set keepalive interval",
+			interval:         5,
+			expectedInterval: "5",
+		},
+		{
+			name:             "clear keepalive interval",
+			interval:         0,
+			expectedInterval: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Peering keepalive interval updated", "reason": ""}`)
+
+			err := client.SetSpokePeeringKeepaliveInterval(&SpokeVpc{GwName: "spoke-gw"}, tt.interval)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_peering_keepalive_interval", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedInterval, rt.form.Get("keepalive_interval"))
+		})
+	}
+}
+
+func TestGetSpokePeeringKeepaliveInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected int
+	}{
+		{
+			name:     "keepalive interval set",
+			response: `{"return": true, "results": {"keepalive_interval": 5}, "reason": ""}`,
+			expected: 5,
+		},
+		{
+			name:     "controller default",
+			response: `{"return": true, "results": {}, "reason": ""}`,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			interval, err := client.GetSpokePeeringKeepaliveInterval(&SpokeVpc{GwName: "spoke-gw"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, interval)
+			assert.Equal(t, "show_gateway_peering_keepalive_interval", rt.form.Get("action"))
+		})
+	}
+}
+
+func TestSetSpokeTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+	}{
+		{
+			name:     "set time zone",
+			timezone: "Europe/Berlin",
+		},
+		{
+			name:     "clear time zone",
+			timezone: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Time zone updated", "reason": ""}`)
+
+			err := client.SetSpokeTimezone(&SpokeVpc{GwName: "spoke-gw"}, tt.timezone)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_timezone", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.timezone, rt.form.Get("timezone"))
+		})
+	}
+}
+
+func TestGetSpokeTimezone(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"timezone": "Europe/Berlin"}, "reason": ""}`)
+
+	timezone, err := client.GetSpokeTimezone(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", timezone)
+	assert.Equal(t, "show_gateway_timezone", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestSetSpokeFireNetInspectionExcludeCidrs(t *testing.T) {
+	tests := []struct {
+		name     string
+		cidrs    []string
+		expected string
+	}{
+		{
+			name:     "set exclude CIDRs",
+			cidrs:    []string{"10.0.0.0/16", "10.1.0.0/16"},
+			expected: "10.0.0.0/16,10.1.0.0/16",
+		},
+		{
+			name:     "clear exclude CIDRs",
+			cidrs:    nil,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Exclude CIDRs updated", "reason": ""}`)
+
+			err := client.SetSpokeFireNetInspectionExcludeCidrs(&SpokeVpc{GwName: "spoke-gw"}, tt.cidrs)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_spoke_firenet_inspection_exclude_cidrs", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expected, rt.form.Get("exclude_cidrs"))
+		})
+	}
+}
+
+func TestGetSpokeFireNetInspectionExcludeCidrs(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"exclude_cidrs": ["10.1.0.0/16", "10.0.0.0/16"]}, "reason": ""}`)
+
+	cidrs, err := client.GetSpokeFireNetInspectionExcludeCidrs(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.1.0.0/16", "10.0.0.0/16"}, cidrs)
+	assert.Equal(t, "show_spoke_firenet_inspection_exclude_cidrs", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestSetSpokeEgressFqdnGateway(t *testing.T) {
+	tests := []struct {
+		name        string
+		fqdnGwName  string
+		response    string
+		expectError bool
+	}{
+		{
+			name:       "associate FQDN gateway",
+			fqdnGwName: "fqdn-gw",
+			response:   `{"return": true, "results": "Egress FQDN gateway updated", "reason": ""}`,
+		},
+		{
+			name:       "remove association",
+			fqdnGwName: "",
+			response:   `{"return": true, "results": "Egress FQDN gateway updated", "reason": ""}`,
+		},
+		{
+			name:        "API error",
+			fqdnGwName:  "fqdn-gw",
+			response:    `{"return": false, "reason": "Gateway fqdn-gw is not an FQDN gateway"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			err := client.SetSpokeEgressFqdnGateway(&SpokeVpc{GwName: "spoke-gw"}, tt.fqdnGwName)
+			if tt.expectError {
+				assert.ErrorContains(t, err, "is not an FQDN gateway")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_spoke_egress_fqdn_gateway", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.fqdnGwName, rt.form.Get("fqdn_gateway_name"))
+		})
+	}
+}
+
+func TestGetSpokeEgressFqdnGateway(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"fqdn_gateway_name": "fqdn-gw"}, "reason": ""}`)
+
+	fqdnGwName, err := client.GetSpokeEgressFqdnGateway(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Equal(t, "fqdn-gw", fqdnGwName)
+	assert.Equal(t, "show_spoke_egress_fqdn_gateway", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestSetSpokeEgressViaConnection(t *testing.T) {
+	tests := []struct {
+		name        string
+		connName    string
+		response    string
+		expectError bool
+	}{
+		{
+			name:     "egress via connection",
+			connName: "s2c-conn",
+			response: `{"return": true, "results": "Egress connection updated", "reason": ""}`,
+		},
+		{
+			name:     "restore default egress",
+			connName: "",
+			response: `{"return": true, "results": "Egress connection updated", "reason": ""}`,
+		},
+		{
+			name:        "API error",
+			connName:    "s2c-conn",
+			response:    `{"return": false, "reason": "Connection s2c-conn does not exist"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			err := client.SetSpokeEgressViaConnection(&SpokeVpc{GwName: "spoke-gw"}, tt.connName)
+			if tt.expectError {
+				assert.ErrorContains(t, err, "does not exist")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_spoke_egress_connection", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.connName, rt.form.Get("connection_name"))
+		})
+	}
+}
+
+func TestGetSpokeEgressViaConnection(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"connection_name": "s2c-conn"}, "reason": ""}`)
+
+	connName, err := client.GetSpokeEgressViaConnection(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Equal(t, "s2c-conn", connName)
+	assert.Equal(t, "show_spoke_egress_connection", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestSetSpokeTcpMssClamp(t *testing.T) {
+	tests := []struct {
+		name           string
+		tcpMss         int
+		expectedTcpMss string
+	}{
+		{
+			name:           "set MSS clamp",
+			tcpMss:         1350,
+			expectedTcpMss: "1350",
+		},
+		{
+			name:           "clear MSS clamp",
+			tcpMss:         0,
+			expectedTcpMss: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "TCP MSS clamp updated", "reason": ""}`)
+
+			err := client.SetSpokeTcpMssClamp(&SpokeVpc{GwName: "spoke-gw"}, tt.tcpMss)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_tcp_mss_clamp", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedTcpMss, rt.form.Get("tcp_mss"))
+		})
+	}
+}
+
+func TestGetSpokeTcpMssClamp(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected int
+	}{
+		{
+			name:     "MSS clamp set",
+			response: `{"return": true, "results": {"tcp_mss": 1350}, "reason": ""}`,
+			expected: 1350,
+		},
+		{
+			name:     "MSS clamp disabled",
+			response: `{"return": true, "results": {}, "reason": ""}`,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			tcpMss, err := client.GetSpokeTcpMssClamp(&SpokeVpc{GwName: "spoke-gw"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, tcpMss)
+			assert.Equal(t, "show_gateway_tcp_mss_clamp", rt.form.Get("action"))
+		})
+	}
+}
+
+func TestSetSpokeBgpNeighborPassive(t *testing.T) {
+	tests := []struct {
+		name            string
+		passive         bool
+		expectedPassive string
+	}{
+		{
+			name:            "set passive",
+			passive:         true,
+			expectedPassive: "yes",
+		},
+		{
+			name:            "clear passive",
+			passive:         false,
+			expectedPassive: "no",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "BGP neighbor passive mode updated", "reason": ""}`)
+
+			err := client.SetSpokeBgpNeighborPassive(&SpokeVpc{GwName: "spoke-gw"}, tt.passive)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_bgp_neighbor_passive", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedPassive, rt.form.Get("bgp_neighbor_passive"))
+		})
+	}
+}
+
+func TestSetSpokeBgpNeighborPassiveError(t *testing.T) {
+	client := newMockJSONClient(`{"return": false, "reason": "BGP is not enabled on gateway spoke-gw"}`)
+
+	err := client.SetSpokeBgpNeighborPassive(&SpokeVpc{GwName: "spoke-gw"}, true)
+	assert.ErrorContains(t, err, "BGP is not enabled")
+}
+
+func TestGetSpokeBgpNeighborPassive(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected bool
+	}{
+		{
+			name:     "passive",
+			response: `{"return": true, "results": {"bgp_neighbor_passive": true}, "reason": ""}`,
+			expected: true,
+		},
+		{
+			name:     "active",
+			response: `{"return": true, "results": {"bgp_neighbor_passive": false}, "reason": ""}`,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			passive, err := client.GetSpokeBgpNeighborPassive(&SpokeVpc{GwName: "spoke-gw"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, passive)
+			assert.Equal(t, "show_gateway_bgp_neighbor_passive", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+		})
+	}
+}
+
+func TestSetSpokeHaMode(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": "HA mode updated", "reason": ""}`)
+
+	err := client.SetSpokeHaMode(&SpokeVpc{GwName: "spoke-gw"}, "active_active")
+	assert.NoError(t, err)
+	assert.Equal(t, "set_spoke_gateway_ha_mode", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+	assert.Equal(t, "active_active", rt.form.Get("ha_mode"))
+}
+
+func TestGetSpokeHaMode(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"ha_mode": "standby"}, "reason": ""}`)
+
+	haMode, err := client.GetSpokeHaMode(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Equal(t, "standby", haMode)
+	assert.Equal(t, "get_spoke_gateway_ha_mode", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestSetSpokeDnsForwarding(t *testing.T) {
+	tests := []struct {
+		name            string
+		enable          bool
+		targets         []string
+		expectedEnable  string
+		expectedTargets string
+	}{
+		{
+			name:            "enable",
+			enable:          true,
+			targets:         []string{"10.10.0.53", "10.20.0.53"},
+			expectedEnable:  "yes",
+			expectedTargets: "10.10.0.53,10.20.0.53",
+		},
+		{
+			name:            "disable",
+			enable:          false,
+			targets:         []string{"10.10.0.53"},
+			expectedEnable:  "no",
+			expectedTargets: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "DNS forwarding updated", "reason": ""}`)
+
+			err := client.SetSpokeDnsForwarding(&SpokeVpc{GwName: "spoke-gw"}, tt.enable, tt.targets)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_dns_forwarding", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedEnable, rt.form.Get("dns_forwarding"))
+			assert.Equal(t, tt.expectedTargets, rt.form.Get("dns_forwarding_targets"))
+		})
+	}
+}
+
+func TestSetSpokeDnsForwardingError(t *testing.T) {
+	client := newMockJSONClient(`{"return": false, "reason": "Gateway spoke-gw does not exist"}`)
+
+	err := client.SetSpokeDnsForwarding(&SpokeVpc{GwName: "spoke-gw"}, true, []string{"10.10.0.53"})
+	assert.ErrorContains(t, err, "does not exist")
+}
+
+func TestGetSpokeDnsForwarding(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"dns_forwarding": true,
+		"dns_forwarding_targets": ["10.10.0.53", "10.20.0.53"]}, "reason": ""}`)
+
+	enabled, targets, err := client.GetSpokeDnsForwarding(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Equal(t, []string{"10.10.0.53", "10.20.0.53"}, targets)
+	assert.Equal(t, "show_gateway_dns_forwarding", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestSetSpokeLearnedCIDRsApprovalMode(t *testing.T) {
+	for _, mode := range []string{"gateway", "connection"} {
+		t.Run(mode, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Learned CIDRs approval mode updated", "reason": ""}`)
+
+			err := client.SetSpokeLearnedCIDRsApprovalMode(&SpokeVpc{GwName: "spoke-gw"}, mode)
+			assert.NoError(t, err)
+			assert.Equal(t, "set_bgp_gateway_cidr_approval_mode", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, mode, rt.form.Get("mode"))
+		})
+	}
+}
+
+func TestSetSpokeActiveStandbyFailoverDelay(t *testing.T) {
+	tests := []struct {
+		name          string
+		delay         int
+		expectedDelay string
+	}{
+		{
+			name:          "set failover delay",
+			delay:         30,
+			expectedDelay: "30",
+		},
+		{
+			name:          "clear failover delay",
+			delay:         0,
+			expectedDelay: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Failover delay updated", "reason": ""}`)
+
+			err := client.SetSpokeActiveStandbyFailoverDelay(&SpokeVpc{GwName: "spoke-gw"}, tt.delay)
+			assert.NoError(t, err)
+			assert.Equal(t, "set_active_standby_failover_delay", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedDelay, rt.form.Get("failover_delay"))
+		})
+	}
+}
+
+func TestSetSpokeActiveStandbyFailoverDelayError(t *testing.T) {
+	client, _ := newRecordingClient(`{"return": false, "reason": "Active-Standby is not enabled on spoke-gw"}`)
+
+	err := client.SetSpokeActiveStandbyFailoverDelay(&SpokeVpc{GwName: "spoke-gw"}, 30)
+	assert.ErrorContains(t, err, "Active-Standby is not enabled")
+}
+
+func TestUpdateSpokeConnectionPendingApprovedCidrs(t *testing.T) {
+	tests := []struct {
+		name          string
+		cidrs         []string
+		expectedCidrs string
+	}{
+		{
+			name:          "set approved CIDRs",
+			cidrs:         []string{"10.0.0.0/16", "10.1.0.0/16"},
+			expectedCidrs: "10.0.0.0/16,10.1.0.0/16",
+		},
+		{
+			name:          "clear approved CIDRs",
+			cidrs:         nil,
+			expectedCidrs: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Approved CIDR rules updated", "reason": ""}`)
+
+			err := client.UpdateSpokeConnectionPendingApprovedCidrs("spoke-gw", "conn-a", tt.cidrs)
+			assert.NoError(t, err)
+			assert.Equal(t, "set_bgp_connection_approved_cidr_rules", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, "conn-a", rt.form.Get("connection_name"))
+			assert.Equal(t, tt.expectedCidrs, rt.form.Get("cidr_rules"))
+		})
+	}
+}
+
+func TestSetSpokeBgpSummaryCidrs(t *testing.T) {
+	tests := []struct {
+		name          string
+		cidrs         []string
+		expectedCidrs string
+	}{
+		{
+			name:          "set summary CIDRs",
+			cidrs:         []string{"10.0.0.0/8", "172.16.0.0/12"},
+			expectedCidrs: "10.0.0.0/8,172.16.0.0/12",
+		},
+		{
+			name:          "clear summary CIDRs",
+			cidrs:         nil,
+			expectedCidrs: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "BGP summary CIDRs updated", "reason": ""}`)
+
+			err := client.SetSpokeBgpSummaryCidrs(&SpokeVpc{GwName: "spoke-gw"}, tt.cidrs)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_bgp_summary_cidrs", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedCidrs, rt.form.Get("bgp_summary_cidrs"))
+		})
+	}
+}
+
+func TestSetSpokeBgpSummaryCidrsError(t *testing.T) {
+	client := newMockJSONClient(`{"return": false, "reason": "BGP is not enabled on gateway spoke-gw"}`)
+
+	err := client.SetSpokeBgpSummaryCidrs(&SpokeVpc{GwName: "spoke-gw"}, []string{"10.0.0.0/8"})
+	assert.ErrorContains(t, err, "BGP is not enabled")
+}
+
+func TestGetSpokeBgpSummaryCidrs(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected []string
+	}{
+		{
+			name:     "summary CIDRs set",
+			response: `{"return": true, "results": {"bgp_summary_cidrs": ["10.0.0.0/8", "172.16.0.0/12"]}, "reason": ""}`,
+			expected: []string{"10.0.0.0/8", "172.16.0.0/12"},
+		},
+		{
+			name:     "no summary CIDRs",
+			response: `{"return": true, "results": {}, "reason": ""}`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			cidrs, err := client.GetSpokeBgpSummaryCidrs(&SpokeVpc{GwName: "spoke-gw"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cidrs)
+			assert.Equal(t, "show_gateway_bgp_summary_cidrs", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+		})
+	}
+}
+
+func TestSetSpokeDefaultEgressAction(t *testing.T) {
+	for _, action := range []string{"allow", "deny"} {
+		t.Run(action, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "Default egress action updated", "reason": ""}`)
+
+			err := client.SetSpokeDefaultEgressAction(&SpokeVpc{GwName: "spoke-gw"}, action)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_default_egress_action", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, action, rt.form.Get("default_egress_action"))
+		})
+	}
+}
+
+func TestGetSpokeDefaultEgressAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected string
+	}{
+		{
+			name:     "deny",
+			response: `{"return": true, "results": {"default_egress_action": "deny"}, "reason": ""}`,
+			expected: "deny",
+		},
+		{
+			name:     "not configured",
+			response: `{"return": true, "results": {}, "reason": ""}`,
+			expected: "allow",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			action, err := client.GetSpokeDefaultEgressAction(&SpokeVpc{GwName: "spoke-gw"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, action)
+			assert.Equal(t, "show_gateway_default_egress_action", rt.form.Get("action"))
+		})
+	}
+}
+
+func TestSetSpokeBgpCommunityOutboundFilter(t *testing.T) {
+	tests := []struct {
+		name                string
+		communities         []string
+		expectedCommunities string
+	}{
+		{
+			name:                "set outbound filter",
+			communities:         []string{"65000:100", "65000:200"},
+			expectedCommunities: "65000:100,65000:200",
+		},
+		{
+			name:                "clear outbound filter",
+			communities:         nil,
+			expectedCommunities: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "BGP community outbound filter updated", "reason": ""}`)
+
+			err := client.SetSpokeBgpCommunityOutboundFilter(&SpokeVpc{GwName: "spoke-gw"}, tt.communities)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_bgp_community_outbound_filter", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedCommunities, rt.form.Get("bgp_community_outbound_filter"))
+		})
+	}
+}
+
+func TestGetSpokeBgpCommunityOutboundFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected []string
+	}{
+		{
+			name:     "outbound filter set",
+			response: `{"return": true, "results": {"bgp_community_outbound_filter": ["65000:100", "65000:200"]}, "reason": ""}`,
+			expected: []string{"65000:100", "65000:200"},
+		},
+		{
+			name:     "no outbound filter",
+			response: `{"return": true, "results": {}, "reason": ""}`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			communities, err := client.GetSpokeBgpCommunityOutboundFilter(&SpokeVpc{GwName: "spoke-gw"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, communities)
+			assert.Equal(t, "show_gateway_bgp_community_outbound_filter", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+		})
+	}
+}
+
+func TestSetSpokeBgpRouteMaps(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": "BGP route maps updated", "reason": ""}`)
+
+	err := client.SetSpokeBgpRouteMaps(&SpokeVpc{GwName: "spoke-gw"}, "import-rm", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "edit_gateway_bgp_route_maps", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+	assert.Equal(t, "import-rm", rt.form.Get("import_route_map"))
+	assert.Equal(t, "", rt.form.Get("export_route_map"))
+}
+
+func TestGetSpokeBgpRouteMaps(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"import_route_map": "import-rm", "export_route_map": "export-rm"}, "reason": ""}`)
+
+	importRouteMap, exportRouteMap, err := client.GetSpokeBgpRouteMaps(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Equal(t, "import-rm", importRouteMap)
+	assert.Equal(t, "export-rm", exportRouteMap)
+	assert.Equal(t, "show_gateway_bgp_route_maps", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestSetSpokeBgpNextHopSelf(t *testing.T) {
+	tests := []struct {
+		name           string
+		enable         bool
+		expectedAction string
+	}{
+		{
+			name:           "set",
+			enable:         true,
+			expectedAction: "enable_gateway_bgp_next_hop_self",
+		},
+		{
+			name:           "clear",
+			expectedAction: "disable_gateway_bgp_next_hop_self",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "done", "reason": ""}`)
+
+			err := client.SetSpokeBgpNextHopSelf(&SpokeVpc{GwName: "spoke-gw"}, tt.enable)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAction, rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+		})
+	}
+}
+
+func TestGetSpokeBgpNextHopSelf(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"next_hop_self": true}, "reason": ""}`)
+
+	nextHopSelf, err := client.GetSpokeBgpNextHopSelf(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.True(t, nextHopSelf)
+	assert.Equal(t, "show_gateway_bgp_next_hop_self", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestListBgpRouteMapNames(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": [{"name": "import-rm"}, {"name": "export-rm"}], "reason": ""}`)
+
+	names, err := client.ListBgpRouteMapNames()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"import-rm", "export-rm"}, names)
+	assert.Equal(t, "list_bgp_route_maps", rt.form.Get("action"))
+}
+
+func TestSetSpokeBgpPrefixCommunities(t *testing.T) {
+	tests := []struct {
+		name              string
+		prefixCommunities map[string][]string
+		expected          string
+	}{
+		{
+			name: "set prefix communities",
+			prefixCommunities: map[string][]string{
+				"10.1.0.0/16": {"65000:200"},
+				"10.0.0.0/16": {"65000:100", "65000:101"},
+			},
+			expected: `{"10.0.0.0/16":["65000:100","65000:101"],"10.1.0.0/16":["65000:200"]}`,
+		},
+		{
+			name:              "clear prefix communities",
+			prefixCommunities: nil,
+			expected:          `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "BGP prefix communities updated", "reason": ""}`)
+
+			err := client.SetSpokeBgpPrefixCommunities(&SpokeVpc{GwName: "spoke-gw"}, tt.prefixCommunities)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_bgp_prefix_communities", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expected, rt.form.Get("prefix_communities"))
+		})
+	}
+}
+
+func TestGetSpokeBgpPrefixCommunities(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"prefix_communities": {"10.0.0.0/16": ["65000:100", "65000:101"]}}, "reason": ""}`)
+
+	prefixCommunities, err := client.GetSpokeBgpPrefixCommunities(&SpokeVpc{GwName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"10.0.0.0/16": {"65000:100", "65000:101"}}, prefixCommunities)
+	assert.Equal(t, "show_gateway_bgp_prefix_communities", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+}
+
+func TestSetSpokeIpsecProposals(t *testing.T) {
+	tests := []struct {
+		name         string
+		ikeProposals []string
+		espProposals []string
+		expectedIke  string
+		expectedEsp  string
+	}{
+		{
+			name:         "set proposals",
+			ikeProposals: []string{"AES-256-GCM-128/SHA-384/20", "AES-256-CBC/SHA-256/14"},
+			espProposals: []string{"AES-256-GCM-128/NO-AUTH/20"},
+			expectedIke:  "AES-256-GCM-128/SHA-384/20,AES-256-CBC/SHA-256/14",
+			expectedEsp:  "AES-256-GCM-128/NO-AUTH/20",
+		},
+		{
+			name: "clear proposals",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(`{"return": true, "results": "IPsec proposals updated", "reason": ""}`)
+
+			err := client.SetSpokeIpsecProposals(&SpokeVpc{GwName: "spoke-gw"}, tt.ikeProposals, tt.espProposals)
+			assert.NoError(t, err)
+			assert.Equal(t, "edit_gateway_ipsec_proposals", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.expectedIke, rt.form.Get("ike_proposals"))
+			assert.Equal(t, tt.expectedEsp, rt.form.Get("esp_proposals"))
+		})
+	}
+}
+
+func TestSetSpokeIpsecProposalsError(t *testing.T) {
+	client := newMockJSONClient(`{"return": false, "reason": "Proposal AES-256-CBC/SHA-256/14 is not supported by the gateway image"}`)
+
+	err := client.SetSpokeIpsecProposals(&SpokeVpc{GwName: "spoke-gw"}, []string{"AES-256-CBC/SHA-256/14"}, nil)
+	assert.ErrorContains(t, err, "is not supported")
+}
+
+func TestGetSpokeIpsecProposals(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    string
+		expectedIke []string
+		expectedEsp []string
+	}{
+		{
+			name: "proposals set",
+			response: `{"return": true, "results": {"ike_proposals": ["AES-256-CBC/SHA-256/14"],
+				"esp_proposals": ["AES-256-GCM-128/NO-AUTH"]}, "reason": ""}`,
+			expectedIke: []string{"AES-256-CBC/SHA-256/14"},
+			expectedEsp: []string{"AES-256-GCM-128/NO-AUTH"},
+		},
+		{
+			name:     "no proposals",
+			response: `{"return": true, "results": {}, "reason": ""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			ikeProposals, espProposals, err := client.GetSpokeIpsecProposals(&SpokeVpc{GwName: "spoke-gw"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedIke, ikeProposals)
+			assert.Equal(t, tt.expectedEsp, espProposals)
+			assert.Equal(t, "show_gateway_ipsec_proposals", rt.form.Get("action"))
+			assert.Equal(t, "spoke-gw", rt.form.Get("gateway_name"))
+		})
+	}
+}
+
+func TestSetSpokeTransitFailoverPriority(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": "Failover priority updated", "reason": ""}`)
+
+	err := client.SetSpokeTransitFailoverPriority("spoke-gw", []string{"transit-gw-2", "transit-gw-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "set_spoke_transit_failover_priority", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("spoke_gw"))
+	assert.Equal(t, "transit-gw-2,transit-gw-1", rt.form.Get("transit_gw_list"))
+}
+
+func TestGetSpokeTransitFailoverPriority(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"transit_gw_list": ["transit-gw-2", "transit-gw-1"]}, "reason": ""}`)
+
+	transitGwNames, err := client.GetSpokeTransitFailoverPriority("spoke-gw")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"transit-gw-2", "transit-gw-1"}, transitGwNames)
+	assert.Equal(t, "get_spoke_transit_failover_priority", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("spoke_gw"))
+}
+
+func TestSetSpokeTransitRouteTable(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": "Route table updated", "reason": ""}`)
+
+	err := client.SetSpokeTransitRouteTable("spoke-gw", "transit-gw-1", "prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "edit_spoke_transit_route_table", rt.form.Get("action"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("spoke_gw"))
+	assert.Equal(t, "transit-gw-1", rt.form.Get("transit_gw"))
+	assert.Equal(t, "prod", rt.form.Get("route_table"))
+}
+
+func TestGetSpokeTransitRouteTable(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"route_table": "prod"}, "reason": ""}`)
+
+	routeTable, err := client.GetSpokeTransitRouteTable("spoke-gw", "transit-gw-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", routeTable)
+	assert.Equal(t, "show_spoke_transit_route_table", rt.form.Get("action"))
+	assert.Equal(t, "transit-gw-1", rt.form.Get("transit_gw"))
+}
+
+func TestListTransitRouteTables(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {"route_tables": ["default", "prod"]}, "reason": ""}`)
+
+	routeTables, err := client.ListTransitRouteTables("transit-gw-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"default", "prod"}, routeTables)
+	assert.Equal(t, "list_transit_gateway_route_tables", rt.form.Get("action"))
+	assert.Equal(t, "transit-gw-1", rt.form.Get("gateway_name"))
+}
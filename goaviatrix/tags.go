@@ -124,6 +124,39 @@ func (c *Client) GetTags(tags *Tags) ([]string, error) {
 	return tagList, nil
 }
 
+// GetAllTags returns every tag on the cloud resource. Unlike GetTags it also includes the tags the
+// controller manages itself, such as the aviatrix-* tags, which are reported outside usr_tags.
+func (c *Client) GetAllTags(tags *Tags) (map[string]string, error) {
+	data := map[string]string{
+		"action":        "list_resource_tags",
+		"CID":           c.CID,
+		"cloud_type":    strconv.Itoa(tags.CloudType),
+		"resource_type": tags.ResourceType,
+		"resource_name": tags.ResourceName,
+	}
+	var resp TagAPIResp
+	err := c.GetAPI(&resp, data["action"], data, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	allTags := make(map[string]string)
+	for group, tagsMap := range resp.Results {
+		if group == "usr_tags" {
+			continue
+		}
+		for key, val := range tagsMap {
+			allTags[key] = val
+		}
+	}
+	// User tags take precedence over a controller tag with the same key.
+	for key, val := range resp.Results["usr_tags"] {
+		allTags[key] = val
+	}
+
+	return allTags, nil
+}
+
 func (c *Client) DeleteTags(tags *Tags) error {
 	params := map[string]string{
 		"action":        "delete_resource_tag",
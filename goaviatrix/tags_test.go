@@ -0,0 +1,72 @@
+package goaviatrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyValueTagsIgnoreConfig(t *testing.T) {
+	tags := KeyValueTags{
+		"Name":                           "spoke-gw",
+		"aws:cloudformation:stack-name":  "network",
+		"kubernetes.io/cluster/platform": "owned",
+		"CostCenter":                     "1234",
+	}
+
+	tests := []struct {
+		name     string
+		config   *IgnoreTagsConfig
+		expected KeyValueTags
+	}{
+		{
+			name:     "no configuration",
+			expected: tags,
+		},
+		{
+			name: "keys",
+			config: &IgnoreTagsConfig{
+				Keys: NewIgnoreTags([]interface{}{"CostCenter"}),
+			},
+			expected: KeyValueTags{
+				"Name":                           "spoke-gw",
+				"aws:cloudformation:stack-name":  "network",
+				"kubernetes.io/cluster/platform": "owned",
+			},
+		},
+		{
+			name: "keys and key prefixes",
+			config: &IgnoreTagsConfig{
+				Keys:        NewIgnoreTags([]interface{}{"CostCenter"}),
+				KeyPrefixes: NewIgnoreTags([]interface{}{"aws:", "kubernetes.io/"}),
+			},
+			expected: KeyValueTags{"Name": "spoke-gw"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tags.IgnoreConfig(tt.config))
+		})
+	}
+}
+
+func TestGetAllTags(t *testing.T) {
+	client, rt := newRecordingClient(`{"return": true, "results": {
+		"usr_tags": {"Name": "spoke-gw", "CostCenter": "1234"},
+		"sys_tags": {"aviatrix-gw-name": "spoke-gw", "aviatrix-created-resource": "Do-Not-Delete-Aviatrix-Created-Resource"}
+	}, "reason": ""}`)
+
+	allTags, err := client.GetAllTags(&Tags{CloudType: AWS, ResourceType: "gw", ResourceName: "spoke-gw"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"Name":                      "spoke-gw",
+		"CostCenter":                "1234",
+		"aviatrix-gw-name":          "spoke-gw",
+		"aviatrix-created-resource": "Do-Not-Delete-Aviatrix-Created-Resource",
+	}, allTags)
+	assert.Equal(t, "list_resource_tags", rt.form.Get("action"))
+	assert.Equal(t, "gw", rt.form.Get("resource_type"))
+	assert.Equal(t, "spoke-gw", rt.form.Get("resource_name"))
+	assert.Equal(t, "1", rt.form.Get("cloud_type"))
+}
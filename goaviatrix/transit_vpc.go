@@ -300,6 +300,10 @@ func (c *Client) UpdateEdgeGateway(gateway *TransitVpc) error {
 		form["interfaces"] = gateway.Interfaces
 	}
 
+	if gateway.InterfaceMapping != "" {
+		form["interface_mapping"] = gateway.InterfaceMapping
+	}
+
 	if gateway.EipMap != "" {
 		form["eip_map"] = gateway.EipMap
 	}
@@ -807,6 +811,32 @@ func (c *Client) DisableTransitPreserveAsPath(transitGateway *TransitVpc) error
 	return c.PostAPI(action, data, BasicCheck)
 }
 
+// DownloadEdgeZtpFile downloads the ZTP file of an existing edge transit gateway from the controller and
+// rewrites it in ztpFileDownloadPath under the same name it was created with. The gateway itself is not changed.
+func (c *Client) DownloadEdgeZtpFile(gwName, vpcID, ztpFileDownloadPath, ztpFileType string) error {
+	form := map[string]string{
+		"CID":           c.CID,
+		"action":        "get_edge_gateway_ztp_file",
+		"gateway_name":  gwName,
+		"ztp_file_type": ztpFileType,
+	}
+
+	var data CreateEdgeEquinixResp
+	err := c.PostAPIWithResponse(&data, form["action"], form, BasicCheck)
+	if err != nil {
+		return err
+	}
+
+	if ztpFileType == "iso" {
+		return createZtpFileISO(ztpFileDownloadPath+"/"+gwName+"-"+vpcID+".iso", data.Result)
+	}
+	fileContent, err := processZtpFileContent(data.Result)
+	if err != nil {
+		return err
+	}
+	return createZtpFile(getFileName(ztpFileDownloadPath, gwName, vpcID), fileContent)
+}
+
 func processZtpFileContent(cloudInitTransit string) (string, error) {
 	var jsonCloudInit map[string]interface{}
 	err := json.Unmarshal([]byte(cloudInitTransit), &jsonCloudInit)
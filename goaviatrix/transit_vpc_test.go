@@ -0,0 +1,62 @@
+package goaviatrix
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadEdgeZtpFile(t *testing.T) {
+	isoText := base64.StdEncoding.EncodeToString([]byte("iso-content"))
+	tests := []struct {
+		name        string
+		ztpFileType string
+		response    string
+		fileName    string
+		expected    string
+	}{
+		{
+			name:     "cloud-init",
+			response: `{"return": true, "results": "{\"text\": \"#cloud-config\"}", "reason": ""}`,
+			fileName: "edge-transit-site-1-cloud-init.txt",
+			expected: "#cloud-config",
+		},
+		{
+			name:        "iso",
+			ztpFileType: "iso",
+			response:    `{"return": true, "results": "{\"text\": \"` + isoText + `\"}", "reason": ""}`,
+			fileName:    "edge-transit-site-1.iso",
+			expected:    "iso-content",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+			dir := t.TempDir()
+
+			err := client.DownloadEdgeZtpFile("edge-transit", "site-1", dir, tt.ztpFileType)
+			assert.NoError(t, err)
+			assert.Equal(t, "get_edge_gateway_ztp_file", rt.form.Get("action"))
+			assert.Equal(t, "edge-transit", rt.form.Get("gateway_name"))
+			assert.Equal(t, tt.ztpFileType, rt.form.Get("ztp_file_type"))
+
+			content, err := os.ReadFile(filepath.Join(dir, tt.fileName))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+		})
+	}
+}
+
+func TestDownloadEdgeZtpFileError(t *testing.T) {
+	client, _ := newRecordingClient(`{"return": false, "reason": "Gateway edge-transit does not exist"}`)
+	dir := t.TempDir()
+
+	err := client.DownloadEdgeZtpFile("edge-transit", "site-1", dir, "")
+	assert.ErrorContains(t, err, "does not exist")
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+}
@@ -83,3 +83,20 @@ func (c *Client) GetCompatibleImageVersion(ctx context.Context, cloudType int, s
 	}
 	return data.Results.ImageVersion, nil
 }
+
+// UpgradeGateway upgrades a single gateway to the given software and/or image version.
+// An empty version is omitted so the controller picks the compatible one.
+func (c *Client) UpgradeGateway(gateway *Gateway, softwareVersion, imageVersion string) error {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "upgrade_selected_gateway",
+		"gateway_list": gateway.GwName,
+	}
+	if softwareVersion != "" {
+		form["software_version"] = softwareVersion
+	}
+	if imageVersion != "" {
+		form["image_version"] = imageVersion
+	}
+	return c.PostAPI(form["action"], form, BasicCheck)
+}
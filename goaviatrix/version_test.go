@@ -0,0 +1,54 @@
+package goaviatrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgradeGateway(t *testing.T) {
+	tests := []struct {
+		name            string
+		softwareVersion string
+		imageVersion    string
+		response        string
+		expectError     string
+	}{
+		{
+			name:            "software and image version",
+			softwareVersion: "7.1.3958",
+			imageVersion:    "hvm-cloudx-aws-022021",
+			response:        `{"return": true, "results": "Gateway upgraded", "reason": ""}`,
+		},
+		{
+			name:            "software version only",
+			softwareVersion: "7.1.3958",
+			response:        `{"return": true, "results": "Gateway upgraded", "reason": ""}`,
+		},
+		{
+			name:            "incompatible version",
+			softwareVersion: "6.0.1",
+			response:        `{"return": false, "reason": "Software version 6.0.1 is not compatible"}`,
+			expectError:     "is not compatible",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rt := newRecordingClient(tt.response)
+
+			err := client.UpgradeGateway(&Gateway{GwName: "transit-gw"}, tt.softwareVersion, tt.imageVersion)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "upgrade_selected_gateway", rt.form.Get("action"))
+			assert.Equal(t, "transit-gw", rt.form.Get("gateway_list"))
+			assert.Equal(t, tt.softwareVersion, rt.form.Get("software_version"))
+			assert.Equal(t, tt.imageVersion, rt.form.Get("image_version"))
+			_, sent := rt.form["image_version"]
+			assert.Equal(t, tt.imageVersion != "", sent)
+		})
+	}
+}
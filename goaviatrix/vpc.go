@@ -269,6 +269,53 @@ func (c *Client) GetVpcRouteTableIDs(vpc *Vpc) ([]string, error) {
 	return rtbs, nil
 }
 
+type SubnetRoute struct {
+	Destination string `json:"destination"`
+	Target      string `json:"target"`
+}
+
+type SubnetRouteTable struct {
+	RouteTableID string        `json:"route_table_id"`
+	Routes       []SubnetRoute `json:"routes"`
+}
+
+// HasInternetGatewayDefaultRoute reports whether the route table sends the IPv4 default route to an internet gateway,
+// which makes the subnets associated with it public.
+func (rtb *SubnetRouteTable) HasInternetGatewayDefaultRoute() bool {
+	for _, route := range rtb.Routes {
+		if route.Destination == "0.0.0.0/0" && strings.HasPrefix(route.Target, "igw-") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSubnetRouteTable returns the route table associated with the given subnet of an AWS VPC.
+func (c *Client) GetSubnetRouteTable(vpc *Vpc, subnet string) (*SubnetRouteTable, error) {
+	form := map[string]string{
+		"CID":          c.CID,
+		"action":       "get_subnet_route_table",
+		"vpc_id":       vpc.VpcID,
+		"account_name": vpc.AccountName,
+		"vpc_region":   vpc.Region,
+		"subnet_cidr":  subnet,
+	}
+
+	type Resp struct {
+		Return  bool             `json:"return"`
+		Results SubnetRouteTable `json:"results"`
+		Reason  string           `json:"reason"`
+	}
+	var data Resp
+
+	err := c.GetAPI(&data, form["action"], form, BasicCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	return &data.Results, nil
+}
+
 func (c *Client) UpdateVpc(vpc *Vpc) error {
 	return nil
 }
@@ -77,7 +77,9 @@ SetEnv EDGE_CSP_HA_COMPUTE_NODE_UUID "$(terraform output -raw EDGE_CSP_HA_COMPUT
 
 SetEnv SKIP_CID_EXPIRY "yes"
 SetEnv SKIP_DATA_ACCOUNT "no"
+SetEnv SKIP_DATA_BGP_LAN_IP_LIST "no"
 SetEnv SKIP_DATA_CALLER_IDENTITY "no"
+SetEnv SKIP_DATA_CONTROLLER_ENTITLEMENTS "no"
 SetEnv SKIP_DATA_CONTROLLER_METADATA "no"
 SetEnv SKIP_DATA_DEVICE_INTERFACES "no"
 SetEnv SKIP_DATA_EDGE_GATEWAY_WAN_INTERFACE_DISCOVERY "no"
@@ -88,6 +90,7 @@ SetEnv SKIP_DATA_FIREWALL "no"
 SetEnv SKIP_DATA_FIREWALL_INSTANCE_IMAGES "no"
 SetEnv SKIP_DATA_GATEWAY "no"
 SetEnv SKIP_DATA_GATEWAY_IMAGE "no"
+SetEnv SKIP_DATA_GATEWAYS_PENDING_UPGRADE "no"
 SetEnv SKIP_DATA_NETWORK_DOMAINS "no"
 SetEnv SKIP_DATA_SMART_GROUPS "no"
 SetEnv SKIP_DATA_SPOKE_GATEWAY "no"
@@ -101,6 +104,7 @@ SetEnv SKIP_DATA_TRANSIT_GATEWAY_AWS "no"
 SetEnv SKIP_DATA_TRANSIT_GATEWAY_AZURE "no"
 SetEnv SKIP_DATA_TRANSIT_GATEWAY_GCP "no"
 SetEnv SKIP_DATA_TRANSIT_GATEWAYS "no"
+SetEnv SKIP_DATA_TRANSIT_INSTANCE "no"
 SetEnv SKIP_DATA_VPC "no"
 SetEnv SKIP_DATA_VPC_TRACKER "no"
 SetEnv SKIP_ACCOUNT "no"